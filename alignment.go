@@ -16,6 +16,18 @@ func (a HorizontalAlignment) AlignLeft() bool   { return a == AlignLeft }
 func (a HorizontalAlignment) AlignCenter() bool { return a == AlignCenter }
 func (a HorizontalAlignment) AlignRight() bool  { return a == AlignRight }
 
+// Flip returns the mirrored alignment, swapping AlignLeft and AlignRight.
+func (a HorizontalAlignment) Flip() HorizontalAlignment {
+	switch a {
+	case AlignLeft:
+		return AlignRight
+	case AlignRight:
+		return AlignLeft
+	default:
+		return a
+	}
+}
+
 type VerticalAlignment int
 
 const (
@@ -0,0 +1,239 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"time"
+
+	"github.com/google/gxui/math"
+)
+
+// Animation is a time-based update driven by an Animator, once per frame.
+type Animation interface {
+	// Begin is called before the first Step of the animation, each time the
+	// animation is started.
+	Begin()
+
+	// Step advances the animation by dt. Step returns true once the
+	// animation has finished, along with the part of dt that was not used by
+	// the animation.
+	Step(dt time.Duration) (done bool, remaining time.Duration)
+}
+
+// Tween is an Animation that calls Update with the eased progress of the
+// animation over Duration.
+type Tween struct {
+	Duration time.Duration
+	Easing   Easing          // If nil, EaseLinear is used.
+	Update   func(t float32) // Called with the eased progress. May be nil.
+
+	elapsed time.Duration
+}
+
+// CreateTween returns a new Tween that calls update with the eased progress of
+// the animation over duration.
+func CreateTween(duration time.Duration, easing Easing, update func(t float32)) *Tween {
+	return &Tween{Duration: duration, Easing: easing, Update: update}
+}
+
+// TweenFloat returns a Tween that calls set with values between from and to.
+func TweenFloat(from, to float32, duration time.Duration, easing Easing, set func(float32)) *Tween {
+	return CreateTween(duration, easing, func(t float32) {
+		set(math.Lerpf(from, to, t))
+	})
+}
+
+// TweenPoint returns a Tween that calls set with points between from and to.
+func TweenPoint(from, to math.Point, duration time.Duration, easing Easing, set func(math.Point)) *Tween {
+	return CreateTween(duration, easing, func(t float32) {
+		set(math.Point{X: math.Lerp(from.X, to.X, t), Y: math.Lerp(from.Y, to.Y, t)})
+	})
+}
+
+// TweenColor returns a Tween that calls set with colors between from and to.
+func TweenColor(from, to Color, duration time.Duration, easing Easing, set func(Color)) *Tween {
+	return CreateTween(duration, easing, func(t float32) {
+		set(Color{
+			R: math.Lerpf(from.R, to.R, t),
+			G: math.Lerpf(from.G, to.G, t),
+			B: math.Lerpf(from.B, to.B, t),
+			A: math.Lerpf(from.A, to.A, t),
+		})
+	})
+}
+
+// Delay returns an Animation that does nothing for the duration.
+func Delay(duration time.Duration) *Tween {
+	return CreateTween(duration, nil, nil)
+}
+
+func (t *Tween) update(progress float32) {
+	if t.Update == nil {
+		return
+	}
+	if t.Easing != nil {
+		progress = t.Easing(progress)
+	}
+	t.Update(progress)
+}
+
+// Animation compliance
+func (t *Tween) Begin() {
+	t.elapsed = 0
+	t.update(0)
+}
+
+func (t *Tween) Step(dt time.Duration) (bool, time.Duration) {
+	t.elapsed += dt
+	if t.elapsed >= t.Duration {
+		t.update(1)
+		return true, t.elapsed - t.Duration
+	}
+	t.update(float32(t.elapsed) / float32(t.Duration))
+	return false, 0
+}
+
+// The default physical properties of a Spring, giving a quick, slightly
+// bouncy response.
+const (
+	DefaultSpringStiffness = 170
+	DefaultSpringDamping   = 18
+	DefaultSpringMass      = 1
+)
+
+// springStep is the longest step used to integrate a Spring, as the
+// integration becomes unstable with long steps.
+const springStep = time.Millisecond * 4
+
+// Spring is an Animation that moves Value towards Target as if attached to it
+// by a damped spring. Unlike a Tween, the Target can be changed while the
+// animation is running, and the Value will smoothly change direction.
+type Spring struct {
+	Stiffness float32 // The force pulling Value towards Target per unit of distance.
+	Damping   float32 // The force opposing Velocity per unit of speed.
+	Mass      float32
+	Precision float32 // The distance and speed from the Target considered at rest.
+
+	Value    float32
+	Velocity float32 // Units per second.
+	Target   float32
+
+	Update func(value float32) // Called with the new Value. May be nil.
+}
+
+// CreateSpring returns a new Spring with the default physical properties,
+// moving from the value from to to.
+func CreateSpring(from, to float32, update func(value float32)) *Spring {
+	return &Spring{
+		Stiffness: DefaultSpringStiffness,
+		Damping:   DefaultSpringDamping,
+		Mass:      DefaultSpringMass,
+		Precision: 0.01,
+		Value:     from,
+		Target:    to,
+		Update:    update,
+	}
+}
+
+// AtRest returns true if the Value has reached the Target and stopped.
+func (s *Spring) AtRest() bool {
+	return math.Absf(s.Target-s.Value) <= s.Precision && math.Absf(s.Velocity) <= s.Precision
+}
+
+// Animation compliance
+func (s *Spring) Begin() {}
+
+func (s *Spring) Step(dt time.Duration) (bool, time.Duration) {
+	for dt > 0 && !s.AtRest() {
+		step := dt
+		if step > springStep {
+			step = springStep
+		}
+		dt -= step
+		// Semi-implicit Euler integration.
+		seconds := float32(step.Seconds())
+		force := s.Stiffness*(s.Target-s.Value) - s.Damping*s.Velocity
+		s.Velocity += force / s.Mass * seconds
+		s.Value += s.Velocity * seconds
+	}
+	done := s.AtRest()
+	if done {
+		s.Value, s.Velocity = s.Target, 0
+	}
+	if s.Update != nil {
+		s.Update(s.Value)
+	}
+	return done, dt
+}
+
+// Sequence returns an Animation that runs each of the animations in turn.
+func Sequence(animations ...Animation) Animation {
+	return &sequenceAnimation{animations: animations}
+}
+
+type sequenceAnimation struct {
+	animations []Animation
+	current    int
+}
+
+func (s *sequenceAnimation) Begin() {
+	s.current = 0
+	if len(s.animations) > 0 {
+		s.animations[0].Begin()
+	}
+}
+
+func (s *sequenceAnimation) Step(dt time.Duration) (bool, time.Duration) {
+	for s.current < len(s.animations) {
+		done, remaining := s.animations[s.current].Step(dt)
+		if !done {
+			return false, 0
+		}
+		s.current++
+		if s.current < len(s.animations) {
+			s.animations[s.current].Begin()
+		}
+		dt = remaining
+	}
+	return true, dt
+}
+
+// Parallel returns an Animation that runs all of the animations together,
+// finishing when the last of them finishes.
+func Parallel(animations ...Animation) Animation {
+	return &parallelAnimation{animations: animations, done: make([]bool, len(animations))}
+}
+
+type parallelAnimation struct {
+	animations []Animation
+	done       []bool
+}
+
+func (p *parallelAnimation) Begin() {
+	for i, a := range p.animations {
+		p.done[i] = false
+		a.Begin()
+	}
+}
+
+func (p *parallelAnimation) Step(dt time.Duration) (bool, time.Duration) {
+	allDone, leftover := true, dt
+	for i, a := range p.animations {
+		if p.done[i] {
+			continue
+		}
+		done, remaining := a.Step(dt)
+		p.done[i] = done
+		if !done {
+			allDone = false
+		} else if remaining < leftover {
+			leftover = remaining
+		}
+	}
+	if !allDone {
+		return false, 0
+	}
+	return true, leftover
+}
@@ -0,0 +1,166 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/gxui/math"
+	test "github.com/google/gxui/testing"
+)
+
+type testFrameClock struct {
+	now      time.Time
+	requests []func(time.Time)
+}
+
+func (c *testFrameClock) RequestFrame(f func(time.Time)) {
+	c.requests = append(c.requests, f)
+}
+
+func (c *testFrameClock) advance(dt time.Duration) {
+	c.now = c.now.Add(dt)
+	requests := c.requests
+	c.requests = nil
+	for _, f := range requests {
+		f(c.now)
+	}
+}
+
+func TestEasings(t *testing.T) {
+	for _, e := range []Easing{
+		EaseLinear, EaseInQuad, EaseOutQuad, EaseInOutQuad, EaseInCubic,
+		EaseOutCubic, EaseInOutCubic, EaseInSine, EaseOutSine, EaseInOutSine,
+		EaseOutBack, EaseOutBounce, CreateCubicBezierEasing(0.25, 0.1, 0.25, 1),
+	} {
+		test.AssertEquals(t, true, math.Absf(e(0)) < 1e-5)
+		test.AssertEquals(t, true, math.Absf(e(1)-1) < 1e-5)
+	}
+	linear := CreateCubicBezierEasing(0.5, 0.5, 0.5, 0.5)
+	test.AssertEquals(t, true, math.Absf(linear(0.3)-0.3) < 1e-3)
+	test.AssertEquals(t, float32(0.5), EaseInOutQuad(0.5))
+}
+
+func TestSequenceCarriesRemainingTime(t *testing.T) {
+	var a, b float32
+	s := Sequence(
+		TweenFloat(0, 10, 100*time.Millisecond, nil, func(v float32) { a = v }),
+		TweenFloat(0, 10, 100*time.Millisecond, nil, func(v float32) { b = v }),
+	)
+	s.Begin()
+	done, _ := s.Step(150 * time.Millisecond)
+	test.AssertEquals(t, false, done)
+	test.AssertEquals(t, float32(10), a)
+	test.AssertEquals(t, float32(5), b)
+	done, remaining := s.Step(100 * time.Millisecond)
+	test.AssertEquals(t, true, done)
+	test.AssertEquals(t, 50*time.Millisecond, remaining)
+}
+
+func TestSpringSettlesOnTarget(t *testing.T) {
+	var value float32
+	s := CreateSpring(0, 100, func(v float32) { value = v })
+	s.Begin()
+	done := false
+	for i := 0; i < 600 && !done; i++ {
+		done, _ = s.Step(time.Second / 60)
+	}
+	test.AssertEquals(t, true, done)
+	test.AssertEquals(t, float32(100), value)
+}
+
+func TestAnimator(t *testing.T) {
+	clock := &testFrameClock{now: time.Unix(0, 0)}
+	a := CreateAnimator(clock)
+
+	var value float32
+	completed := false
+	h := a.Start(TweenFloat(0, 1, 100*time.Millisecond, nil, func(v float32) { value = v }))
+	h.OnComplete(func() { completed = true })
+	ticks := 0
+	tick := a.OnFrame(func(time.Duration) { ticks++ })
+	test.AssertEquals(t, 1, len(clock.requests))
+
+	clock.advance(10 * time.Millisecond) // The first frame begins the animations.
+	test.AssertEquals(t, float32(0), value)
+	clock.advance(50 * time.Millisecond)
+	test.AssertEquals(t, float32(0.5), value)
+	clock.advance(50 * time.Millisecond)
+	test.AssertEquals(t, float32(1), value)
+	test.AssertEquals(t, true, completed)
+	test.AssertEquals(t, false, h.Running())
+	test.AssertEquals(t, 3, ticks)
+
+	// Frames are requested until the last animation stops.
+	test.AssertEquals(t, 1, len(clock.requests))
+	tick.Unlisten()
+	test.AssertEquals(t, 0, a.Running())
+	clock.advance(10 * time.Millisecond)
+	test.AssertEquals(t, 0, len(clock.requests))
+	test.AssertEquals(t, 3, ticks)
+
+	// Cancelled animations do not complete.
+	completed = false
+	h = a.Start(Delay(time.Second))
+	h.OnComplete(func() { completed = true })
+	clock.advance(10 * time.Millisecond)
+	h.Cancel()
+	clock.advance(2 * time.Second)
+	test.AssertEquals(t, false, completed)
+	test.AssertEquals(t, 0, len(clock.requests))
+}
+
+type testAttachable struct {
+	Control
+	attached bool
+	onDetach Event
+}
+
+func (c *testAttachable) Attached() bool { return c.attached }
+
+func (c *testAttachable) OnDetach(f func()) EventSubscription {
+	return c.onDetach.Listen(f)
+}
+
+func (c *testAttachable) detach() {
+	c.attached = false
+	c.onDetach.Fire()
+}
+
+func TestAnimatorStartWhileAttached(t *testing.T) {
+	clock := &testFrameClock{now: time.Unix(0, 0)}
+	a := CreateAnimator(clock)
+	c := &testAttachable{onDetach: CreateEvent(func() {})}
+
+	// Animations are not started for detached controls.
+	h := a.StartWhileAttached(c, Delay(time.Second))
+	test.AssertEquals(t, false, h.Running())
+	test.AssertEquals(t, 0, a.Running())
+	test.AssertEquals(t, 0, len(clock.requests))
+
+	c.attached = true
+	completed := false
+	h = a.StartWhileAttached(c, Delay(time.Second))
+	h.OnComplete(func() { completed = true })
+	test.AssertEquals(t, true, h.Running())
+	clock.advance(10 * time.Millisecond)
+
+	// Detaching cancels the animation.
+	c.detach()
+	test.AssertEquals(t, false, h.Running())
+	test.AssertEquals(t, 0, a.Running())
+	clock.advance(2 * time.Second)
+	test.AssertEquals(t, false, completed)
+	test.AssertEquals(t, 0, len(clock.requests))
+
+	// Animations that stop unsubscribe from the control.
+	c.attached = true
+	h = a.StartWhileAttached(c, Delay(time.Second))
+	h.Cancel()
+	a.Start(Delay(time.Second))
+	c.detach()
+	test.AssertEquals(t, 1, a.Running())
+}
@@ -0,0 +1,188 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"time"
+)
+
+// FrameClock is the interface implemented by types that can synchronize
+// updates with the frames they draw, such as Viewport and Window.
+type FrameClock interface {
+	// RequestFrame calls f once, on the UI go-routine, before the next frame
+	// is drawn. f is called with the time of the frame.
+	RequestFrame(f func(frameTime time.Time))
+}
+
+// AnimationHandle controls an Animation started by an Animator. An
+// AnimationHandle is also an EventSubscription, where Unlisten cancels the
+// animation.
+type AnimationHandle struct {
+	animator   *Animator
+	animation  Animation
+	begun      bool
+	running    bool
+	detach     EventSubscription
+	onComplete Event
+}
+
+// Running returns true if the animation has not yet finished or been
+// cancelled.
+func (h *AnimationHandle) Running() bool {
+	return h.running
+}
+
+// Cancel stops the animation where it is, without completing it.
+func (h *AnimationHandle) Cancel() {
+	if h.running {
+		h.stop()
+		h.animator.remove(h)
+	}
+}
+
+// OnComplete subscribes f to be called when the animation finishes. f is not
+// called if the animation is cancelled.
+func (h *AnimationHandle) OnComplete(f func()) EventSubscription {
+	if h.onComplete == nil {
+		h.onComplete = CreateEvent(func() {})
+	}
+	return h.onComplete.Listen(f)
+}
+
+func (h *AnimationHandle) stop() {
+	h.running = false
+	if h.detach != nil {
+		h.detach.Unlisten()
+		h.detach = nil
+	}
+}
+
+// EventSubscription compliance
+func (h *AnimationHandle) Unlisten() {
+	h.Cancel()
+}
+
+// Animator steps a set of running Animations once per frame of a FrameClock.
+// Frames are only requested while there are animations running. All methods
+// must be called on the UI go-routine.
+type Animator struct {
+	clock          FrameClock
+	handles        []*AnimationHandle
+	lastFrame      time.Time
+	frameRequested bool
+}
+
+// CreateAnimator returns a new Animator driven by the clock.
+func CreateAnimator(clock FrameClock) *Animator {
+	return &Animator{clock: clock}
+}
+
+// Start runs the animation from the next frame.
+func (a *Animator) Start(animation Animation) *AnimationHandle {
+	h := &AnimationHandle{animator: a, animation: animation, running: true}
+	a.handles = append(a.handles, h)
+	a.requestFrame()
+	return h
+}
+
+// StartWhileAttached runs the animation from the next frame, cancelling it if
+// c is detached. If c is not attached, the animation is not started.
+func (a *Animator) StartWhileAttached(c Control, animation Animation) *AnimationHandle {
+	if !c.Attached() {
+		return &AnimationHandle{animator: a, animation: animation}
+	}
+	h := a.Start(animation)
+	h.detach = c.OnDetach(h.Cancel)
+	return h
+}
+
+// OnFrame subscribes f to be called on every frame with the time since the
+// last frame. Frames are drawn continuously until the subscription is
+// unlistened. OnFrame can be used with WhileAttached to tick for as long as
+// a control is attached.
+func (a *Animator) OnFrame(f func(dt time.Duration)) EventSubscription {
+	return a.Start(frameAnimation(f))
+}
+
+// Running returns the number of running animations.
+func (a *Animator) Running() int {
+	return len(a.handles)
+}
+
+// CancelAll cancels all of the running animations.
+func (a *Animator) CancelAll() {
+	for _, h := range a.handles {
+		h.stop()
+	}
+	a.handles = nil
+}
+
+func (a *Animator) remove(h *AnimationHandle) {
+	for i, o := range a.handles {
+		if o == h {
+			a.handles = append(a.handles[:i], a.handles[i+1:]...)
+			return
+		}
+	}
+}
+
+func (a *Animator) requestFrame() {
+	if !a.frameRequested {
+		a.frameRequested = true
+		a.clock.RequestFrame(a.frame)
+	}
+}
+
+// frame steps all the running animations to the frame time now.
+func (a *Animator) frame(now time.Time) {
+	a.frameRequested = false
+	if len(a.handles) == 0 {
+		a.lastFrame = time.Time{}
+		return
+	}
+	var dt time.Duration
+	if !a.lastFrame.IsZero() {
+		dt = now.Sub(a.lastFrame)
+	}
+	a.lastFrame = now
+
+	// Animations may start or cancel others as they step.
+	for _, h := range append([]*AnimationHandle{}, a.handles...) {
+		if !h.running {
+			continue
+		}
+		step := dt
+		if !h.begun {
+			// Animations start on the first frame they are stepped.
+			h.begun = true
+			h.animation.Begin()
+			step = 0
+		}
+		if done, _ := h.animation.Step(step); done && h.running {
+			h.stop()
+			a.remove(h)
+			if h.onComplete != nil {
+				h.onComplete.Fire()
+			}
+		}
+	}
+
+	if len(a.handles) > 0 {
+		a.requestFrame()
+	} else {
+		a.lastFrame = time.Time{}
+	}
+}
+
+// frameAnimation is an Animation that never finishes, calling itself each
+// step.
+type frameAnimation func(dt time.Duration)
+
+func (f frameAnimation) Begin() {}
+
+func (f frameAnimation) Step(dt time.Duration) (bool, time.Duration) {
+	f(dt)
+	return false, 0
+}
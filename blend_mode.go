@@ -0,0 +1,21 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+// BlendMode describes how a layer is composited with the content beneath it.
+type BlendMode int
+
+const (
+	// BlendNormal draws the layer over the content beneath it.
+	BlendNormal BlendMode = iota
+	// BlendAdd adds the layer's color to the content beneath it.
+	BlendAdd
+	// BlendMultiply multiplies the content beneath the layer by the layer's
+	// color, darkening it.
+	BlendMultiply
+	// BlendScreen multiplies the inverse of the content beneath the layer by
+	// the inverse of the layer's color, lightening it.
+	BlendScreen
+)
@@ -11,8 +11,25 @@ var DefaultBrush = WhiteBrush
 
 type Brush struct {
 	Color Color
+
+	// Gradient, if non-nil, is used to fill instead of Color.
+	Gradient *Gradient
+
+	// Pattern, if non-nil, is used to fill instead of Color or Gradient.
+	Pattern *Pattern
 }
 
 func CreateBrush(color Color) Brush {
-	return Brush{color}
+	return Brush{Color: color}
+}
+
+// Transparent returns true if the brush would not draw any visible pixels.
+func (b Brush) Transparent() bool {
+	if b.Pattern != nil {
+		return false
+	}
+	if b.Gradient != nil {
+		return b.Gradient.Transparent()
+	}
+	return b.Color.A == 0
 }
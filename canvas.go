@@ -15,12 +15,44 @@ type Canvas interface {
 	Push()
 	Pop()
 	AddClip(math.Rect)
+
+	// Transform multiplies the current transform by the affine matrix m, so
+	// that subsequent draw calls have their coordinates transformed by m
+	// before the existing transform. Push and Pop save and restore the
+	// transform. Clip rectangles added under a rotation or skew are clipped
+	// to their transformed bounding box.
+	Transform(m math.Mat3)
+
+	// PushLayer begins a layer. The draw calls up to the matching PopLayer
+	// are drawn to an offscreen surface which is then composited, as a
+	// single unit, with the opacity and blend mode. PushLayer also saves the
+	// clip and transform like Push.
+	PushLayer(opacity float32, mode BlendMode)
+
+	// PopLayer ends the layer begun by the last call to PushLayer.
+	PopLayer()
+
 	Clear(Color)
 	DrawCanvas(c Canvas, position math.Point)
 	DrawTexture(t Texture, bounds math.Rect)
+	DrawNinePatch(n NinePatch, bounds math.Rect)
 	DrawRunes(font Font, runes []rune, points []math.Point, color Color)
 	DrawLines(Polygon, Pen)
 	DrawPolygon(Polygon, Pen, Brush)
+
+	// DrawPath fills the path with the brush using the path's fill rule, and
+	// then strokes the path's center line with the pen.
+	DrawPath(Path2D, Pen, Brush)
+
 	DrawRect(math.Rect, Brush)
 	DrawRoundedRect(rect math.Rect, tl, tr, bl, br float32, p Pen, b Brush)
+
+	// DrawShadow draws the shadow cast by the rectangle rect with corners
+	// rounded by radius. Only the shadow is drawn, so it is usually followed by
+	// a draw call filling rect.
+	DrawShadow(rect math.Rect, radius float32, s Shadow)
+
+	// BlurBackdrop blurs the content already drawn beneath rect by the blur
+	// radius, for frosted-glass effects behind translucent controls.
+	BlurBackdrop(rect math.Rect, radius float32)
 }
@@ -0,0 +1,95 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+// Command is an action of the application, such as "Save" or "Comment
+// selection", that can be invoked by the key chords bound in a Keymap and by
+// buttons of menus and toolbars.
+type Command struct {
+	id        string
+	label     string
+	enabled   bool
+	handler   func()
+	onChanged Event
+}
+
+// CreateCommand returns a new, enabled command with the unique identifier id,
+// such as "editor.comment", and the label displayed to the user. handler is
+// called whenever the command is executed.
+func CreateCommand(id, label string, handler func()) *Command {
+	if id == "" {
+		panic("Commands must have an identifier")
+	}
+	if handler == nil {
+		panic("Commands must have a handler")
+	}
+	return &Command{
+		id:        id,
+		label:     label,
+		enabled:   true,
+		handler:   handler,
+		onChanged: CreateEvent(func() {}),
+	}
+}
+
+// ID returns the unique identifier of the command.
+func (c *Command) ID() string {
+	return c.id
+}
+
+// Label returns the label displayed to the user.
+func (c *Command) Label() string {
+	return c.label
+}
+
+// SetLabel changes the label displayed to the user, firing OnChanged.
+func (c *Command) SetLabel(label string) {
+	if c.label != label {
+		c.label = label
+		c.onChanged.Fire()
+	}
+}
+
+// IsEnabled returns true if the command can be executed.
+func (c *Command) IsEnabled() bool {
+	return c.enabled
+}
+
+// SetEnabled enables or disables the command, firing OnChanged.
+func (c *Command) SetEnabled(enabled bool) {
+	if c.enabled != enabled {
+		c.enabled = enabled
+		c.onChanged.Fire()
+	}
+}
+
+// Execute calls the command's handler if the command is enabled, returning
+// true if the handler was called.
+func (c *Command) Execute() bool {
+	if !c.enabled {
+		return false
+	}
+	c.handler()
+	return true
+}
+
+// OnChanged subscribes f to be called whenever the label or enabled state of
+// the command changes.
+func (c *Command) OnChanged(f func()) EventSubscription {
+	return c.onChanged.Listen(f)
+}
+
+// BindButton makes the button a control for the command, as used by menus and
+// toolbars. The button displays the command's label, is enabled while the
+// command is enabled, and executes the command when clicked.
+func BindButton(b Button, c *Command) {
+	update := func() {
+		b.SetText(c.Label())
+		b.SetEnabled(c.IsEnabled())
+	}
+	update()
+	c.OnChanged(update)
+	b.OnClick(func(MouseEvent) { c.Execute() })
+}
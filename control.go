@@ -8,6 +8,8 @@ import "github.com/google/gxui/math"
 
 // Control is the interface exposed by all UI control elements.
 type Control interface {
+	RoutedEventTarget
+
 	// Size returns the size of the control. If the control is not attached, then
 	// the returned size is undefined.
 	Size() math.Size
@@ -46,6 +48,43 @@ type Control interface {
 	// max size limits. The parent control may ignore the desired size.
 	DesiredSize(min, max math.Size) math.Size
 
+	// MinSize returns the minimum size of the control set with SetMinSize.
+	MinSize() math.Size
+
+	// SetMinSize sets the minimum size that the control should be laid out
+	// with, issuing a relayout if the size has changed. Parent layouts honor
+	// the minimum size unless there is insufficient space.
+	SetMinSize(math.Size)
+
+	// MaxSize returns the maximum size of the control set with SetMaxSize.
+	// The default maximum size is math.MaxSize.
+	MaxSize() math.Size
+
+	// SetMaxSize sets the maximum size that the control should be laid out
+	// with, issuing a relayout if the size has changed. If the maximum size is
+	// smaller than the minimum size then the minimum size takes precedence.
+	SetMaxSize(math.Size)
+
+	// PreferredSize returns the preferred size of the control set with
+	// SetPreferredSize.
+	PreferredSize() math.Size
+
+	// SetPreferredSize sets the size that the control should be laid out with
+	// in place of the size returned by DesiredSize, issuing a relayout if the
+	// size has changed. A zero width or height leaves that dimension to
+	// DesiredSize.
+	SetPreferredSize(math.Size)
+
+	// LayoutDirection returns the layout direction set with SetLayoutDirection.
+	// Use ResolveLayoutDirection to find the direction used for layout.
+	LayoutDirection() LayoutDirection
+
+	// SetLayoutDirection sets the horizontal direction in which the control
+	// and its descendants lay out their content, issuing a relayout of the
+	// control and its descendants if the direction has changed.
+	// The default is InheritLayoutDirection.
+	SetLayoutDirection(LayoutDirection)
+
 	// Margin returns the desired spacing between sibling controls.
 	Margin() math.Spacing
 
@@ -59,6 +98,29 @@ type Control interface {
 	// SetVisible sets the visibility of the control.
 	SetVisible(bool)
 
+	// IsEnabled returns true if the control and all of its ancestors are
+	// enabled. Disabled controls cannot gain focus, do not receive mouse or
+	// keyboard events and are drawn with the theme's disabled style.
+	IsEnabled() bool
+
+	// SetEnabled enables or disables the control and all of its descendants.
+	// Descendants disabled with SetEnabled remain disabled when the control is
+	// re-enabled.
+	SetEnabled(bool)
+
+	// OnEnabledChanged subscribes f to be called whenever IsEnabled changes,
+	// either by a call to SetEnabled on the control or on one of its
+	// ancestors.
+	OnEnabledChanged(f func()) EventSubscription
+
+	// Opacity returns the opacity of the control, from 0 (fully transparent)
+	// to 1 (fully opaque).
+	Opacity() float32
+
+	// SetOpacity sets the opacity of the control. The control and its
+	// children are faded as a single unit when painted by the parent.
+	SetOpacity(float32)
+
 	// ContainsPoint returns true if the specified local-space point is considered
 	// within the control.
 	ContainsPoint(math.Point) bool
@@ -132,6 +194,63 @@ type Control interface {
 	// repeat-key event while the control (or child control) has focus.
 	KeyRepeat(KeyboardEvent)
 
+	// MouseCaptureLost is called when the control loses the mouse capture
+	// taken with Window.CaptureMouse, either by Window.ReleaseMouse, by another
+	// control capturing the mouse, or by the control being detached or
+	// disabled.
+	MouseCaptureLost()
+
+	// Cursor returns the mouse cursor set with SetCursor.
+	Cursor() Cursor
+
+	// SetCursor sets the mouse cursor shown while the pointer is over the
+	// control. If the cursor is the DefaultCursor, then the cursor of the
+	// control's parent is shown.
+	SetCursor(Cursor)
+
+	// Theme returns the theme the control was created with, or the theme last
+	// set with SetTheme.
+	Theme() Theme
+
+	// SetTheme changes the theme of the control and of its children. The
+	// control re-resolves its styles, fonts and metrics from the theme, and is
+	// relaid out and redrawn. Any children created from the previous theme
+	// that are not needed to preserve the control's state may be recreated.
+	// SetTheme is typically called by Window.SetTheme.
+	SetTheme(Theme)
+
+	// OnThemeChanged subscribes f to be called whenever the theme of the
+	// control is changed with SetTheme.
+	OnThemeChanged(f func()) EventSubscription
+
+	// StyleClasses returns the style class names of the control, in the order
+	// they were added. Themes use the classes to select the control's style.
+	StyleClasses() []string
+
+	// HasStyleClass returns true if the control has the style class.
+	HasStyleClass(class string) bool
+
+	// AddStyleClass adds the style class to the control, issuing a redraw if
+	// the control did not already have the class.
+	AddStyleClass(class string)
+
+	// RemoveStyleClass removes the style class from the control, issuing a
+	// redraw if the control had the class.
+	RemoveStyleClass(class string)
+
+	// StyleOverride returns the changes to the control's style in the state
+	// set with SetStyleOverride.
+	StyleOverride(state StyleState) StyleOverride
+
+	// SetStyleOverride sets changes to the control's style in the state,
+	// which take precedence over the styles of the theme. state must be
+	// StyleDefault or a single state flag.
+	SetStyleOverride(state StyleState, override StyleOverride)
+
+	// OnStyleChanged subscribes f to be called whenever the control's style
+	// classes or overrides change.
+	OnStyleChanged(f func()) EventSubscription
+
 	// OnAttach subscribes f to be called whenever the control is attached.
 	OnAttach(f func()) EventSubscription
 
@@ -189,4 +308,8 @@ type Control interface {
 	// OnKeyRepeat subscribes f to be called whenever the control receives a
 	// key-repeat event.
 	OnKeyRepeat(f func(KeyboardEvent)) EventSubscription
+
+	// OnMouseCaptureLost subscribes f to be called whenever the control loses
+	// the mouse capture.
+	OnMouseCaptureLost(f func()) EventSubscription
 }
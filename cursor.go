@@ -0,0 +1,95 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import "github.com/google/gxui/math"
+
+// CursorShape is the shape of one of the platform's standard mouse cursors.
+type CursorShape int
+
+const (
+	// DefaultCursor uses the cursor of the control's parent, or the arrow if
+	// no ancestor sets a cursor.
+	DefaultCursor CursorShape = iota
+	ArrowCursor
+	IBeamCursor
+	HandCursor
+	CrosshairCursor
+	WaitCursor
+	// HResizeCursor is used to resize horizontally, such as by a vertical
+	// splitter bar.
+	HResizeCursor
+	// VResizeCursor is used to resize vertically, such as by a horizontal
+	// splitter bar.
+	VResizeCursor
+	// NWSEResizeCursor is used to resize diagonally from the top-left or
+	// bottom-right corner.
+	NWSEResizeCursor
+	// NESWResizeCursor is used to resize diagonally from the top-right or
+	// bottom-left corner.
+	NESWResizeCursor
+	MoveCursor
+)
+
+func (s CursorShape) String() string {
+	switch s {
+	case DefaultCursor:
+		return "DefaultCursor"
+	case ArrowCursor:
+		return "ArrowCursor"
+	case IBeamCursor:
+		return "IBeamCursor"
+	case HandCursor:
+		return "HandCursor"
+	case CrosshairCursor:
+		return "CrosshairCursor"
+	case WaitCursor:
+		return "WaitCursor"
+	case HResizeCursor:
+		return "HResizeCursor"
+	case VResizeCursor:
+		return "VResizeCursor"
+	case NWSEResizeCursor:
+		return "NWSEResizeCursor"
+	case NESWResizeCursor:
+		return "NESWResizeCursor"
+	case MoveCursor:
+		return "MoveCursor"
+	}
+	return "CursorShape(?)"
+}
+
+// Cursor is a mouse cursor, either one of the standard shapes or a custom
+// image. The zero Cursor is the DefaultCursor.
+type Cursor struct {
+	// Shape is the standard cursor shape, used if Image is nil.
+	Shape CursorShape
+
+	// Image is the texture of a custom cursor.
+	Image Texture
+
+	// Hotspot is the point of Image, in pixels from the top-left corner, that
+	// is positioned at the mouse pointer.
+	Hotspot math.Point
+}
+
+// CreateCursor returns the standard cursor with the specified shape.
+func CreateCursor(shape CursorShape) Cursor {
+	return Cursor{Shape: shape}
+}
+
+// CreateImageCursor returns a custom cursor that displays the texture, with
+// hotspot positioned at the mouse pointer.
+func CreateImageCursor(image Texture, hotspot math.Point) Cursor {
+	if image == nil {
+		panic("Cannot create an image cursor with a nil texture")
+	}
+	return Cursor{Image: image, Hotspot: hotspot}
+}
+
+// IsDefault returns true if c is the DefaultCursor.
+func (c Cursor) IsDefault() bool {
+	return c.Image == nil && c.Shape == DefaultCursor
+}
@@ -0,0 +1,34 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"testing"
+
+	test "github.com/google/gxui/testing"
+)
+
+type testCursorControl struct {
+	Control
+	cursor Cursor
+}
+
+func (c testCursorControl) Cursor() Cursor { return c.cursor }
+
+func TestControlPointListCursor(t *testing.T) {
+	window := testCursorControl{}
+	splitter := testCursorControl{cursor: CreateCursor(HResizeCursor)}
+	textBox := testCursorControl{cursor: CreateCursor(IBeamCursor)}
+
+	l := ControlPointList{{C: window}, {C: splitter}, {C: textBox}}
+	test.AssertEquals(t, CreateCursor(IBeamCursor), l.Cursor(Cursor{}))
+
+	l = ControlPointList{{C: splitter}, {C: window}}
+	test.AssertEquals(t, CreateCursor(HResizeCursor), l.Cursor(Cursor{}))
+
+	l = ControlPointList{{C: window}}
+	test.AssertEquals(t, CreateCursor(ArrowCursor), l.Cursor(Cursor{}))
+	test.AssertEquals(t, CreateCursor(WaitCursor), l.Cursor(CreateCursor(WaitCursor)))
+}
@@ -48,11 +48,11 @@ func FunctionName(i interface{}) string {
 func BreadcrumbsAt(p Container, pnt math.Point) string {
 	s := reflect.TypeOf(p).String()
 	for _, c := range p.Children() {
-		b := c.Control.Size().Rect().Offset(c.Offset)
-		if b.Contains(pnt) {
+		cp := c.ToChild(pnt)
+		if c.Control.Size().Rect().Contains(cp) {
 			switch t := c.Control.(type) {
 			case Container:
-				return s + " > " + BreadcrumbsAt(t, pnt.Sub(c.Offset))
+				return s + " > " + BreadcrumbsAt(t, cp)
 			default:
 				return s + " > " + reflect.TypeOf(c.Control).String()
 			}
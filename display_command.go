@@ -0,0 +1,178 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"fmt"
+
+	"github.com/google/gxui/math"
+)
+
+// DisplayOp is an enumerator of the Canvas methods recorded by a DisplayList.
+type DisplayOp int
+
+const (
+	DisplayPush DisplayOp = iota
+	DisplayPop
+	DisplayPushLayer
+	DisplayPopLayer
+	DisplayAddClip
+	DisplayTransform
+	DisplayClear
+	DisplayDrawCanvas
+	DisplayDrawTexture
+	DisplayDrawNinePatch
+	DisplayDrawRunes
+	DisplayDrawLines
+	DisplayDrawPolygon
+	DisplayDrawPath
+	DisplayDrawRect
+	DisplayDrawRoundedRect
+	DisplayDrawShadow
+	DisplayBlurBackdrop
+)
+
+var displayOpNames = []string{
+	DisplayPush:            "Push",
+	DisplayPop:             "Pop",
+	DisplayPushLayer:       "PushLayer",
+	DisplayPopLayer:        "PopLayer",
+	DisplayAddClip:         "AddClip",
+	DisplayTransform:       "Transform",
+	DisplayClear:           "Clear",
+	DisplayDrawCanvas:      "DrawCanvas",
+	DisplayDrawTexture:     "DrawTexture",
+	DisplayDrawNinePatch:   "DrawNinePatch",
+	DisplayDrawRunes:       "DrawRunes",
+	DisplayDrawLines:       "DrawLines",
+	DisplayDrawPolygon:     "DrawPolygon",
+	DisplayDrawPath:        "DrawPath",
+	DisplayDrawRect:        "DrawRect",
+	DisplayDrawRoundedRect: "DrawRoundedRect",
+	DisplayDrawShadow:      "DrawShadow",
+	DisplayBlurBackdrop:    "BlurBackdrop",
+}
+
+// String returns the name of the Canvas method.
+func (o DisplayOp) String() string {
+	if o >= 0 && int(o) < len(displayOpNames) {
+		return displayOpNames[o]
+	}
+	return fmt.Sprintf("DisplayOp(%d)", int(o))
+}
+
+// DisplayCommand is a single recorded Canvas call. Only the fields used by the
+// Op's Canvas method are set.
+type DisplayCommand struct {
+	Op DisplayOp
+
+	Rect   math.Rect  // AddClip, DrawTexture, DrawNinePatch, DrawRect, DrawRoundedRect, DrawShadow, BlurBackdrop
+	Point  math.Point // DrawCanvas
+	Matrix math.Mat3  // Transform
+
+	Opacity   float32   // PushLayer
+	BlendMode BlendMode // PushLayer
+
+	Color     Color     // Clear, DrawRunes
+	Canvas    Canvas    // DrawCanvas
+	Texture   Texture   // DrawTexture
+	NinePatch NinePatch // DrawNinePatch
+
+	Font   Font         // DrawRunes
+	Runes  []rune       // DrawRunes
+	Points []math.Point // DrawRunes
+
+	Polygon Polygon // DrawLines, DrawPolygon
+	Path    Path2D  // DrawPath
+	Pen     Pen     // DrawLines, DrawPolygon, DrawPath, DrawRoundedRect
+	Brush   Brush   // DrawPolygon, DrawPath, DrawRect, DrawRoundedRect
+
+	Radii  [4]float32 // DrawRoundedRect: top-left, top-right, bottom-left, bottom-right
+	Radius float32    // DrawShadow, BlurBackdrop
+	Shadow Shadow     // DrawShadow
+}
+
+// Replay calls the command's Canvas method on c.
+func (d DisplayCommand) Replay(c Canvas) {
+	switch d.Op {
+	case DisplayPush:
+		c.Push()
+	case DisplayPop:
+		c.Pop()
+	case DisplayPushLayer:
+		c.PushLayer(d.Opacity, d.BlendMode)
+	case DisplayPopLayer:
+		c.PopLayer()
+	case DisplayAddClip:
+		c.AddClip(d.Rect)
+	case DisplayTransform:
+		c.Transform(d.Matrix)
+	case DisplayClear:
+		c.Clear(d.Color)
+	case DisplayDrawCanvas:
+		c.DrawCanvas(d.Canvas, d.Point)
+	case DisplayDrawTexture:
+		c.DrawTexture(d.Texture, d.Rect)
+	case DisplayDrawNinePatch:
+		c.DrawNinePatch(d.NinePatch, d.Rect)
+	case DisplayDrawRunes:
+		c.DrawRunes(d.Font, d.Runes, d.Points, d.Color)
+	case DisplayDrawLines:
+		c.DrawLines(d.Polygon, d.Pen)
+	case DisplayDrawPolygon:
+		c.DrawPolygon(d.Polygon, d.Pen, d.Brush)
+	case DisplayDrawPath:
+		c.DrawPath(d.Path, d.Pen, d.Brush)
+	case DisplayDrawRect:
+		c.DrawRect(d.Rect, d.Brush)
+	case DisplayDrawRoundedRect:
+		c.DrawRoundedRect(d.Rect, d.Radii[0], d.Radii[1], d.Radii[2], d.Radii[3], d.Pen, d.Brush)
+	case DisplayDrawShadow:
+		c.DrawShadow(d.Rect, d.Radius, d.Shadow)
+	case DisplayBlurBackdrop:
+		c.BlurBackdrop(d.Rect, d.Radius)
+	default:
+		panic(fmt.Errorf("Unknown display op %v", d.Op))
+	}
+}
+
+// String returns the command's Canvas method and arguments.
+func (d DisplayCommand) String() string {
+	switch d.Op {
+	case DisplayPush, DisplayPop, DisplayPopLayer:
+		return d.Op.String()
+	case DisplayPushLayer:
+		return fmt.Sprintf("%v opacity: %v mode: %v", d.Op, d.Opacity, d.BlendMode)
+	case DisplayAddClip:
+		return fmt.Sprintf("%v %v", d.Op, d.Rect)
+	case DisplayBlurBackdrop:
+		return fmt.Sprintf("%v %v radius: %v", d.Op, d.Rect, d.Radius)
+	case DisplayTransform:
+		return fmt.Sprintf("%v %v", d.Op, d.Matrix)
+	case DisplayClear:
+		return fmt.Sprintf("%v %+v", d.Op, d.Color)
+	case DisplayDrawCanvas:
+		return fmt.Sprintf("%v %v size: %v", d.Op, d.Point, d.Canvas.Size())
+	case DisplayDrawTexture:
+		return fmt.Sprintf("%v %v texture: %v", d.Op, d.Rect, d.Texture.SizePixels())
+	case DisplayDrawNinePatch:
+		return fmt.Sprintf("%v %v insets: %v", d.Op, d.Rect, d.NinePatch.Insets)
+	case DisplayDrawRunes:
+		return fmt.Sprintf("%v %q color: %+v", d.Op, string(d.Runes), d.Color)
+	case DisplayDrawLines:
+		return fmt.Sprintf("%v %d vertices pen: %+v", d.Op, len(d.Polygon), d.Pen)
+	case DisplayDrawPolygon:
+		return fmt.Sprintf("%v %d vertices pen: %+v brush: %+v", d.Op, len(d.Polygon), d.Pen, d.Brush)
+	case DisplayDrawPath:
+		return fmt.Sprintf("%v %d segments pen: %+v brush: %+v", d.Op, len(d.Path.Segments), d.Pen, d.Brush)
+	case DisplayDrawRect:
+		return fmt.Sprintf("%v %v brush: %+v", d.Op, d.Rect, d.Brush)
+	case DisplayDrawRoundedRect:
+		return fmt.Sprintf("%v %v radii: %v pen: %+v brush: %+v", d.Op, d.Rect, d.Radii, d.Pen, d.Brush)
+	case DisplayDrawShadow:
+		return fmt.Sprintf("%v %v radius: %v shadow: %+v", d.Op, d.Rect, d.Radius, d.Shadow)
+	}
+	return d.Op.String()
+}
@@ -0,0 +1,223 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/google/gxui/math"
+)
+
+// DisplayList is a Canvas that records its draw calls as a list of
+// DisplayCommands. A DisplayList can be inspected, replayed to another Canvas,
+// or saved with a DisplayListEncoder.
+type DisplayList struct {
+	// Commands are the recorded draw calls, in order.
+	Commands []DisplayCommand
+
+	size       math.Size
+	complete   bool
+	pushCount  int
+	layerCount int
+}
+
+// DisplayListRecorder is the optional interface implemented by Canvases that
+// record their draw calls to a DisplayList.
+type DisplayListRecorder interface {
+	DisplayList() *DisplayList
+}
+
+// CreateDisplayList returns a new, empty DisplayList of the given size in
+// DIPs.
+func CreateDisplayList(size math.Size) *DisplayList {
+	return &DisplayList{size: size}
+}
+
+// DisplayListOf returns the DisplayList of the canvas c, which must be a
+// DisplayList or implement DisplayListRecorder.
+func DisplayListOf(c Canvas) (*DisplayList, bool) {
+	switch c := c.(type) {
+	case *DisplayList:
+		return c, true
+	case DisplayListRecorder:
+		return c.DisplayList(), true
+	}
+	return nil, false
+}
+
+func (l *DisplayList) record(cmd DisplayCommand) {
+	if l.complete {
+		panic(fmt.Errorf("%v() called after Complete()", cmd.Op))
+	}
+	l.Commands = append(l.Commands, cmd)
+}
+
+// Last returns the most recently recorded command.
+func (l *DisplayList) Last() DisplayCommand {
+	return l.Commands[len(l.Commands)-1]
+}
+
+// Replay issues the recorded commands to the canvas c. Nested canvases are
+// drawn to c as they are, so they must be compatible with c.
+func (l *DisplayList) Replay(c Canvas) {
+	for _, cmd := range l.Commands {
+		cmd.Replay(c)
+	}
+}
+
+// String returns a human-readable dump of the commands, indented by their
+// Push and PushLayer depth.
+func (l *DisplayList) String() string {
+	b := &bytes.Buffer{}
+	fmt.Fprintf(b, "DisplayList %v\n", l.size)
+	l.dump(b, 1)
+	return b.String()
+}
+
+func (l *DisplayList) dump(b *bytes.Buffer, depth int) {
+	for _, cmd := range l.Commands {
+		if cmd.Op == DisplayPop || cmd.Op == DisplayPopLayer {
+			depth--
+		}
+		fmt.Fprintf(b, "%s%v\n", strings.Repeat("  ", depth), cmd)
+		if nested, ok := DisplayListOf(cmd.Canvas); ok && cmd.Op == DisplayDrawCanvas {
+			nested.dump(b, depth+1)
+		}
+		if cmd.Op == DisplayPush || cmd.Op == DisplayPushLayer {
+			depth++
+		}
+	}
+}
+
+// gxui.Canvas compliance
+func (l *DisplayList) Size() math.Size {
+	return l.size
+}
+
+func (l *DisplayList) IsComplete() bool {
+	return l.complete
+}
+
+func (l *DisplayList) Complete() {
+	if l.complete {
+		panic("Complete() called twice")
+	}
+	if l.pushCount != 0 {
+		panic(fmt.Errorf("Push() count was %d when calling Complete", l.pushCount))
+	}
+	if l.layerCount != 0 {
+		panic(fmt.Errorf("PushLayer() count was %d when calling Complete", l.layerCount))
+	}
+	l.complete = true
+}
+
+func (l *DisplayList) Push() {
+	l.record(DisplayCommand{Op: DisplayPush})
+	l.pushCount++
+}
+
+func (l *DisplayList) Pop() {
+	if l.pushCount == 0 {
+		panic("Pop() called without a matching Push()")
+	}
+	l.record(DisplayCommand{Op: DisplayPop})
+	l.pushCount--
+}
+
+func (l *DisplayList) PushLayer(opacity float32, mode BlendMode) {
+	l.record(DisplayCommand{Op: DisplayPushLayer, Opacity: opacity, BlendMode: mode})
+	l.layerCount++
+}
+
+func (l *DisplayList) PopLayer() {
+	if l.layerCount == 0 {
+		panic("PopLayer() called without a matching PushLayer()")
+	}
+	l.record(DisplayCommand{Op: DisplayPopLayer})
+	l.layerCount--
+}
+
+func (l *DisplayList) AddClip(r math.Rect) {
+	l.record(DisplayCommand{Op: DisplayAddClip, Rect: r})
+}
+
+func (l *DisplayList) Transform(m math.Mat3) {
+	l.record(DisplayCommand{Op: DisplayTransform, Matrix: m})
+}
+
+func (l *DisplayList) Clear(color Color) {
+	l.record(DisplayCommand{Op: DisplayClear, Color: color})
+}
+
+func (l *DisplayList) DrawCanvas(c Canvas, position math.Point) {
+	if c == nil {
+		panic("Canvas cannot be nil")
+	}
+	l.record(DisplayCommand{Op: DisplayDrawCanvas, Canvas: c, Point: position})
+}
+
+func (l *DisplayList) DrawTexture(t Texture, bounds math.Rect) {
+	if t == nil {
+		panic("Texture cannot be nil")
+	}
+	l.record(DisplayCommand{Op: DisplayDrawTexture, Texture: t, Rect: bounds})
+}
+
+func (l *DisplayList) DrawNinePatch(n NinePatch, bounds math.Rect) {
+	if n.Texture == nil {
+		panic("NinePatch texture cannot be nil")
+	}
+	l.record(DisplayCommand{Op: DisplayDrawNinePatch, NinePatch: n, Rect: bounds})
+}
+
+func (l *DisplayList) DrawRunes(font Font, runes []rune, points []math.Point, color Color) {
+	if font == nil {
+		panic("Font cannot be nil")
+	}
+	l.record(DisplayCommand{
+		Op:     DisplayDrawRunes,
+		Font:   font,
+		Runes:  append([]rune{}, runes...),
+		Points: append([]math.Point{}, points...),
+		Color:  color,
+	})
+}
+
+func (l *DisplayList) DrawLines(lines Polygon, pen Pen) {
+	l.record(DisplayCommand{Op: DisplayDrawLines, Polygon: append(Polygon{}, lines...), Pen: pen})
+}
+
+func (l *DisplayList) DrawPolygon(poly Polygon, pen Pen, brush Brush) {
+	l.record(DisplayCommand{Op: DisplayDrawPolygon, Polygon: append(Polygon{}, poly...), Pen: pen, Brush: brush})
+}
+
+func (l *DisplayList) DrawPath(path Path2D, pen Pen, brush Brush) {
+	path.Segments = append([]PathSegment{}, path.Segments...)
+	l.record(DisplayCommand{Op: DisplayDrawPath, Path: path, Pen: pen, Brush: brush})
+}
+
+func (l *DisplayList) DrawRect(r math.Rect, brush Brush) {
+	l.record(DisplayCommand{Op: DisplayDrawRect, Rect: r, Brush: brush})
+}
+
+func (l *DisplayList) DrawRoundedRect(r math.Rect, tl, tr, bl, br float32, pen Pen, brush Brush) {
+	l.record(DisplayCommand{
+		Op:    DisplayDrawRoundedRect,
+		Rect:  r,
+		Radii: [4]float32{tl, tr, bl, br},
+		Pen:   pen,
+		Brush: brush,
+	})
+}
+
+func (l *DisplayList) DrawShadow(r math.Rect, radius float32, s Shadow) {
+	l.record(DisplayCommand{Op: DisplayDrawShadow, Rect: r, Radius: radius, Shadow: s})
+}
+
+func (l *DisplayList) BlurBackdrop(r math.Rect, radius float32) {
+	l.record(DisplayCommand{Op: DisplayBlurBackdrop, Rect: r, Radius: radius})
+}
@@ -0,0 +1,476 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+
+	"github.com/google/gxui/math"
+)
+
+// The JSON representation of an encoded DisplayList. Textures and fonts are
+// stored once in tables, and referenced by index from the commands. Each
+// command is a JSON object holding the "Op" name and the arguments used by
+// that op.
+type displayListFile struct {
+	Textures []displayTexture
+	Fonts    []displayFont
+	List     displayListJSON
+}
+
+type displayTexture struct {
+	PixelsPerDip float32
+	FlipY        bool
+	PNG          []byte
+}
+
+type displayFont struct {
+	Name string
+	Size int
+}
+
+type displayListJSON struct {
+	Size     math.Size
+	Commands []map[string]interface{}
+}
+
+type displayBrush struct {
+	Color    Color
+	Gradient *Gradient       `json:",omitempty"`
+	Pattern  *displayPattern `json:",omitempty"`
+}
+
+type displayPattern struct {
+	Texture int
+	Offset  math.Point
+}
+
+type displayNinePatch struct {
+	Texture int
+	Insets  math.Spacing
+	Edges   PatchMode
+	Center  PatchMode
+}
+
+// DisplayListEncoder writes DisplayLists to an output stream as JSON.
+// Nested canvases must be DisplayLists or implement DisplayListRecorder, and
+// textures are stored as PNG images.
+type DisplayListEncoder struct {
+	// FontName, if non-nil, returns the name stored for a font, which can be
+	// used by the DisplayListDecoder to find the font again.
+	FontName func(Font) string
+
+	enc      *json.Encoder
+	file     *displayListFile
+	textures map[Texture]int
+	fonts    map[Font]int
+}
+
+// CreateDisplayListEncoder returns a new encoder that writes to w.
+func CreateDisplayListEncoder(w io.Writer) *DisplayListEncoder {
+	return &DisplayListEncoder{enc: json.NewEncoder(w)}
+}
+
+// Encode writes the display list of the canvas c to the stream. c must be a
+// DisplayList or implement DisplayListRecorder.
+func (e *DisplayListEncoder) Encode(c Canvas) error {
+	l, ok := DisplayListOf(c)
+	if !ok {
+		return fmt.Errorf("Canvas of type %T does not record a display list", c)
+	}
+	e.file = &displayListFile{}
+	e.textures = map[Texture]int{}
+	e.fonts = map[Font]int{}
+	list, err := e.list(l)
+	if err != nil {
+		return err
+	}
+	e.file.List = list
+	return e.enc.Encode(e.file)
+}
+
+func (e *DisplayListEncoder) list(l *DisplayList) (displayListJSON, error) {
+	out := displayListJSON{Size: l.Size(), Commands: make([]map[string]interface{}, len(l.Commands))}
+	for i, cmd := range l.Commands {
+		m, err := e.command(cmd)
+		if err != nil {
+			return displayListJSON{}, fmt.Errorf("Command %d (%v): %v", i, cmd.Op, err)
+		}
+		out.Commands[i] = m
+	}
+	return out, nil
+}
+
+func (e *DisplayListEncoder) texture(t Texture) (int, error) {
+	if i, ok := e.textures[t]; ok {
+		return i, nil
+	}
+	b := &bytes.Buffer{}
+	if err := png.Encode(b, t.Image()); err != nil {
+		return 0, err
+	}
+	i := len(e.file.Textures)
+	e.file.Textures = append(e.file.Textures, displayTexture{
+		PixelsPerDip: float32(t.SizePixels().W) / float32(t.Size().W),
+		FlipY:        t.FlipY(),
+		PNG:          b.Bytes(),
+	})
+	e.textures[t] = i
+	return i, nil
+}
+
+func (e *DisplayListEncoder) font(f Font) int {
+	if i, ok := e.fonts[f]; ok {
+		return i
+	}
+	i := len(e.file.Fonts)
+	font := displayFont{Size: f.Size()}
+	if e.FontName != nil {
+		font.Name = e.FontName(f)
+	}
+	e.file.Fonts = append(e.file.Fonts, font)
+	e.fonts[f] = i
+	return i
+}
+
+func (e *DisplayListEncoder) brush(b Brush) (displayBrush, error) {
+	out := displayBrush{Color: b.Color, Gradient: b.Gradient}
+	if b.Pattern != nil {
+		t, err := e.texture(b.Pattern.Texture)
+		if err != nil {
+			return displayBrush{}, err
+		}
+		out.Pattern = &displayPattern{Texture: t, Offset: b.Pattern.Offset}
+	}
+	return out, nil
+}
+
+func (e *DisplayListEncoder) command(cmd DisplayCommand) (map[string]interface{}, error) {
+	m := map[string]interface{}{"Op": cmd.Op.String()}
+	switch cmd.Op {
+	case DisplayPush, DisplayPop, DisplayPopLayer:
+	case DisplayPushLayer:
+		m["Opacity"], m["BlendMode"] = cmd.Opacity, cmd.BlendMode
+	case DisplayAddClip, DisplayDrawRect, DisplayDrawRoundedRect,
+		DisplayDrawShadow, DisplayBlurBackdrop, DisplayDrawTexture, DisplayDrawNinePatch:
+		m["Rect"] = cmd.Rect
+	case DisplayTransform:
+		m["Matrix"] = cmd.Matrix
+	case DisplayClear:
+		m["Color"] = cmd.Color
+	case DisplayDrawCanvas:
+		nested, ok := DisplayListOf(cmd.Canvas)
+		if !ok {
+			return nil, fmt.Errorf("Canvas of type %T does not record a display list", cmd.Canvas)
+		}
+		l, err := e.list(nested)
+		if err != nil {
+			return nil, err
+		}
+		m["Canvas"], m["Point"] = l, cmd.Point
+	case DisplayDrawRunes:
+		m["Font"] = e.font(cmd.Font)
+		m["Runes"], m["Points"], m["Color"] = string(cmd.Runes), cmd.Points, cmd.Color
+	case DisplayDrawLines, DisplayDrawPolygon:
+		m["Polygon"] = cmd.Polygon
+	case DisplayDrawPath:
+		m["Path"] = cmd.Path
+	default:
+		return nil, fmt.Errorf("Unknown display op %v", cmd.Op)
+	}
+
+	// Arguments shared by several ops.
+	switch cmd.Op {
+	case DisplayDrawLines, DisplayDrawPolygon, DisplayDrawPath, DisplayDrawRoundedRect:
+		m["Pen"] = cmd.Pen
+	}
+	switch cmd.Op {
+	case DisplayDrawPolygon, DisplayDrawPath, DisplayDrawRect, DisplayDrawRoundedRect:
+		b, err := e.brush(cmd.Brush)
+		if err != nil {
+			return nil, err
+		}
+		m["Brush"] = b
+	}
+	switch cmd.Op {
+	case DisplayDrawRoundedRect:
+		m["Radii"] = cmd.Radii
+	case DisplayDrawShadow:
+		m["Radius"], m["Shadow"] = cmd.Radius, cmd.Shadow
+	case DisplayBlurBackdrop:
+		m["Radius"] = cmd.Radius
+	case DisplayDrawTexture:
+		t, err := e.texture(cmd.Texture)
+		if err != nil {
+			return nil, err
+		}
+		m["Texture"] = t
+	case DisplayDrawNinePatch:
+		t, err := e.texture(cmd.NinePatch.Texture)
+		if err != nil {
+			return nil, err
+		}
+		n := cmd.NinePatch
+		m["NinePatch"] = displayNinePatch{Texture: t, Insets: n.Insets, Edges: n.Edges, Center: n.Center}
+	}
+	return m, nil
+}
+
+// DisplayListDecoder reads DisplayLists written by a DisplayListEncoder from
+// an input stream. Decoded DisplayLists are complete.
+type DisplayListDecoder struct {
+	// CreateTexture, if non-nil, creates the textures used by the list, for
+	// example Driver.CreateTexture. Otherwise textures are decoded as
+	// textures that only hold their image, which cannot be drawn by a driver.
+	CreateTexture func(img image.Image, pixelsPerDip float32) Texture
+
+	// Font, if non-nil, returns the font used by the list with the name and
+	// size stored by the encoder. Otherwise DrawRunes commands have a nil
+	// Font and cannot be replayed.
+	Font func(name string, size int) (Font, error)
+
+	dec      *json.Decoder
+	textures []Texture
+	fonts    []Font
+}
+
+// CreateDisplayListDecoder returns a new decoder that reads from r.
+func CreateDisplayListDecoder(r io.Reader) *DisplayListDecoder {
+	return &DisplayListDecoder{dec: json.NewDecoder(r)}
+}
+
+// Decode reads the next encoded DisplayList from the stream.
+func (d *DisplayListDecoder) Decode() (*DisplayList, error) {
+	file := struct {
+		Textures []displayTexture
+		Fonts    []displayFont
+		List     json.RawMessage
+	}{}
+	if err := d.dec.Decode(&file); err != nil {
+		return nil, err
+	}
+
+	d.textures = make([]Texture, len(file.Textures))
+	for i, t := range file.Textures {
+		img, err := png.Decode(bytes.NewReader(t.PNG))
+		if err != nil {
+			return nil, fmt.Errorf("Texture %d: %v", i, err)
+		}
+		if d.CreateTexture != nil {
+			d.textures[i] = d.CreateTexture(img, t.PixelsPerDip)
+		} else {
+			d.textures[i] = &imageTexture{img: img, pixelsPerDip: t.PixelsPerDip}
+		}
+		d.textures[i].SetFlipY(t.FlipY)
+	}
+
+	d.fonts = make([]Font, len(file.Fonts))
+	if d.Font != nil {
+		for i, f := range file.Fonts {
+			font, err := d.Font(f.Name, f.Size)
+			if err != nil {
+				return nil, fmt.Errorf("Font %d: %v", i, err)
+			}
+			d.fonts[i] = font
+		}
+	}
+	return d.list(file.List)
+}
+
+func (d *DisplayListDecoder) list(data json.RawMessage) (*DisplayList, error) {
+	in := struct {
+		Size     math.Size
+		Commands []map[string]json.RawMessage
+	}{}
+	if err := json.Unmarshal(data, &in); err != nil {
+		return nil, err
+	}
+	l := CreateDisplayList(in.Size)
+	for i, fields := range in.Commands {
+		cmd, err := d.command(fields)
+		if err != nil {
+			return nil, fmt.Errorf("Command %d: %v", i, err)
+		}
+		// The list is marked complete without calling Complete, so check
+		// that the pushes and pops balance here.
+		switch cmd.Op {
+		case DisplayPush:
+			l.pushCount++
+		case DisplayPop:
+			if l.pushCount == 0 {
+				return nil, fmt.Errorf("Command %d: Pop without a matching Push", i)
+			}
+			l.pushCount--
+		case DisplayPushLayer:
+			l.layerCount++
+		case DisplayPopLayer:
+			if l.layerCount == 0 {
+				return nil, fmt.Errorf("Command %d: PopLayer without a matching PushLayer", i)
+			}
+			l.layerCount--
+		}
+		l.Commands = append(l.Commands, cmd)
+	}
+	if l.pushCount != 0 {
+		return nil, fmt.Errorf("Push count was %d at the end of the list", l.pushCount)
+	}
+	if l.layerCount != 0 {
+		return nil, fmt.Errorf("PushLayer count was %d at the end of the list", l.layerCount)
+	}
+	l.complete = true
+	return l, nil
+}
+
+func (d *DisplayListDecoder) texture(i int) (Texture, error) {
+	if i < 0 || i >= len(d.textures) {
+		return nil, fmt.Errorf("Texture index %d out of range", i)
+	}
+	return d.textures[i], nil
+}
+
+func (d *DisplayListDecoder) command(fields map[string]json.RawMessage) (DisplayCommand, error) {
+	cmd := DisplayCommand{}
+	get := func(name string, v interface{}) error {
+		data, ok := fields[name]
+		if !ok {
+			return fmt.Errorf("%v is missing %s", cmd.Op, name)
+		}
+		return json.Unmarshal(data, v)
+	}
+	var name string
+	if err := get("Op", &name); err != nil {
+		return cmd, err
+	}
+	cmd.Op = -1
+	for op, n := range displayOpNames {
+		if n == name {
+			cmd.Op = DisplayOp(op)
+		}
+	}
+	if cmd.Op < 0 {
+		return cmd, fmt.Errorf("Unknown display op '%s'", name)
+	}
+
+	var errs []error
+	try := func(name string, v interface{}) {
+		errs = append(errs, get(name, v))
+	}
+	switch cmd.Op {
+	case DisplayPushLayer:
+		try("Opacity", &cmd.Opacity)
+		try("BlendMode", &cmd.BlendMode)
+	case DisplayAddClip, DisplayDrawRect, DisplayDrawRoundedRect,
+		DisplayDrawShadow, DisplayBlurBackdrop, DisplayDrawTexture, DisplayDrawNinePatch:
+		try("Rect", &cmd.Rect)
+	case DisplayTransform:
+		try("Matrix", &cmd.Matrix)
+	case DisplayClear:
+		try("Color", &cmd.Color)
+	case DisplayDrawCanvas:
+		var nested json.RawMessage
+		try("Canvas", &nested)
+		try("Point", &cmd.Point)
+		if nested != nil {
+			l, err := d.list(nested)
+			errs = append(errs, err)
+			cmd.Canvas = l
+		}
+	case DisplayDrawRunes:
+		var font int
+		var runes string
+		try("Font", &font)
+		try("Runes", &runes)
+		try("Points", &cmd.Points)
+		try("Color", &cmd.Color)
+		if font < 0 || font >= len(d.fonts) {
+			errs = append(errs, fmt.Errorf("Font index %d out of range", font))
+		} else {
+			cmd.Font = d.fonts[font]
+		}
+		cmd.Runes = []rune(runes)
+	case DisplayDrawLines, DisplayDrawPolygon:
+		try("Polygon", &cmd.Polygon)
+	case DisplayDrawPath:
+		try("Path", &cmd.Path)
+	}
+
+	switch cmd.Op {
+	case DisplayDrawLines, DisplayDrawPolygon, DisplayDrawPath, DisplayDrawRoundedRect:
+		try("Pen", &cmd.Pen)
+	}
+	switch cmd.Op {
+	case DisplayDrawPolygon, DisplayDrawPath, DisplayDrawRect, DisplayDrawRoundedRect:
+		b := displayBrush{}
+		try("Brush", &b)
+		cmd.Brush = Brush{Color: b.Color, Gradient: b.Gradient}
+		if b.Pattern != nil {
+			t, err := d.texture(b.Pattern.Texture)
+			errs = append(errs, err)
+			cmd.Brush.Pattern = &Pattern{Texture: t, Offset: b.Pattern.Offset}
+		}
+	}
+	switch cmd.Op {
+	case DisplayDrawRoundedRect:
+		try("Radii", &cmd.Radii)
+	case DisplayDrawShadow:
+		try("Radius", &cmd.Radius)
+		try("Shadow", &cmd.Shadow)
+	case DisplayBlurBackdrop:
+		try("Radius", &cmd.Radius)
+	case DisplayDrawTexture:
+		var t int
+		try("Texture", &t)
+		tex, err := d.texture(t)
+		errs = append(errs, err)
+		cmd.Texture = tex
+	case DisplayDrawNinePatch:
+		n := displayNinePatch{}
+		try("NinePatch", &n)
+		tex, err := d.texture(n.Texture)
+		errs = append(errs, err)
+		cmd.NinePatch = NinePatch{Texture: tex, Insets: n.Insets, Edges: n.Edges, Center: n.Center}
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return cmd, err
+		}
+	}
+	return cmd, nil
+}
+
+// imageTexture is the Texture used for decoded textures when the
+// DisplayListDecoder has no CreateTexture function.
+type imageTexture struct {
+	img          image.Image
+	pixelsPerDip float32
+	flipY        bool
+}
+
+func (t *imageTexture) Image() image.Image {
+	return t.img
+}
+
+func (t *imageTexture) Size() math.Size {
+	return t.SizePixels().ScaleS(1 / t.pixelsPerDip)
+}
+
+func (t *imageTexture) SizePixels() math.Size {
+	s := t.img.Bounds().Size()
+	return math.Size{W: s.X, H: s.Y}
+}
+
+func (t *imageTexture) FlipY() bool {
+	return t.flipY
+}
+
+func (t *imageTexture) SetFlipY(flipY bool) {
+	t.flipY = flipY
+}
@@ -0,0 +1,172 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/google/gxui/math"
+	test "github.com/google/gxui/testing"
+)
+
+type testFont struct{ size int }
+
+func (f testFont) LoadGlyphs(first, last rune)              {}
+func (f testFont) Size() int                                { return f.size }
+func (f testFont) GlyphMaxSize() math.Size                  { return math.Size{} }
+func (f testFont) Measure(*TextBlock) math.Size             { return math.Size{} }
+func (f testFont) Layout(*TextBlock) (offsets []math.Point) { return nil }
+
+// assertPanics asserts that f panics with the message msg.
+func assertPanics(t *testing.T, msg string, f func()) {
+	defer func() {
+		test.AssertEquals(t, msg, fmt.Sprint(recover()))
+	}()
+	f()
+}
+
+func createTestDisplayList() *DisplayList {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	img.Set(1, 1, color.RGBA{255, 0, 0, 255})
+	tex := &imageTexture{img: img, pixelsPerDip: 2}
+
+	child := CreateDisplayList(math.Size{W: 5, H: 5})
+	child.DrawRect(math.CreateRect(0, 0, 5, 5), CreatePatternBrush(tex))
+	child.Complete()
+
+	path := Path2D{FillRule: EvenOddFill}
+	path.MoveTo(math.Vec2{X: 1, Y: 2})
+	path.CubicTo(math.Vec2{X: 3, Y: 4}, math.Vec2{X: 5, Y: 6}, math.Vec2{X: 7, Y: 8})
+	path.Close()
+
+	l := CreateDisplayList(math.Size{W: 20, H: 10})
+	l.Clear(Gray20)
+	l.Push()
+	l.AddClip(math.CreateRect(1, 1, 19, 9))
+	l.Transform(math.CreateMat3Scale(2, 2))
+	l.PushLayer(0.5, BlendMultiply)
+	l.DrawTexture(tex, math.CreateRect(0, 0, 2, 1))
+	l.DrawCanvas(child, math.Point{X: 3, Y: 4})
+	l.PopLayer()
+	l.Pop()
+	l.DrawRunes(testFont{12}, []rune("hi"), []math.Point{{X: 1, Y: 2}, {X: 3, Y: 2}}, Red)
+	l.DrawPath(path, CreateDashedPen(2, Blue, 1, 3, 4), CreateVerticalGradientBrush(Red, Green))
+	l.DrawRoundedRect(math.CreateRect(2, 2, 8, 8), 1, 2, 3, 4, DefaultPen, WhiteBrush)
+	l.DrawShadow(math.CreateRect(2, 2, 8, 8), 3, CreateShadow(math.Point{Y: 2}, 4, 1, Black))
+	l.DrawNinePatch(CreateNinePatch(tex, math.CreateSpacing(1)), math.CreateRect(0, 0, 10, 10))
+	l.Complete()
+	return l
+}
+
+func TestDisplayListReplay(t *testing.T) {
+	l := createTestDisplayList()
+	replayed := CreateDisplayList(l.Size())
+	l.Replay(replayed)
+	replayed.Complete()
+	test.AssertEquals(t, l.Commands, replayed.Commands)
+}
+
+func TestDisplayListEncodeDecode(t *testing.T) {
+	l := createTestDisplayList()
+	b := &bytes.Buffer{}
+	test.AssertEquals(t, nil, CreateDisplayListEncoder(b).Encode(l))
+
+	d := CreateDisplayListDecoder(b)
+	d.Font = func(name string, size int) (Font, error) { return testFont{size}, nil }
+	decoded, err := d.Decode()
+	test.AssertEquals(t, nil, err)
+	test.AssertEquals(t, true, decoded.IsComplete())
+	test.AssertEquals(t, l.Size(), decoded.Size())
+	test.AssertEquals(t, len(l.Commands), len(decoded.Commands))
+
+	// Textures are decoded once, so compare them separately.
+	tex := decoded.Commands[5].Texture
+	test.AssertEquals(t, math.Size{W: 2, H: 1}, tex.Size())
+	test.AssertEquals(t, color.RGBA{255, 0, 0, 255}, color.RGBAModel.Convert(tex.Image().At(1, 1)))
+	test.AssertEquals(t, tex, decoded.Commands[len(decoded.Commands)-1].NinePatch.Texture)
+
+	nested := decoded.Commands[6].Canvas.(*DisplayList)
+	test.AssertEquals(t, tex, nested.Commands[0].Brush.Pattern.Texture)
+
+	for i, cmd := range decoded.Commands {
+		expected := l.Commands[i]
+		switch cmd.Op {
+		case DisplayDrawTexture, DisplayDrawNinePatch, DisplayDrawCanvas:
+			test.AssertEquals(t, expected.Rect, cmd.Rect)
+			test.AssertEquals(t, expected.Point, cmd.Point)
+		default:
+			test.AssertEquals(t, expected, cmd)
+		}
+	}
+}
+
+func TestDisplayListDecodeErrors(t *testing.T) {
+	for _, data := range []string{
+		`{"List": {"Commands": [{"Op": "Bogus"}]}}`,
+		`{"List": {"Commands": [{"Op": "DrawRect"}]}}`,
+		`{"List": {"Commands": [{"Op": "DrawTexture", "Rect": {}, "Texture": 0}]}}`,
+	} {
+		_, err := CreateDisplayListDecoder(bytes.NewBufferString(data)).Decode()
+		test.AssertEquals(t, true, err != nil)
+	}
+}
+
+func TestDisplayListDecodeUnbalanced(t *testing.T) {
+	for _, c := range []struct{ commands, err string }{
+		{`{"Op": "Pop"}`, "Command 0: Pop without a matching Push"},
+		{`{"Op": "Push"}, {"Op": "Pop"}, {"Op": "Pop"}, {"Op": "Push"}`, "Command 2: Pop without a matching Push"},
+		{`{"Op": "Push"}, {"Op": "Push"}, {"Op": "Pop"}`, "Push count was 1 at the end of the list"},
+		{`{"Op": "PopLayer"}`, "Command 0: PopLayer without a matching PushLayer"},
+		{`{"Op": "PushLayer", "Opacity": 1, "BlendMode": 0}`, "PushLayer count was 1 at the end of the list"},
+	} {
+		data := `{"List": {"Commands": [` + c.commands + `]}}`
+		_, err := CreateDisplayListDecoder(bytes.NewBufferString(data)).Decode()
+		test.AssertEquals(t, true, err != nil)
+		test.AssertEquals(t, c.err, err.Error())
+	}
+}
+
+func TestDisplayListPopPanics(t *testing.T) {
+	l := CreateDisplayList(math.Size{W: 10, H: 10})
+	l.Push()
+	l.Pop()
+	assertPanics(t, "Pop() called without a matching Push()", l.Pop)
+}
+
+func TestDisplayListLayers(t *testing.T) {
+	l := CreateDisplayList(math.Size{W: 10, H: 10})
+	assertPanics(t, "PopLayer() called without a matching PushLayer()", l.PopLayer)
+
+	// Layers and pushes are balanced separately.
+	l.PushLayer(0.5, BlendScreen)
+	l.Push()
+	l.PushLayer(1, BlendNormal)
+	l.PopLayer()
+	l.Pop()
+	assertPanics(t, "Pop() called without a matching Push()", l.Pop)
+	assertPanics(t, "PushLayer() count was 1 when calling Complete", l.Complete)
+	l.PopLayer()
+	l.Complete()
+
+	test.AssertEquals(t, 6, len(l.Commands))
+	test.AssertEquals(t, DisplayCommand{Op: DisplayPushLayer, Opacity: 0.5, BlendMode: BlendScreen}, l.Commands[0])
+	test.AssertEquals(t, DisplayCommand{Op: DisplayPopLayer}, l.Last())
+}
+
+func TestDisplayListString(t *testing.T) {
+	l := CreateDisplayList(math.Size{W: 10, H: 10})
+	l.Push()
+	l.DrawRect(math.CreateRect(0, 0, 10, 10), CreateBrush(Black))
+	l.Pop()
+	l.Complete()
+	test.AssertEquals(t, "DisplayList {10 10}\n"+
+		"  Push\n"+
+		"    DrawRect {{0 0} {10 10}} brush: {Color:{R:0 G:0 B:0 A:1} Gradient:<nil> Pattern:<nil>}\n"+
+		"  Pop\n", l.String())
+}
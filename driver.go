@@ -37,6 +37,13 @@ type Driver interface {
 	// height is 0, then the viewport adopts the current screen resolution.
 	CreateFullscreenViewport(width, height int, name string) Viewport
 
+	// RenderOptions returns the RenderOptions given to new viewports.
+	RenderOptions() RenderOptions
+
+	// SetRenderOptions changes the RenderOptions given to viewports created
+	// after the call. Existing viewports are unaffected.
+	SetRenderOptions(RenderOptions)
+
 	CreateCanvas(math.Size) Canvas
 	CreateTexture(img image.Image, pixelsPerDip float32) Texture
 
@@ -31,9 +31,10 @@ const (
   #endif
 
   uniform sampler2D source;
+  uniform float Opacity;
   varying vec2 vTexcoords;
   void main() {
-    gl_FragColor = texture2D(source, vTexcoords);
+    gl_FragColor = texture2D(source, vTexcoords) * Opacity;
   }`
 
 	vsColorSrc = `
@@ -55,6 +56,161 @@ const (
     gl_FragColor *= gl_FragColor.a; // PMA
   }`
 
+	vsGradientSrc = `
+  attribute vec2 aPosition;
+  varying vec2 vGradient;
+  uniform mat3 mPos;
+  uniform mat3 mGradient;
+  void main() {
+    vec3 pos3 = vec3(aPosition, 1.0);
+    gl_Position = vec4((mPos * pos3).xy, 0.0, 1.0);
+    vGradient = (mGradient * pos3).xy;
+  }`
+
+	fsGradientSrc = `
+  #ifdef GL_ES
+    precision mediump float;
+  #endif
+
+  const int MaxStops = 8;
+  uniform float Radial;
+  uniform float Spread;
+  uniform float StopCount;
+  uniform float StopOffsets[MaxStops];
+  uniform vec4 StopColors[MaxStops];
+  varying vec2 vGradient;
+  void main() {
+    float t = mix(vGradient.x, length(vGradient), Radial);
+    if (Spread > 1.5) {
+      t = 1.0 - abs(mod(t, 2.0) - 1.0); // Reflect
+    } else if (Spread > 0.5) {
+      t = fract(t); // Repeat
+    } else {
+      t = clamp(t, 0.0, 1.0); // Pad
+    }
+    vec4 color = StopColors[0];
+    for (int i = 1; i < MaxStops; i++) {
+      if (float(i) >= StopCount) {
+        break;
+      }
+      float s = StopOffsets[i-1];
+      float e = StopOffsets[i];
+      if (t >= s) {
+        color = mix(StopColors[i-1], StopColors[i], clamp((t - s) / max(e - s, 0.0001), 0.0, 1.0));
+      }
+    }
+    gl_FragColor = color;
+    gl_FragColor *= gl_FragColor.a; // PMA
+  }`
+
+	vsPatternSrc = `
+  attribute vec2 aPosition;
+  varying vec2 vTile;
+  uniform mat3 mPos;
+  uniform mat3 mTile;
+  void main() {
+    vec3 pos3 = vec3(aPosition, 1.0);
+    gl_Position = vec4((mPos * pos3).xy, 0.0, 1.0);
+    vTile = (mTile * pos3).xy;
+  }`
+
+	fsPatternSrc = `
+  #ifdef GL_ES
+    precision mediump float;
+  #endif
+
+  uniform sampler2D source;
+  uniform vec2 UVMin;
+  uniform vec2 UVSize;
+  varying vec2 vTile;
+  void main() {
+    gl_FragColor = texture2D(source, UVMin + fract(vTile) * UVSize);
+  }`
+
+	vsShadowSrc = `
+  attribute vec2 aPosition;
+  varying vec2 vPoint;
+  uniform mat3 mPos;
+  uniform mat3 mPoint;
+  void main() {
+    vec3 pos3 = vec3(aPosition, 1.0);
+    gl_Position = vec4((mPos * pos3).xy, 0.0, 1.0);
+    vPoint = (mPoint * pos3).xy;
+  }`
+
+	// Based on Evan Wallace's "Fast Rounded Rectangle Shadows": the gaussian
+	// blurred rectangle is integrated exactly along X using erf, and
+	// approximated with a few samples along Y.
+	fsShadowSrc = `
+  #ifdef GL_ES
+    precision mediump float;
+  #endif
+
+  uniform vec2 Lower;
+  uniform vec2 Upper;
+  uniform float Sigma;
+  uniform float Corner;
+  uniform vec4 Color;
+  varying vec2 vPoint;
+
+  float gaussian(float x, float sigma) {
+    return exp(-(x * x) / (2.0 * sigma * sigma)) / (2.5066283 * sigma);
+  }
+
+  vec2 erf(vec2 x) {
+    vec2 s = sign(x), a = abs(x);
+    x = 1.0 + (0.278393 + (0.230389 + 0.078108 * (a * a)) * a) * a;
+    x *= x;
+    return s - s / (x * x);
+  }
+
+  float shadowX(float x, float y, vec2 halfSize) {
+    float delta = min(halfSize.y - Corner - abs(y), 0.0);
+    float curved = halfSize.x - Corner + sqrt(max(0.0, Corner * Corner - delta * delta));
+    vec2 integral = 0.5 + 0.5 * erf((x + vec2(-curved, curved)) * (0.7071068 / Sigma));
+    return integral.y - integral.x;
+  }
+
+  void main() {
+    vec2 halfSize = (Upper - Lower) * 0.5;
+    vec2 point = vPoint - (Lower + Upper) * 0.5;
+    float low = point.y - halfSize.y;
+    float high = point.y + halfSize.y;
+    float start = clamp(-3.0 * Sigma, low, high);
+    float end = clamp(3.0 * Sigma, low, high);
+    float step = (end - start) / 4.0;
+    float y = start + step * 0.5;
+    float value = 0.0;
+    for (int i = 0; i < 4; i++) {
+      value += shadowX(point.x, point.y - y, halfSize) * gaussian(y, Sigma) * step;
+      y += step;
+    }
+    gl_FragColor = vec4(Color.rgb * Color.a, Color.a) * value; // PMA
+  }`
+
+	fsBlurSrc = `
+  #ifdef GL_ES
+    precision mediump float;
+  #endif
+
+  const int Taps = 12;
+  uniform sampler2D source;
+  uniform vec2 Step;
+  uniform float Sigma;
+  varying vec2 vTexcoords;
+  void main() {
+    vec4 sum = texture2D(source, vTexcoords);
+    float total = 1.0;
+    for (int i = 1; i <= Taps; i++) {
+      float x = float(i);
+      float w = exp(-(x * x) / (2.0 * Sigma * Sigma));
+      sum += texture2D(source, vTexcoords + Step * x) * w;
+      sum += texture2D(source, vTexcoords - Step * x) * w;
+      total += 2.0 * w;
+    }
+    gl_FragColor = sum / total;
+  }`
+
 	vsFontSrc = `
   attribute vec2 aSrc;
   attribute vec2 aDst;
@@ -80,16 +236,84 @@ const (
   #endif
 
   uniform sampler2D source;
+  uniform float Gamma;
   varying vec2 vSrc;
   varying vec4 vCol;
   varying vec2 vClp;
   void main() {
     vec2 clipping = step(vec2(0.0, 0.0), vClp) * step(vClp, vec2(1.0, 1.0));
-    gl_FragColor  = vCol * texture2D(source, vSrc).aaaa;
+    float coverage = texture2D(source, vSrc).a;
+    // Blending in the display's gamma thins dark glyphs and thickens light
+    // glyphs. Adjust the coverage to compensate.
+    float luminance = dot(vCol.rgb / max(vCol.a, 0.0001), vec3(0.2126, 0.7152, 0.0722));
+    float g = 1.0 / Gamma;
+    coverage = mix(1.0 - pow(1.0 - coverage, g), pow(coverage, g), luminance);
+    gl_FragColor  = vCol * coverage;
     gl_FragColor *= clipping.x * clipping.y;
+  }`
+
+	// Distance field glyphs hold 0.5 on the glyph's edge, rising inside the
+	// glyph. Smoothing is the change in distance across a window pixel.
+	fsDistanceFieldFontSrc = `
+  #ifdef GL_ES
+    precision mediump float;
+  #endif
+
+  uniform sampler2D source;
+  uniform float Gamma;
+  uniform float Smoothing;
+  varying vec2 vSrc;
+  varying vec4 vCol;
+  varying vec2 vClp;
+  void main() {
+    vec2 clipping = step(vec2(0.0, 0.0), vClp) * step(vClp, vec2(1.0, 1.0));
+    float distance = texture2D(source, vSrc).a;
+    float coverage = clamp((distance - 0.5) / Smoothing + 0.5, 0.0, 1.0);
+    float luminance = dot(vCol.rgb / max(vCol.a, 0.0001), vec3(0.2126, 0.7152, 0.0722));
+    float g = 1.0 / Gamma;
+    coverage = mix(1.0 - pow(1.0 - coverage, g), pow(coverage, g), luminance);
+    gl_FragColor  = vCol * coverage;
+    gl_FragColor *= clipping.x * clipping.y;
+  }`
+
+	// LCD glyphs have a coverage per subpixel, which cannot be blended with a
+	// single alpha. Instead they are drawn in two passes: the first darkens
+	// each subpixel by its coverage, the second adds the glyph color.
+	fsLCDFontSrc = `
+  #ifdef GL_ES
+    precision mediump float;
+  #endif
+
+  uniform sampler2D source;
+  uniform float Gamma;
+  uniform float Pass;
+  varying vec2 vSrc;
+  varying vec4 vCol;
+  varying vec2 vClp;
+  void main() {
+    vec2 clipping = step(vec2(0.0, 0.0), vClp) * step(vClp, vec2(1.0, 1.0));
+    vec3 coverage = texture2D(source, vSrc).rgb;
+    vec3 color = vCol.rgb / max(vCol.a, 0.0001);
+    float luminance = dot(color, vec3(0.2126, 0.7152, 0.0722));
+    vec3 g = vec3(1.0 / Gamma);
+    coverage = mix(1.0 - pow(1.0 - coverage, g), pow(coverage, g), luminance);
+    coverage *= vCol.a * clipping.x * clipping.y;
+    float alpha = (coverage.r + coverage.g + coverage.b) / 3.0;
+    if (Pass < 0.5) {
+      gl_FragColor = vec4(coverage, alpha);
+    } else {
+      gl_FragColor = vec4(color * coverage, alpha);
+    }
   }`
 )
 
+// glyphStyle describes the contents of a glyph page.
+type glyphStyle struct {
+	lcd           bool    // The page holds a coverage value for each subpixel.
+	distanceField bool    // The page holds signed distance fields.
+	smoothing     float32 // The change in distance across a window pixel.
+}
+
 type glyphBatch struct {
 	DstRects  []float32
 	SrcRects  []float32
@@ -97,69 +321,75 @@ type glyphBatch struct {
 	ClipRects []float32
 	Indices   []uint16
 	GlyphPage *textureContext
+	Style     glyphStyle
 }
 
+// blurTaps is the number of texture samples either side of the center taken
+// by each pass of the blur shader.
+const blurTaps = 12
+
 type blitter struct {
-	stats       *contextStats
-	quad        *shape
-	copyShader  *shaderProgram
-	colorShader *shaderProgram
-	fontShader  *shaderProgram
-	glyphBatch  glyphBatch
+	stats          *contextStats
+	quad           *shape
+	copyShader     *shaderProgram
+	colorShader    *shaderProgram
+	gradientShader *shaderProgram
+	patternShader  *shaderProgram
+	shadowShader   *shaderProgram
+	blurShader     *shaderProgram
+	fontShader     *shaderProgram
+	lcdFontShader  *shaderProgram
+	sdfFontShader  *shaderProgram
+	glyphBatch     glyphBatch
 }
 
 func newBlitter(ctx *context, stats *contextStats) *blitter {
 	return &blitter{
-		stats:       stats,
-		quad:        newQuadShape(),
-		copyShader:  newShaderProgram(ctx, vsCopySrc, fsCopySrc),
-		colorShader: newShaderProgram(ctx, vsColorSrc, fsColorSrc),
-		fontShader:  newShaderProgram(ctx, vsFontSrc, fsFontSrc),
+		stats:          stats,
+		quad:           newQuadShape(),
+		copyShader:     newShaderProgram(ctx, vsCopySrc, fsCopySrc),
+		colorShader:    newShaderProgram(ctx, vsColorSrc, fsColorSrc),
+		gradientShader: newShaderProgram(ctx, vsGradientSrc, fsGradientSrc),
+		patternShader:  newShaderProgram(ctx, vsPatternSrc, fsPatternSrc),
+		shadowShader:   newShaderProgram(ctx, vsShadowSrc, fsShadowSrc),
+		blurShader:     newShaderProgram(ctx, vsCopySrc, fsBlurSrc),
+		fontShader:     newShaderProgram(ctx, vsFontSrc, fsFontSrc),
+		lcdFontShader:  newShaderProgram(ctx, vsFontSrc, fsLCDFontSrc),
+		sdfFontShader:  newShaderProgram(ctx, vsFontSrc, fsDistanceFieldFontSrc),
 	}
 }
 
 func (b *blitter) destroy(ctx *context) {
 	b.copyShader.destroy(ctx)
 	b.colorShader.destroy(ctx)
+	b.gradientShader.destroy(ctx)
+	b.patternShader.destroy(ctx)
+	b.shadowShader.destroy(ctx)
+	b.blurShader.destroy(ctx)
 	b.fontShader.destroy(ctx)
+	b.lcdFontShader.destroy(ctx)
+	b.sdfFontShader.destroy(ctx)
 }
 
 func (b *blitter) blit(ctx *context, tc *textureContext, srcRect, dstRect math.Rect, ds *drawState) {
-	b.commitGlyphs(ctx)
+	b.blitOpacity(ctx, tc, srcRect, dstRect, 1, ds)
+}
 
-	dstRect = dstRect.Offset(ds.OriginPixels)
-	sw, sh := tc.sizePixels.WH()
-	dw, dh := ctx.sizePixels.WH()
+// blitOpacity is like blit, but fades the texture by opacity. The texture must
+// use pre-multiplied alpha unless opacity is 1.
+func (b *blitter) blitOpacity(ctx *context, tc *textureContext, srcRect, dstRect math.Rect, opacity float32, ds *drawState) {
+	b.commitGlyphs(ctx)
 
-	var mUV math.Mat3
-	if tc.flipY {
-		mUV = math.CreateMat3(
-			float32(srcRect.W())/float32(sw), 0, 0,
-			0, -float32(srcRect.H())/float32(sh), 0,
-			float32(srcRect.Min.X)/float32(sw),
-			1.0-float32(srcRect.Min.Y)/float32(sh), 1,
-		)
-	} else {
-		mUV = math.CreateMat3(
-			float32(srcRect.W())/float32(sw), 0, 0,
-			0, float32(srcRect.H())/float32(sh), 0,
-			float32(srcRect.Min.X)/float32(sw),
-			float32(srcRect.Min.Y)/float32(sh), 1,
-		)
-	}
-	mPos := math.CreateMat3(
-		+2.0*float32(dstRect.W())/float32(dw), 0, 0,
-		0, -2.0*float32(dstRect.H())/float32(dh), 0,
-		-1.0+2.0*float32(dstRect.Min.X)/float32(dw),
-		+1.0-2.0*float32(dstRect.Min.Y)/float32(dh), 1,
-	)
+	mUV := uvMatrix(tc, srcRect)
+	mPos := quadToRect(dstRect).Mul(ds.Transform).Mul(windowToNDC(ctx))
 	if !tc.pma {
 		gl.BlendFunc(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA)
 	}
 	b.quad.draw(ctx, b.copyShader, uniformBindings{
-		"source": tc,
-		"mUV":    mUV,
-		"mPos":   mPos,
+		"source":  tc,
+		"mUV":     mUV,
+		"mPos":    mPos,
+		"Opacity": opacity,
 	})
 	if !tc.pma {
 		gl.BlendFunc(gl.ONE, gl.ONE_MINUS_SRC_ALPHA)
@@ -167,12 +397,55 @@ func (b *blitter) blit(ctx *context, tc *textureContext, srcRect, dstRect math.R
 	b.stats.drawCallCount++
 }
 
-func (b *blitter) blitGlyph(ctx *context, tc *textureContext, c gxui.Color, srcRect, dstRect math.Rect, ds *drawState) {
-	dstRect = dstRect.Offset(ds.OriginPixels)
+// blitBlur draws the pixel rectangle rect of the texture to the same rectangle
+// in window space, blurred along the direction of step by a gaussian. step is
+// the distance in pixels between samples and sigma is the standard deviation
+// in multiples of step.
+func (b *blitter) blitBlur(ctx *context, tc *textureContext, rect math.Rect, step math.Vec2, sigma float32, ds *drawState) {
+	b.commitGlyphs(ctx)
+	sw, sh := tc.sizePixels.WH()
+	b.quad.draw(ctx, b.blurShader, uniformBindings{
+		"source": tc,
+		"mUV":    uvMatrix(tc, rect),
+		"mPos":   quadToRect(rect).Mul(ds.Transform).Mul(windowToNDC(ctx)),
+		"Step":   math.Vec2{X: step.X / float32(sw), Y: step.Y / float32(sh)},
+		"Sigma":  sigma,
+	})
+	b.stats.drawCallCount++
+}
 
-	if b.glyphBatch.GlyphPage != tc {
+// blitShadow draws the shadow cast by the rectangle r, in DIPs, with corners
+// rounded by radius.
+func (b *blitter) blitShadow(ctx *context, r math.Rect, radius float32, s gxui.Shadow, ds *drawState) {
+	b.commitGlyphs(ctx)
+	dipsToPixels := ctx.resolution.dipsToPixels()
+	caster := ctx.resolution.rectDipsToPixels(r.Offset(s.Offset).ExpandI(s.Spread))
+	if caster.W() <= 0 || caster.H() <= 0 {
+		return
+	}
+	bounds := ctx.resolution.rectDipsToPixels(s.Bounds(r))
+	// Very small deviations would make the samples along Y miss the edge.
+	sigma := math.Maxf(s.Blur*dipsToPixels/2, 0.5)
+	corner := math.Minf(radius*dipsToPixels, float32(caster.W())/2, float32(caster.H())/2)
+	b.quad.draw(ctx, b.shadowShader, uniformBindings{
+		"mPos":   quadToRect(bounds).Mul(ds.Transform).Mul(windowToNDC(ctx)),
+		"mPoint": quadToRect(bounds),
+		"Lower":  math.Vec2{X: float32(caster.Min.X), Y: float32(caster.Min.Y)},
+		"Upper":  math.Vec2{X: float32(caster.Max.X), Y: float32(caster.Max.Y)},
+		"Sigma":  sigma,
+		"Corner": corner,
+		"Color":  s.Color,
+	})
+	b.stats.drawCallCount++
+}
+
+// blitGlyph adds the glyph to the batch of glyphs drawn by commitGlyphs.
+// style describes the contents of the glyph page tc.
+func (b *blitter) blitGlyph(ctx *context, tc *textureContext, style glyphStyle, c gxui.Color, srcRect, dstRect math.Rect, ds *drawState) {
+	if b.glyphBatch.GlyphPage != tc || b.glyphBatch.Style != style {
 		b.commitGlyphs(ctx)
 		b.glyphBatch.GlyphPage = tc
+		b.glyphBatch.Style = style
 	}
 	i := uint16(len(b.glyphBatch.DstRects)) / 2
 	clip := []float32{
@@ -181,12 +454,11 @@ func (b *blitter) blitGlyph(ctx *context, tc *textureContext, c gxui.Color, srcR
 		float32(ds.ClipPixels.Max.X),
 		float32(ds.ClipPixels.Max.Y),
 	}
-	b.glyphBatch.DstRects = append(b.glyphBatch.DstRects,
-		float32(dstRect.Min.X), float32(dstRect.Min.Y),
-		float32(dstRect.Max.X), float32(dstRect.Min.Y),
-		float32(dstRect.Min.X), float32(dstRect.Max.Y),
-		float32(dstRect.Max.X), float32(dstRect.Max.Y),
-	)
+	for _, p := range []math.Point{dstRect.TL(), dstRect.TR(), dstRect.BL(), dstRect.BR()} {
+		// Transform each corner so that glyphs rotate, scale and skew.
+		v := p.Vec3(1).MulM(ds.Transform)
+		b.glyphBatch.DstRects = append(b.glyphBatch.DstRects, v.X, v.Y)
+	}
 	b.glyphBatch.SrcRects = append(b.glyphBatch.SrcRects,
 		float32(srcRect.Min.X), float32(srcRect.Min.Y),
 		float32(srcRect.Max.X), float32(srcRect.Min.Y),
@@ -216,13 +488,7 @@ func (b *blitter) blitGlyph(ctx *context, tc *textureContext, c gxui.Color, srcR
 func (b *blitter) blitShape(ctx *context, shape shape, color gxui.Color, ds *drawState) {
 	b.commitGlyphs(ctx)
 	dipsToPixels := ctx.resolution.dipsToPixels()
-	dw, dh := ctx.sizePixels.WH()
-	mPos := math.CreateMat3(
-		+2.0*dipsToPixels/float32(dw), 0, 0,
-		0, -2.0*dipsToPixels/float32(dh), 0,
-		-1.0+2.0*float32(ds.OriginPixels.X)/float32(dw),
-		+1.0-2.0*float32(ds.OriginPixels.Y)/float32(dh), 1,
-	)
+	mPos := math.CreateMat3Scale(dipsToPixels, dipsToPixels).Mul(ds.Transform).Mul(windowToNDC(ctx))
 
 	shape.draw(ctx, b.colorShader, uniformBindings{
 		"mPos":  mPos,
@@ -241,16 +507,103 @@ func (b *blitter) blitShape(ctx *context, shape shape, color gxui.Color, ds *dra
 	b.stats.drawCallCount++
 }
 
+// blitStroke draws the shape of a stroke with the color. The segments, joins
+// and caps of strokes overlap, so translucent strokes are drawn with
+// context.drawOnce to avoid darkening the overlaps.
+func (b *blitter) blitStroke(ctx *context, shape shape, color gxui.Color, ds *drawState) {
+	if color.A >= 1 {
+		b.blitShape(ctx, shape, color, ds)
+		return
+	}
+	ctx.drawOnce(ds, func() { b.blitShape(ctx, shape, color, ds) })
+}
+
+// blitGradientShape draws the shape filled with the gradient. bounds is the
+// rectangle, in the shape's coordinate space, that the gradient positions are
+// relative to.
+func (b *blitter) blitGradientShape(ctx *context, shape shape, g *gxui.Gradient, bounds math.Rect, ds *drawState) {
+	b.commitGlyphs(ctx)
+	dipsToPixels := ctx.resolution.dipsToPixels()
+	mPos := math.CreateMat3Scale(dipsToPixels, dipsToPixels).Mul(ds.Transform).Mul(windowToNDC(ctx))
+	min := math.Vec2{X: float32(bounds.Min.X), Y: float32(bounds.Min.Y)}
+	size := math.Vec2{X: float32(bounds.W()), Y: float32(bounds.H())}
+	shape.draw(ctx, b.gradientShader, gradientUniforms(g, mPos, min, size))
+	b.stats.drawCallCount++
+}
+
+// blitGradientRect draws the pixel rectangle dstRect filled with the gradient.
+func (b *blitter) blitGradientRect(ctx *context, dstRect math.Rect, g *gxui.Gradient, ds *drawState) {
+	b.commitGlyphs(ctx)
+	mPos := quadToRect(dstRect).Mul(ds.Transform).Mul(windowToNDC(ctx))
+	// The quad's positions are already normalized to the rectangle.
+	b.quad.draw(ctx, b.gradientShader, gradientUniforms(g, mPos, math.Vec2{}, math.Vec2{X: 1, Y: 1}))
+	b.stats.drawCallCount++
+}
+
+// blitBrushShape draws the shape, with positions in DIPs, filled with the
+// brush. bounds is the rectangle, in DIPs, that gradient positions are
+// relative to.
+func (b *blitter) blitBrushShape(ctx *context, shape shape, brush gxui.Brush, bounds math.Rect, ds *drawState) {
+	switch {
+	case brush.Pattern != nil:
+		b.blitPatternBrush(ctx, shape, math.Mat3Ident, brush.Pattern, ds)
+	case brush.Gradient != nil:
+		b.blitGradientShape(ctx, shape, brush.Gradient, bounds, ds)
+	default:
+		b.blitShape(ctx, shape, brush.Color, ds)
+	}
+}
+
+// blitPatternBrush draws the shape filled with the pattern. toDips maps the
+// shape's positions to DIPs.
+func (b *blitter) blitPatternBrush(ctx *context, shape shape, toDips math.Mat3, p *gxui.Pattern, ds *drawState) {
+	tc := ctx.getOrCreateTextureContext(p.Texture.(*texture))
+	tile := p.Texture.Size()
+	b.blitPattern(ctx, shape, toDips, tc, tc.sizePixels.Rect(),
+		p.Offset.Vec2(), math.Vec2{X: float32(tile.W), Y: float32(tile.H)}, ds)
+}
+
+// blitPattern draws the shape filled with the pixel rectangle src of the
+// texture, repeated every tileDips in each direction starting at originDips.
+// toDips maps the shape's positions to DIPs.
+func (b *blitter) blitPattern(ctx *context, shape shape, toDips math.Mat3, tc *textureContext, src math.Rect, originDips, tileDips math.Vec2, ds *drawState) {
+	b.commitGlyphs(ctx)
+	if tileDips.X <= 0 || tileDips.Y <= 0 {
+		return
+	}
+	dipsToPixels := ctx.resolution.dipsToPixels()
+	sw, sh := tc.sizePixels.WH()
+	uvMin := math.Vec2{X: float32(src.Min.X) / float32(sw), Y: float32(src.Min.Y) / float32(sh)}
+	uvSize := math.Vec2{X: float32(src.W()) / float32(sw), Y: float32(src.H()) / float32(sh)}
+	if tc.flipY {
+		uvMin.Y, uvSize.Y = 1-uvMin.Y, -uvSize.Y
+	}
+	mPos := toDips.
+		Mul(math.CreateMat3Scale(dipsToPixels, dipsToPixels)).
+		Mul(ds.Transform).
+		Mul(windowToNDC(ctx))
+	mTile := toDips.
+		Mul(math.CreateMat3Translate(-originDips.X, -originDips.Y)).
+		Mul(math.CreateMat3Scale(1/tileDips.X, 1/tileDips.Y))
+	if !tc.pma {
+		gl.BlendFunc(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA)
+	}
+	shape.draw(ctx, b.patternShader, uniformBindings{
+		"source": tc,
+		"mPos":   mPos,
+		"mTile":  mTile,
+		"UVMin":  uvMin,
+		"UVSize": uvSize,
+	})
+	if !tc.pma {
+		gl.BlendFunc(gl.ONE, gl.ONE_MINUS_SRC_ALPHA)
+	}
+	b.stats.drawCallCount++
+}
+
 func (b *blitter) blitRect(ctx *context, dstRect math.Rect, color gxui.Color, ds *drawState) {
 	b.commitGlyphs(ctx)
-	dstRect = dstRect.Offset(ds.OriginPixels)
-	dw, dh := ctx.sizePixels.WH()
-	mPos := math.CreateMat3(
-		+2.0*float32(dstRect.W())/float32(dw), 0, 0,
-		0, -2.0*float32(dstRect.H())/float32(dh), 0,
-		-1.0+2.0*float32(dstRect.Min.X)/float32(dw),
-		+1.0-2.0*float32(dstRect.Min.Y)/float32(dh), 1,
-	)
+	mPos := quadToRect(dstRect).Mul(ds.Transform).Mul(windowToNDC(ctx))
 
 	b.quad.draw(ctx, b.colorShader, uniformBindings{
 		"mPos":  mPos,
@@ -259,6 +612,46 @@ func (b *blitter) blitRect(ctx *context, dstRect math.Rect, color gxui.Color, ds
 	b.stats.drawCallCount++
 }
 
+// uvMatrix returns the matrix that maps the unit quad to the texture
+// coordinates of the pixel rectangle r of the texture.
+func uvMatrix(tc *textureContext, r math.Rect) math.Mat3 {
+	sw, sh := tc.sizePixels.WH()
+	if tc.flipY {
+		return math.CreateMat3(
+			float32(r.W())/float32(sw), 0, 0,
+			0, -float32(r.H())/float32(sh), 0,
+			float32(r.Min.X)/float32(sw),
+			1.0-float32(r.Min.Y)/float32(sh), 1,
+		)
+	}
+	return math.CreateMat3(
+		float32(r.W())/float32(sw), 0, 0,
+		0, float32(r.H())/float32(sh), 0,
+		float32(r.Min.X)/float32(sw),
+		float32(r.Min.Y)/float32(sh), 1,
+	)
+}
+
+// windowToNDC returns the matrix that maps window pixel coordinates to
+// normalized device coordinates.
+func windowToNDC(ctx *context) math.Mat3 {
+	dw, dh := ctx.sizePixels.WH()
+	return math.CreateMat3(
+		+2.0/float32(dw), 0, 0,
+		0, -2.0/float32(dh), 0,
+		-1.0, +1.0, 1,
+	)
+}
+
+// quadToRect returns the matrix that maps the unit quad to the rectangle r.
+func quadToRect(r math.Rect) math.Mat3 {
+	return math.CreateMat3(
+		float32(r.W()), 0, 0,
+		0, float32(r.H()), 0,
+		float32(r.Min.X), float32(r.Min.Y), 1,
+	)
+}
+
 func (b *blitter) commit(ctx *context) {
 	b.commitGlyphs(ctx)
 }
@@ -289,12 +682,47 @@ func (b *blitter) commitGlyphs(ctx *context) {
 	)
 	ib := newIndexBuffer(ptUshort, b.glyphBatch.Indices)
 	s := newShape(vb, ib, dmTriangles)
+	gamma := ctx.options.TextGamma
+	if gamma <= 0 {
+		gamma = 1
+	}
 	gl.Disable(gl.SCISSOR_TEST)
-	s.draw(ctx, b.fontShader, uniformBindings{
-		"source": tc,
-		"mDst":   mDst,
-		"mSrc":   mSrc,
-	})
+	switch style := b.glyphBatch.Style; {
+	case style.distanceField:
+		s.draw(ctx, b.sdfFontShader, uniformBindings{
+			"source":    tc,
+			"mDst":      mDst,
+			"mSrc":      mSrc,
+			"Gamma":     gamma,
+			"Smoothing": style.smoothing,
+		})
+	case style.lcd:
+		gl.BlendFunc(gl.ZERO, gl.ONE_MINUS_SRC_COLOR)
+		s.draw(ctx, b.lcdFontShader, uniformBindings{
+			"source": tc,
+			"mDst":   mDst,
+			"mSrc":   mSrc,
+			"Gamma":  gamma,
+			"Pass":   float32(0),
+		})
+		gl.BlendFunc(gl.ONE, gl.ONE)
+		s.draw(ctx, b.lcdFontShader, uniformBindings{
+			"source": tc,
+			"mDst":   mDst,
+			"mSrc":   mSrc,
+			"Gamma":  gamma,
+			"Pass":   float32(1),
+		})
+		gl.BlendFunc(gl.ONE, gl.ONE_MINUS_SRC_ALPHA)
+		b.stats.drawCallCount++
+	default:
+		s.draw(ctx, b.fontShader, uniformBindings{
+			"source": tc,
+			"mDst":   mDst,
+			"mSrc":   mSrc,
+			"Gamma":  gamma,
+		})
+	}
 	gl.Enable(gl.SCISSOR_TEST)
 	b.glyphBatch.GlyphPage = nil
 	b.glyphBatch.DstRects = b.glyphBatch.DstRects[:0]
@@ -27,16 +27,81 @@ func (s *drawStateStack) pop() {
 type canvasOp func(ctx *context, dss *drawStateStack)
 
 type drawState struct {
-	// The below are all in window coordinates
-	ClipPixels   math.Rect
-	OriginPixels math.Point
+	// ClipPixels is in window coordinates.
+	ClipPixels math.Rect
+	// StencilClips are the clips that are not axis-aligned in window
+	// coordinates. ClipPixels holds their bounding boxes, and the stencil
+	// buffer masks out the rest.
+	StencilClips []stencilClip
+	// Transform maps the canvas's local pixel coordinates to window
+	// coordinates.
+	Transform math.Mat3
 }
 
+func newDrawState(clipPixels math.Rect) drawState {
+	return drawState{
+		ClipPixels: clipPixels,
+		Transform:  math.Mat3Ident,
+	}
+}
+
+// transformRect returns the window-space bounding box of the local pixel
+// rectangle r.
+func (ds *drawState) transformRect(r math.Rect) math.Rect {
+	return r.Transform(ds.Transform)
+}
+
+// translation returns the translation of the transform, and true if the
+// transform does nothing but translate.
+func (ds *drawState) translation() (math.Vec2, bool) {
+	m := ds.Transform
+	identity := m[0] == 1 && m[1] == 0 && m[2] == 0 &&
+		m[3] == 0 && m[4] == 1 && m[5] == 0 && m[8] == 1
+	return math.Vec2{X: m[6], Y: m[7]}, identity
+}
+
+// axisAligned returns true if the transform maps rectangles to rectangles
+// with edges parallel to the window's.
+func (ds *drawState) axisAligned() bool {
+	m := ds.Transform
+	return m[1] == 0 && m[3] == 0
+}
+
+// addClip intersects the clip with the local pixel rectangle r.
+func (ds *drawState) addClip(r math.Rect) {
+	ds.ClipPixels = ds.intersectClip(ds.transformRect(r))
+	if !ds.axisAligned() {
+		// Copy the clips on append, as the slice is shared with the draw
+		// states lower in the stack.
+		n := len(ds.StencilClips)
+		ds.StencilClips = append(ds.StencilClips[:n:n], stencilClip{rect: r, transform: ds.Transform})
+	}
+}
+
+// scale returns the factor by which the transform scales lengths, on average.
+func (ds *drawState) scale() float32 {
+	m := ds.Transform
+	return math.Sqrtf(math.Absf(m[0]*m[4] - m[1]*m[3]))
+}
+
+// intersectClip returns the intersection of the clip with r, or an empty
+// rectangle if they do not overlap.
+func (ds *drawState) intersectClip(r math.Rect) math.Rect {
+	c := math.Rect{
+		Min: ds.ClipPixels.Min.Max(r.Min),
+		Max: ds.ClipPixels.Max.Min(r.Max),
+	}
+	if c.W() <= 0 || c.H() <= 0 {
+		return math.Rect{}
+	}
+	return c
+}
+
+// canvas records its draw calls to a gxui.DisplayList, and compiles each
+// recorded command into a canvasOp that draws it with GL.
 type canvas struct {
-	sizeDips          math.Size
-	ops               []canvasOp
-	built             bool
-	buildingPushCount int
+	list *gxui.DisplayList
+	ops  []canvasOp
 }
 
 func newCanvas(sizeDips math.Size) *canvas {
@@ -44,7 +109,7 @@ func newCanvas(sizeDips math.Size) *canvas {
 		panic(fmt.Errorf("Canvas width and height must be positive. Size: %d", sizeDips))
 	}
 	c := &canvas{
-		sizeDips: sizeDips,
+		list: gxui.CreateDisplayList(sizeDips),
 	}
 	return c
 }
@@ -58,146 +123,349 @@ func (c *canvas) draw(ctx *context, dss *drawStateStack) {
 	}
 }
 
-func (c *canvas) appendOp(name string, op canvasOp) {
-	if c.built {
-		panic(fmt.Errorf("%s() called after Complete()", name))
+// compile appends the op for the command last recorded to the display list.
+func (c *canvas) compile() {
+	if op := compileOp(c.list.Last()); op != nil {
+		c.ops = append(c.ops, op)
 	}
-	c.ops = append(c.ops, op)
+}
+
+// gxui.DisplayListRecorder compliance
+func (c *canvas) DisplayList() *gxui.DisplayList {
+	return c.list
 }
 
 // gxui.Canvas compliance
 func (c *canvas) Size() math.Size {
-	return c.sizeDips
+	return c.list.Size()
 }
 
 func (c *canvas) IsComplete() bool {
-	return c.built
+	return c.list.IsComplete()
 }
 
 func (c *canvas) Complete() {
-	if c.built {
-		panic("Complete() called twice")
-	}
-	if c.buildingPushCount != 0 {
-		panic(fmt.Errorf("Push() count was %d when calling Complete", c.buildingPushCount))
-	}
-	c.built = true
+	c.list.Complete()
 }
 
 func (c *canvas) Push() {
-	c.buildingPushCount++
-	c.appendOp("Push", func(ctx *context, dss *drawStateStack) {
-		dss.push(*dss.head())
-	})
+	c.list.Push()
+	c.compile()
 }
 
 func (c *canvas) Pop() {
-	c.buildingPushCount--
-	c.appendOp("Pop", func(ctx *context, dss *drawStateStack) {
-		dss.pop()
-		ctx.apply(dss.head())
-	})
+	c.list.Pop()
+	c.compile()
+}
+
+func (c *canvas) PushLayer(opacity float32, mode gxui.BlendMode) {
+	c.list.PushLayer(opacity, mode)
+	c.compile()
+}
+
+func (c *canvas) PopLayer() {
+	c.list.PopLayer()
+	c.compile()
 }
 
 func (c *canvas) AddClip(r math.Rect) {
-	c.appendOp("AddClip", func(ctx *context, dss *drawStateStack) {
-		ds := dss.head()
-		rectLocalPixels := ctx.resolution.rectDipsToPixels(r)
-		rectWindowPixels := rectLocalPixels.Offset(ds.OriginPixels)
-		ds.ClipPixels = ds.ClipPixels.Intersect(rectWindowPixels)
-		ctx.apply(ds)
-	})
+	c.list.AddClip(r)
+	c.compile()
+}
+
+func (c *canvas) Transform(m math.Mat3) {
+	c.list.Transform(m)
+	c.compile()
 }
 
 func (c *canvas) Clear(color gxui.Color) {
-	c.appendOp("Clear", func(ctx *context, dss *drawStateStack) {
-		gl.ClearColor(
-			color.R,
-			color.G,
-			color.B,
-			color.A,
-		)
-		gl.Clear(gl.COLOR_BUFFER_BIT)
-	})
+	c.list.Clear(color)
+	c.compile()
 }
 
 func (c *canvas) DrawCanvas(cc gxui.Canvas, offsetDips math.Point) {
-	if cc == nil {
-		panic("Canvas cannot be nil")
-	}
-	childCanvas := cc.(*canvas)
-	c.appendOp("DrawCanvas", func(ctx *context, dss *drawStateStack) {
-		offsetPixels := ctx.resolution.pointDipsToPixels(offsetDips)
-		dss.push(*dss.head())
-		ds := dss.head()
-		ds.OriginPixels = ds.OriginPixels.Add(offsetPixels)
-		childCanvas.draw(ctx, dss)
-		dss.pop()
-		ctx.apply(dss.head())
-	})
+	c.list.DrawCanvas(cc, offsetDips)
+	c.compile()
 }
 
 func (c *canvas) DrawRunes(f gxui.Font, r []rune, p []math.Point, col gxui.Color) {
-	if f == nil {
-		panic("Font cannot be nil")
-	}
-	runes := append([]rune{}, r...)
-	points := append([]math.Point{}, p...)
-	c.appendOp("DrawRunes", func(ctx *context, dss *drawStateStack) {
-		f.(*font).DrawRunes(ctx, runes, points, col, dss.head())
-	})
+	c.list.DrawRunes(f, r, p, col)
+	c.compile()
 }
 
 func (c *canvas) DrawLines(lines gxui.Polygon, pen gxui.Pen) {
-	edge := openPolyToShape(lines, pen.Width)
-	c.appendOp("DrawLines", func(ctx *context, dss *drawStateStack) {
-		ds := dss.head()
-		if edge != nil && pen.Color.A > 0 {
-			ctx.blitter.blitShape(ctx, *edge, pen.Color, ds)
-		}
-	})
+	c.list.DrawLines(lines, pen)
+	c.compile()
 }
 
 func (c *canvas) DrawPolygon(poly gxui.Polygon, pen gxui.Pen, brush gxui.Brush) {
-	fill, edge := closedPolyToShape(poly, pen.Width)
-	c.appendOp("DrawPolygon", func(ctx *context, dss *drawStateStack) {
-		ds := dss.head()
-		if fill != nil && brush.Color.A > 0 {
-			ctx.blitter.blitShape(ctx, *fill, brush.Color, ds)
-		}
-		if edge != nil && pen.Color.A > 0 {
-			ctx.blitter.blitShape(ctx, *edge, pen.Color, ds)
-		}
-	})
+	c.list.DrawPolygon(poly, pen, brush)
+	c.compile()
+}
+
+func (c *canvas) DrawPath(path gxui.Path2D, pen gxui.Pen, brush gxui.Brush) {
+	c.list.DrawPath(path, pen, brush)
+	c.compile()
 }
 
 func (c *canvas) DrawRect(r math.Rect, brush gxui.Brush) {
-	c.appendOp("DrawRect", func(ctx *context, dss *drawStateStack) {
-		ctx.blitter.blitRect(ctx, ctx.resolution.rectDipsToPixels(r), brush.Color, dss.head())
-	})
+	c.list.DrawRect(r, brush)
+	c.compile()
 }
 
 func (c *canvas) DrawRoundedRect(r math.Rect, tl, tr, bl, br float32, pen gxui.Pen, brush gxui.Brush) {
-	if tl == 0 && tr == 0 && bl == 0 && br == 0 && pen.Color.A == 0 {
-		c.DrawRect(r, brush)
-		return
-	}
-	p := gxui.Polygon{
-		gxui.PolygonVertex{Position: r.TL(), RoundedRadius: tl},
-		gxui.PolygonVertex{Position: r.TR(), RoundedRadius: tr},
-		gxui.PolygonVertex{Position: r.BR(), RoundedRadius: br},
-		gxui.PolygonVertex{Position: r.BL(), RoundedRadius: bl},
-	}
-	c.DrawPolygon(p, pen, brush)
+	c.list.DrawRoundedRect(r, tl, tr, bl, br, pen, brush)
+	c.compile()
+}
+
+func (c *canvas) DrawNinePatch(n gxui.NinePatch, r math.Rect) {
+	c.list.DrawNinePatch(n, r)
+	c.compile()
+}
+
+func (c *canvas) DrawShadow(r math.Rect, radius float32, s gxui.Shadow) {
+	c.list.DrawShadow(r, radius, s)
+	c.compile()
+}
+
+func (c *canvas) BlurBackdrop(r math.Rect, radius float32) {
+	c.list.BlurBackdrop(r, radius)
+	c.compile()
 }
 
 func (c *canvas) DrawTexture(t gxui.Texture, r math.Rect) {
-	if t == nil {
-		panic("Texture cannot be nil")
+	c.list.DrawTexture(t, r)
+	c.compile()
+}
+
+// compileOp returns the op that draws the display list command, or nil if the
+// command draws nothing. Shapes are built when the op is compiled, rather
+// than each time it is drawn.
+func compileOp(cmd gxui.DisplayCommand) canvasOp {
+	switch cmd.Op {
+	case gxui.DisplayPush:
+		return func(ctx *context, dss *drawStateStack) {
+			dss.push(*dss.head())
+		}
+
+	case gxui.DisplayPop:
+		return func(ctx *context, dss *drawStateStack) {
+			dss.pop()
+			ctx.apply(dss.head())
+		}
+
+	case gxui.DisplayPushLayer:
+		opacity, mode := cmd.Opacity, cmd.BlendMode
+		return func(ctx *context, dss *drawStateStack) {
+			dss.push(*dss.head())
+			ctx.pushLayer(opacity, mode, dss.head())
+		}
+
+	case gxui.DisplayPopLayer:
+		return func(ctx *context, dss *drawStateStack) {
+			dss.pop()
+			ctx.popLayer(dss.head())
+		}
+
+	case gxui.DisplayAddClip:
+		r := cmd.Rect
+		return func(ctx *context, dss *drawStateStack) {
+			ds := dss.head()
+			ds.addClip(ctx.resolution.rectDipsToPixels(r))
+			ctx.apply(ds)
+		}
+
+	case gxui.DisplayTransform:
+		m := cmd.Matrix
+		return func(ctx *context, dss *drawStateStack) {
+			// m is in DIPs, but the draw state transform is in pixels.
+			s := ctx.resolution.dipsToPixels()
+			ds := dss.head()
+			ds.Transform = math.CreateMat3Scale(1/s, 1/s).
+				Mul(m).
+				Mul(math.CreateMat3Scale(s, s)).
+				Mul(ds.Transform)
+		}
+
+	case gxui.DisplayClear:
+		color := cmd.Color
+		return func(ctx *context, dss *drawStateStack) {
+			gl.ClearColor(
+				color.R,
+				color.G,
+				color.B,
+				color.A,
+			)
+			gl.Clear(gl.COLOR_BUFFER_BIT)
+		}
+
+	case gxui.DisplayDrawCanvas:
+		childCanvas, ok := cmd.Canvas.(*canvas)
+		if !ok {
+			// Canvases from other sources, such as decoded display lists,
+			// are replayed to a GL canvas.
+			l, isList := cmd.Canvas.(*gxui.DisplayList)
+			if !isList {
+				panic(fmt.Errorf("Cannot draw canvas of type %T", cmd.Canvas))
+			}
+			childCanvas = newCanvas(l.Size())
+			l.Replay(childCanvas)
+			childCanvas.Complete()
+		}
+		offsetDips := cmd.Point
+		return func(ctx *context, dss *drawStateStack) {
+			offsetPixels := ctx.resolution.pointDipsToPixels(offsetDips)
+			bounds := ctx.resolution.sizeDipsToPixels(childCanvas.Size()).Rect().Offset(offsetPixels)
+			clip := dss.head().ClipPixels
+			bounds = dss.head().transformRect(bounds)
+			if bounds.Max.X <= clip.Min.X || bounds.Min.X >= clip.Max.X ||
+				bounds.Max.Y <= clip.Min.Y || bounds.Min.Y >= clip.Max.Y {
+				// Entirely outside of the clip (or dirty) region.
+				ctx.stats.culledCanvasCount++
+				return
+			}
+			dss.push(*dss.head())
+			ds := dss.head()
+			ds.Transform = math.CreateMat3Translate(float32(offsetPixels.X), float32(offsetPixels.Y)).Mul(ds.Transform)
+			childCanvas.draw(ctx, dss)
+			dss.pop()
+			ctx.apply(dss.head())
+		}
+
+	case gxui.DisplayDrawRunes:
+		f, runes, points, col := cmd.Font.(*font), cmd.Runes, cmd.Points, cmd.Color
+		return func(ctx *context, dss *drawStateStack) {
+			f.DrawRunes(ctx, runes, points, col, dss.head())
+		}
+
+	case gxui.DisplayDrawLines:
+		pen := cmd.Pen
+		edge := openPolyToShape(cmd.Polygon, pen)
+		if edge == nil || pen.Color.A == 0 {
+			return nil
+		}
+		return func(ctx *context, dss *drawStateStack) {
+			ctx.blitter.blitStroke(ctx, *edge, pen.Color, dss.head())
+		}
+
+	case gxui.DisplayDrawPolygon:
+		return polygonOp(cmd.Polygon, cmd.Pen, cmd.Brush)
+
+	case gxui.DisplayDrawPath:
+		pen, brush := cmd.Pen, cmd.Brush
+		fill, edge, bounds := pathToShape(cmd.Path, pen)
+		return func(ctx *context, dss *drawStateStack) {
+			ds := dss.head()
+			if fill != nil && !brush.Transparent() {
+				ctx.blitter.blitBrushShape(ctx, *fill, brush, bounds, ds)
+			}
+			if edge != nil {
+				ctx.blitter.blitStroke(ctx, *edge, pen.Color, ds)
+			}
+		}
+
+	case gxui.DisplayDrawRect:
+		return rectOp(cmd.Rect, cmd.Brush)
+
+	case gxui.DisplayDrawRoundedRect:
+		r, pen, brush := cmd.Rect, cmd.Pen, cmd.Brush
+		tl, tr, bl, br := cmd.Radii[0], cmd.Radii[1], cmd.Radii[2], cmd.Radii[3]
+		if tl == 0 && tr == 0 && bl == 0 && br == 0 && pen.Color.A == 0 {
+			return rectOp(r, brush)
+		}
+		return polygonOp(gxui.Polygon{
+			gxui.PolygonVertex{Position: r.TL(), RoundedRadius: tl},
+			gxui.PolygonVertex{Position: r.TR(), RoundedRadius: tr},
+			gxui.PolygonVertex{Position: r.BR(), RoundedRadius: br},
+			gxui.PolygonVertex{Position: r.BL(), RoundedRadius: bl},
+		}, pen, brush)
+
+	case gxui.DisplayDrawNinePatch:
+		n := cmd.NinePatch
+		src, dst := n.Patches(cmd.Rect)
+		return func(ctx *context, dss *drawStateStack) {
+			ds := dss.head()
+			tc := ctx.getOrCreateTextureContext(n.Texture.(*texture))
+			sizeDips := n.Texture.Size()
+			texelsPerDip := float32(tc.sizePixels.W) / float32(sizeDips.W)
+			for i := range dst {
+				s, d := src[i], dst[i]
+				if d.W() <= 0 || d.H() <= 0 || s.W() <= 0 || s.H() <= 0 {
+					continue
+				}
+				srcPixels := s.ScaleS(texelsPerDip)
+				if n.PatchMode(i) == gxui.PatchStretch {
+					ctx.blitter.blit(ctx, tc, srcPixels, ctx.resolution.rectDipsToPixels(d), ds)
+					continue
+				}
+				// Edges only tile along their length, and stretch across it.
+				tile := math.Vec2{X: float32(s.W()), Y: float32(s.H())}
+				switch i {
+				case 1, 7:
+					tile.Y = float32(d.H())
+				case 3, 5:
+					tile.X = float32(d.W())
+				}
+				ctx.blitter.blitPattern(ctx, *ctx.blitter.quad, quadToRect(d), tc, srcPixels, d.Min.Vec2(), tile, ds)
+			}
+		}
+
+	case gxui.DisplayDrawShadow:
+		r, radius, s := cmd.Rect, cmd.Radius, cmd.Shadow
+		if s.Transparent() {
+			return nil
+		}
+		return func(ctx *context, dss *drawStateStack) {
+			ctx.blitter.blitShadow(ctx, r, radius, s, dss.head())
+		}
+
+	case gxui.DisplayBlurBackdrop:
+		r, radius := cmd.Rect, cmd.Radius
+		return func(ctx *context, dss *drawStateStack) {
+			ds := dss.head()
+			rectWindowPixels := ds.transformRect(ctx.resolution.rectDipsToPixels(r))
+			ctx.blurBackdrop(rectWindowPixels, radius*ctx.resolution.dipsToPixels(), ds)
+		}
+
+	case gxui.DisplayDrawTexture:
+		t, r := cmd.Texture.(*texture), cmd.Rect
+		return func(ctx *context, dss *drawStateStack) {
+			tc := ctx.getOrCreateTextureContext(t)
+			ctx.blitter.blit(ctx, tc, tc.sizePixels.Rect(), ctx.resolution.rectDipsToPixels(r), dss.head())
+		}
 	}
+	panic(fmt.Errorf("Unknown display op %v", cmd.Op))
+}
+
+func polygonOp(poly gxui.Polygon, pen gxui.Pen, brush gxui.Brush) canvasOp {
+	fill, edge := closedPolyToShape(poly, pen)
+	var bounds math.Rect
+	if len(poly) > 0 {
+		bounds = math.Rect{Min: poly[0].Position, Max: poly[0].Position}
+		for _, v := range poly[1:] {
+			bounds = bounds.Union(math.Rect{Min: v.Position, Max: v.Position})
+		}
+	}
+	return func(ctx *context, dss *drawStateStack) {
+		ds := dss.head()
+		if fill != nil && !brush.Transparent() {
+			ctx.blitter.blitBrushShape(ctx, *fill, brush, bounds, ds)
+		}
+		if edge != nil && pen.Color.A > 0 {
+			ctx.blitter.blitStroke(ctx, *edge, pen.Color, ds)
+		}
+	}
+}
 
-	c.appendOp("DrawTexture", func(ctx *context, dss *drawStateStack) {
-		tc := ctx.getOrCreateTextureContext(t.(*texture))
-		ctx.blitter.blit(ctx, tc, tc.sizePixels.Rect(), ctx.resolution.rectDipsToPixels(r), dss.head())
-	})
+func rectOp(r math.Rect, brush gxui.Brush) canvasOp {
+	return func(ctx *context, dss *drawStateStack) {
+		switch {
+		case brush.Pattern != nil:
+			ctx.blitter.blitPatternBrush(ctx, *ctx.blitter.quad, quadToRect(r), brush.Pattern, dss.head())
+		case brush.Gradient != nil:
+			ctx.blitter.blitGradientRect(ctx, ctx.resolution.rectDipsToPixels(r), brush.Gradient, dss.head())
+		default:
+			ctx.blitter.blitRect(ctx, ctx.resolution.rectDipsToPixels(r), brush.Color, dss.head())
+		}
+	}
 }
@@ -0,0 +1,75 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl
+
+import (
+	"testing"
+
+	"github.com/google/gxui/math"
+	test "github.com/google/gxui/testing"
+)
+
+func TestDrawStateTranslation(t *testing.T) {
+	ds := newDrawState(math.Rect{})
+	ds.Transform = math.CreateMat3Translate(1.5, 2).Mul(math.CreateMat3Translate(3, 4))
+	translation, ok := ds.translation()
+	test.AssertEquals(t, true, ok)
+	test.AssertEquals(t, math.Vec2{X: 4.5, Y: 6}, translation)
+
+	ds.Transform = math.CreateMat3Scale(2, 2).Mul(math.CreateMat3Translate(3, 4))
+	_, ok = ds.translation()
+	test.AssertEquals(t, false, ok)
+}
+
+func TestDrawStateAddClip(t *testing.T) {
+	ds := newDrawState(math.CreateRect(0, 0, 100, 100))
+	ds.Transform = math.CreateMat3Scale(-1, 2).Mul(math.CreateMat3Translate(50, 10))
+	ds.addClip(math.CreateRect(0, 0, 10, 10))
+	test.AssertEquals(t, math.CreateRect(40, 10, 50, 30), ds.ClipPixels)
+	test.AssertEquals(t, 0, len(ds.StencilClips))
+
+	// The scissor only clips to the bounding box of a skewed clip, so the
+	// stencil clips the rest.
+	ds = newDrawState(math.CreateRect(0, 0, 100, 100))
+	ds.Transform = math.CreateMat3Skew(1, 0)
+	ds.addClip(math.CreateRect(0, 0, 10, 10))
+	test.AssertEquals(t, math.CreateRect(0, 0, 20, 10), ds.ClipPixels)
+	test.AssertEquals(t, []stencilClip{
+		{rect: math.CreateRect(0, 0, 10, 10), transform: ds.Transform},
+	}, ds.StencilClips)
+}
+
+func TestDrawStateAddClipDoesNotAlias(t *testing.T) {
+	parent := newDrawState(math.CreateRect(0, 0, 100, 100))
+	parent.Transform = math.CreateMat3Rotate(0.5)
+	parent.addClip(math.CreateRect(0, 0, 50, 50))
+
+	a, b := parent, parent
+	a.addClip(math.CreateRect(0, 0, 10, 10))
+	b.addClip(math.CreateRect(0, 0, 20, 20))
+	test.AssertEquals(t, 1, len(parent.StencilClips))
+	test.AssertEquals(t, math.CreateRect(0, 0, 10, 10), a.StencilClips[1].rect)
+	test.AssertEquals(t, math.CreateRect(0, 0, 20, 20), b.StencilClips[1].rect)
+}
+
+func TestStencilPrefix(t *testing.T) {
+	clip := func(w int) stencilClip {
+		return stencilClip{rect: math.CreateRect(0, 0, w, w), transform: math.CreateMat3Rotate(1)}
+	}
+	for _, c := range []struct {
+		have, want []stencilClip
+		expected   int
+	}{
+		{nil, nil, 0},
+		{nil, []stencilClip{clip(1)}, 0},
+		{[]stencilClip{clip(1)}, nil, 0},
+		{[]stencilClip{clip(1), clip(2)}, []stencilClip{clip(1)}, 1},
+		{[]stencilClip{clip(1)}, []stencilClip{clip(1), clip(2)}, 1},
+		{[]stencilClip{clip(1), clip(2)}, []stencilClip{clip(1), clip(3)}, 1},
+		{[]stencilClip{clip(1), clip(2)}, []stencilClip{clip(1), clip(2)}, 2},
+	} {
+		test.AssertEquals(t, c.expected, stencilPrefix(c.have, c.want))
+	}
+}
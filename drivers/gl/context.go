@@ -5,6 +5,7 @@
 package gl
 
 import (
+	"github.com/google/gxui"
 	"github.com/google/gxui/math"
 
 	"github.com/goxjs/gl"
@@ -19,13 +20,20 @@ type contextResource struct {
 type context struct {
 	blitter              *blitter
 	resolution           resolution
+	options              gxui.RenderOptions
 	stats                contextStats
 	textureContexts      map[*texture]*textureContext
 	vertexStreamContexts map[*vertexStream]*vertexStreamContext
 	indexBufferContexts  map[*indexBuffer]*indexBufferContext
+	fonts                map[*font]bool // The fonts drawn since the resolution last changed.
 	sizeDips, sizePixels math.Size
 	clip                 math.Rect
+	windowStencilClips   []stencilClip // The stencil clips held by the window's stencil buffer.
+	stencilDepth         int           // The number of stencil clips tested, or -1 if unknown.
 	frame                int
+	framebuffer          *framebuffer   // The bound render target, or nil for the window.
+	layers               []layer        // The stack of layers begun with pushLayer.
+	freeLayers           []*framebuffer // Framebuffers available for reuse by layers and effects.
 }
 
 func newContext() *context {
@@ -33,6 +41,7 @@ func newContext() *context {
 		textureContexts:      make(map[*texture]*textureContext),
 		vertexStreamContexts: make(map[*vertexStream]*vertexStreamContext),
 		indexBufferContexts:  make(map[*indexBuffer]*indexBufferContext),
+		fonts:                make(map[*font]bool),
 	}
 	ctx.blitter = newBlitter(ctx, &ctx.stats)
 	return ctx
@@ -54,21 +63,53 @@ func (c *context) destroy() {
 		ic.destroy()
 		c.stats.indexBufferCount--
 	}
+	for _, fb := range c.freeLayers {
+		fb.destroy()
+	}
+	c.freeLayers = nil
+	c.releaseFonts()
 	c.blitter.destroy(c)
 	c.blitter = nil
 }
 
-func (c *context) beginDraw(sizeDips, sizePixels math.Size) {
+func (c *context) beginDraw(sizeDips, sizePixels math.Size, options gxui.RenderOptions) {
 	dipsToPixels := float32(sizePixels.W) / float32(sizeDips.W)
 
+	c.options = options
 	c.sizeDips = sizeDips
 	c.sizePixels = sizePixels
-	c.resolution = resolution(dipsToPixels*65536 + 0.5)
+	res := resolution(dipsToPixels*65536 + 0.5)
+	if c.resolution != res {
+		// The viewport has moved to a monitor with a different density, or has
+		// been rescaled. The glyphs are rasterized again for the new
+		// resolution, so release those of the old.
+		c.releaseFonts()
+		c.resolution = res
+	}
 
 	c.stats.drawCallCount = 0
+	c.stats.culledCanvasCount = 0
+	c.stats.layerCount = 0
 	c.stats.timer("Frame").start()
 }
 
+// useFont records that the font is drawn at the context's resolution.
+func (c *context) useFont(f *font) {
+	if !c.fonts[f] {
+		c.fonts[f] = true
+		f.acquireGlyphTables(c.resolution)
+	}
+}
+
+// releaseFonts releases the fonts drawn at the context's resolution. Glyph
+// tables still used by other contexts at the resolution are kept.
+func (c *context) releaseFonts() {
+	for f := range c.fonts {
+		f.releaseGlyphTables(c.resolution)
+	}
+	c.fonts = make(map[*font]bool)
+}
+
 func (c *context) endDraw() {
 	// Reap any unused resources
 	for texture, tc := range c.textureContexts {
@@ -140,4 +181,15 @@ func (c *context) apply(ds *drawState) {
 		rs := r.Size()
 		gl.Scissor(int32(r.Min.X), int32(vs.H)-int32(r.Max.Y), int32(rs.W), int32(rs.H))
 	}
+	c.applyStencil(ds)
+}
+
+// bindFramebuffer makes fb the render target. A nil fb targets the window.
+func (c *context) bindFramebuffer(fb *framebuffer) {
+	if fb != nil {
+		fb.bind()
+	} else {
+		gl.BindFramebuffer(gl.FRAMEBUFFER, gl.Framebuffer{})
+	}
+	c.framebuffer = fb
 }
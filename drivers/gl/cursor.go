@@ -0,0 +1,78 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !js
+
+package gl
+
+import (
+	glfw33 "github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/google/gxui"
+	"github.com/goxjs/glfw"
+)
+
+// standardCursor returns the glfw shape for the standard cursor shape, falling
+// back to the arrow for shapes glfw does not provide.
+func standardCursor(shape gxui.CursorShape) glfw33.StandardCursor {
+	switch shape {
+	case gxui.IBeamCursor:
+		return glfw33.IBeamCursor
+	case gxui.HandCursor:
+		return glfw33.HandCursor
+	case gxui.CrosshairCursor, gxui.MoveCursor:
+		return glfw33.CrosshairCursor
+	case gxui.HResizeCursor:
+		return glfw33.HResizeCursor
+	case gxui.VResizeCursor:
+		return glfw33.VResizeCursor
+	default:
+		return glfw33.ArrowCursor
+	}
+}
+
+// standardCursors holds the standard cursors shared by all viewports. They
+// live until the driver terminates.
+type standardCursors map[glfw33.StandardCursor]*glfw33.Cursor
+
+func (s *standardCursors) get(shape gxui.CursorShape) *glfw33.Cursor {
+	if *s == nil {
+		*s = make(standardCursors)
+	}
+	glfwShape := standardCursor(shape)
+	cursor, ok := (*s)[glfwShape]
+	if !ok {
+		cursor = glfw33.CreateStandardCursor(glfwShape)
+		(*s)[glfwShape] = cursor
+	}
+	return cursor
+}
+
+// viewportCursor is the cursor shown by a viewport's window. Image cursors are
+// owned by the viewport, and are destroyed when replaced.
+type viewportCursor struct {
+	image *glfw33.Cursor
+}
+
+// set shows the cursor c in the window. set must only be called on the driver
+// routine.
+func (v *viewportCursor) set(d *driver, w *glfw.Window, c gxui.Cursor) {
+	var cursor *glfw33.Cursor
+	if c.Image != nil {
+		cursor = glfw33.CreateCursor(c.Image.Image(), c.Hotspot.X, c.Hotspot.Y)
+	} else {
+		cursor = d.cursors.get(c.Shape)
+	}
+	w.SetCursor(cursor)
+	v.destroy()
+	if c.Image != nil {
+		v.image = cursor
+	}
+}
+
+func (v *viewportCursor) destroy() {
+	if v.image != nil {
+		v.image.Destroy()
+		v.image = nil
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build js
+
+package gl
+
+import (
+	"github.com/google/gxui"
+	"github.com/goxjs/glfw"
+)
+
+// The browser glfw cannot change the cursor, so the canvas always shows the
+// default cursor.
+type standardCursors struct{}
+
+type viewportCursor struct{}
+
+func (v *viewportCursor) set(d *driver, w *glfw.Window, c gxui.Cursor) {}
+func (v *viewportCursor) destroy()                                     {}
@@ -0,0 +1,49 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl
+
+import (
+	"github.com/google/gxui/math"
+)
+
+// dirtyRegion is the part of a viewport that needs to be repainted.
+type dirtyRegion struct {
+	rect math.Rect // Union of the dirty rectangles, in dips
+	all  bool      // If true, rect is ignored and the entire viewport is dirty
+}
+
+// fullRedraw is the dirtyRegion of a viewport that is repainted in full.
+var fullRedraw = dirtyRegion{all: true}
+
+// add grows the region to also cover r, in dips.
+func (d *dirtyRegion) add(r math.Rect) {
+	if d.rect.Size().Area() == 0 {
+		d.rect = r
+	} else if r.Size().Area() != 0 {
+		d.rect = d.rect.Union(r)
+	}
+}
+
+// merge grows the region to also cover o.
+func (d *dirtyRegion) merge(o dirtyRegion) {
+	d.all = d.all || o.all
+	d.add(o.rect)
+}
+
+// clipPixels returns the rectangle of pixels to repaint in a viewport of
+// sizePixels drawn at resolution res. The rectangle is expanded by a pixel to
+// account for rounding and anti-aliased edges, and is clipped to the viewport.
+func (d dirtyRegion) clipPixels(res resolution, sizePixels math.Size) math.Rect {
+	bounds := sizePixels.Rect()
+	if d.all {
+		return bounds
+	}
+	r := res.rectDipsToPixels(d.rect).ExpandI(1)
+	r = math.Rect{Min: r.Min.Max(bounds.Min), Max: r.Max.Min(bounds.Max)}
+	if r.W() <= 0 || r.H() <= 0 {
+		return math.Rect{}
+	}
+	return r
+}
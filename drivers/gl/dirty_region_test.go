@@ -0,0 +1,41 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl
+
+import (
+	"testing"
+
+	"github.com/google/gxui/math"
+	test "github.com/google/gxui/testing"
+)
+
+func TestDirtyRegionMerge(t *testing.T) {
+	d := dirtyRegion{}
+	d.merge(dirtyRegion{rect: math.CreateRect(10, 10, 20, 20)})
+	d.merge(dirtyRegion{rect: math.CreateRect(30, 5, 40, 15)})
+	d.merge(dirtyRegion{})
+	test.AssertEquals(t, dirtyRegion{rect: math.CreateRect(10, 5, 40, 20)}, d)
+
+	d.merge(fullRedraw)
+	test.AssertEquals(t, true, d.all)
+}
+
+func TestDirtyRegionClipPixels(t *testing.T) {
+	doubled := resolution(2 << 16)
+	size := math.Size{W: 100, H: 80}
+	for _, c := range []struct {
+		dirty    dirtyRegion
+		res      resolution
+		expected math.Rect
+	}{
+		{fullRedraw, doubled, math.CreateRect(0, 0, 100, 80)},
+		{dirtyRegion{rect: math.CreateRect(10, 10, 20, 20)}, resolution(1 << 16), math.CreateRect(9, 9, 21, 21)},
+		{dirtyRegion{rect: math.CreateRect(10, 10, 20, 20)}, doubled, math.CreateRect(19, 19, 41, 41)},
+		{dirtyRegion{rect: math.CreateRect(40, 30, 60, 50)}, doubled, math.CreateRect(79, 59, 100, 80)},
+		{dirtyRegion{rect: math.CreateRect(60, 50, 70, 60)}, doubled, math.Rect{}},
+	} {
+		test.AssertEquals(t, c.expected, c.dirty.clipPixels(c.res, size))
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl
+
+import (
+	"image"
+	"image/draw"
+
+	"github.com/google/gxui/math"
+	fnt "golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	// The size of the em square, in pixels, that distance field glyphs are
+	// rasterized at.
+	distanceFieldEmPixels = 48
+
+	// The distance, in pixels at distanceFieldEmPixels, from a glyph's edge
+	// at which the distance field saturates. Glyphs are padded by this many
+	// pixels on each side.
+	distanceFieldSpread = 6
+
+	// A distance larger than any in a glyph.
+	distanceFieldInf = 1e20
+)
+
+// rasterizeDistanceField returns the signed distance field of the glyph r, and
+// the bounds of the field relative to the glyph's origin.
+func rasterizeDistanceField(face fnt.Face, r rune) (draw.Image, math.Rect) {
+	b, mask, maskp, _, _ := face.Glyph(fixed.Point26_6{}, r)
+	bounds := b.Inset(-distanceFieldSpread)
+	coverage := image.NewAlpha(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	if mask != nil {
+		draw.Draw(coverage, b.Sub(bounds.Min), mask, maskp, draw.Src)
+	}
+	field := distanceField(coverage, distanceFieldSpread)
+	return field, math.CreateRect(bounds.Min.X, bounds.Min.Y, bounds.Max.X, bounds.Max.Y)
+}
+
+// distanceField returns the signed distance field of the anti-aliased coverage
+// image. Each pixel holds 0.5 on the edge of the shape, rising to 1 at spread
+// pixels inside the shape and falling to 0 at spread pixels outside. The
+// partial coverage of edge pixels is used to place the edge within the pixel.
+//
+// Based on Mapbox's TinySDF, which uses Felzenszwalb and Huttenlocher's
+// "Distance Transforms of Sampled Functions".
+func distanceField(coverage *image.Alpha, spread float32) *image.Alpha {
+	w, h := coverage.Rect.Dx(), coverage.Rect.Dy()
+	outer := make([]float32, w*h) // Squared distances to the shape.
+	inner := make([]float32, w*h) // Squared distances to the background.
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			i := y*w + x
+			a := float32(coverage.Pix[y*coverage.Stride+x]) / 255
+			switch {
+			case a == 0:
+				outer[i], inner[i] = distanceFieldInf, 0
+			case a == 1:
+				outer[i], inner[i] = 0, distanceFieldInf
+			default:
+				d := 0.5 - a
+				if d > 0 {
+					outer[i], inner[i] = d*d, 0
+				} else {
+					outer[i], inner[i] = 0, d*d
+				}
+			}
+		}
+	}
+
+	n := math.Max(w, h)
+	f := make([]float32, n)
+	z := make([]float32, n+1)
+	v := make([]int, n)
+	for _, grid := range [][]float32{outer, inner} {
+		for x := 0; x < w; x++ {
+			distanceTransform(grid, x, w, h, f, v, z)
+		}
+		for y := 0; y < h; y++ {
+			distanceTransform(grid, y*w, 1, w, f, v, z)
+		}
+	}
+
+	field := image.NewAlpha(image.Rect(0, 0, w, h))
+	for i := range field.Pix {
+		d := math.Sqrtf(outer[i]) - math.Sqrtf(inner[i])
+		field.Pix[i] = uint8(math.Saturate(0.5-d/(2*spread))*255 + 0.5)
+	}
+	return field
+}
+
+// distanceTransform replaces the count values of grid, starting at offset and
+// stride apart, with their squared euclidean distance transform. f, v and z
+// are scratch buffers.
+func distanceTransform(grid []float32, offset, stride, count int, f []float32, v []int, z []float32) {
+	// Find the lower envelope of the parabolas rooted at each value.
+	v[0] = 0
+	z[0], z[1] = -distanceFieldInf, distanceFieldInf
+	f[0] = grid[offset]
+	for q, k := 1, 0; q < count; q++ {
+		f[q] = grid[offset+q*stride]
+		var s float32
+		for {
+			r := v[k]
+			s = (f[q] - f[r] + float32(q*q-r*r)) / float32(2*(q-r))
+			if s > z[k] {
+				break
+			}
+			if k--; k < 0 {
+				break
+			}
+		}
+		k++
+		v[k] = q
+		z[k], z[k+1] = s, distanceFieldInf
+	}
+
+	// Sample the envelope.
+	for q, k := 0, 0; q < count; q++ {
+		for z[k+1] < float32(q) {
+			k++
+		}
+		r := v[k]
+		grid[offset+q*stride] = f[r] + float32((q-r)*(q-r))
+	}
+}
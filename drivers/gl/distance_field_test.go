@@ -0,0 +1,28 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	test "github.com/google/gxui/testing"
+)
+
+func TestDistanceField(t *testing.T) {
+	coverage := image.NewAlpha(image.Rect(0, 0, 16, 16))
+	draw.Draw(coverage, image.Rect(4, 4, 12, 12), image.NewUniform(color.Alpha{255}), image.ZP, draw.Src)
+	coverage.SetAlpha(3, 8, color.Alpha{128}) // Half covered edge pixel
+
+	field := distanceField(coverage, 4)
+	test.AssertEquals(t, uint8(0), field.AlphaAt(0, 0).A)          // Far outside
+	test.AssertEquals(t, uint8(96), field.AlphaAt(8, 3).A)         // One pixel outside: 0.5 - 1/8
+	test.AssertEquals(t, uint8(159), field.AlphaAt(8, 4).A)        // One pixel inside: 0.5 + 1/8
+	test.AssertEquals(t, uint8(255), field.AlphaAt(8, 8).A)        // Far inside
+	test.AssertEquals(t, uint8(128), field.AlphaAt(3, 8).A)        // On the edge
+	test.AssertEquals(t, field.AlphaAt(5, 8), field.AlphaAt(8, 5)) // Symmetric
+}
@@ -30,6 +30,8 @@ type driver struct {
 	pendingApp    chan func()
 	terminated    int32 // non-zero represents driver terminations
 	viewports     *list.List
+	renderOptions gxui.RenderOptions // Only accessed on the driver routine.
+	cursors       standardCursors    // Only accessed on the driver routine.
 
 	pcs  []uintptr // reusable scratch-buffer for use by runtime.Callers.
 	uiPC uintptr   // the program-counter of the applicationLoop function.
@@ -50,6 +52,7 @@ func StartDriver(appRoutine func(driver gxui.Driver)) {
 		pendingDriver: make(chan func(), 256),
 		pendingApp:    make(chan func(), 256),
 		viewports:     list.New(),
+		renderOptions: gxui.DefaultRenderOptions,
 		pcs:           make([]uintptr, 256),
 	}
 
@@ -188,7 +191,7 @@ func (d *driver) SetClipboard(str string) {
 func (d *driver) GetClipboard() (str string, err error) {
 	d.syncDriver(func() {
 		c := d.viewports.Front().Value.(*viewport)
-		str, err = c.window.GetClipboardString()
+		str, err = clipboardString(c.window)
 	})
 	return
 }
@@ -200,7 +203,7 @@ func (d *driver) CreateFont(data []byte, size int) (gxui.Font, error) {
 func (d *driver) CreateWindowedViewport(width, height int, name string) gxui.Viewport {
 	var v *viewport
 	d.syncDriver(func() {
-		v = newViewport(d, width, height, name, false)
+		v = newViewport(d, width, height, name, false, d.renderOptions)
 		e := d.viewports.PushBack(v)
 		v.onDestroy.Listen(func() {
 			d.viewports.Remove(e)
@@ -212,7 +215,7 @@ func (d *driver) CreateWindowedViewport(width, height int, name string) gxui.Vie
 func (d *driver) CreateFullscreenViewport(width, height int, name string) gxui.Viewport {
 	var v *viewport
 	d.syncDriver(func() {
-		v = newViewport(d, width, height, name, true)
+		v = newViewport(d, width, height, name, true, d.renderOptions)
 		e := d.viewports.PushBack(v)
 		v.onDestroy.Listen(func() {
 			d.viewports.Remove(e)
@@ -221,6 +224,16 @@ func (d *driver) CreateFullscreenViewport(width, height int, name string) gxui.V
 	return v
 }
 
+func (d *driver) RenderOptions() gxui.RenderOptions {
+	var o gxui.RenderOptions
+	d.syncDriver(func() { o = d.renderOptions })
+	return o
+}
+
+func (d *driver) SetRenderOptions(o gxui.RenderOptions) {
+	d.syncDriver(func() { d.renderOptions = o })
+}
+
 func (d *driver) CreateCanvas(s math.Size) gxui.Canvas {
 	return newCanvas(s)
 }
@@ -15,13 +15,23 @@ import (
 	"golang.org/x/image/math/fixed"
 )
 
+// glyphTableKey identifies the glyphs rasterized for a resolution and set of
+// render options.
+type glyphTableKey struct {
+	resolution resolution
+	mode       gxui.TextAntiAliasing
+	subpixel   bool
+}
+
 type font struct {
 	size             int
 	scale            fixed.Int26_6
 	glyphMaxSizeDips math.Size
 	ascentDips       int
 	ttf              *truetype.Font
-	resolutions      map[resolution]*glyphTable
+	tables           map[glyphTableKey]*glyphTable
+	contexts         map[resolution]int // The number of contexts drawing at each resolution.
+	distanceFields   *glyphTable
 	glyphAdvanceDips map[rune]int
 }
 
@@ -41,7 +51,8 @@ func newFont(data []byte, size int) (*font, error) {
 		glyphMaxSizeDips: bounds.Size(),
 		ascentDips:       ascentDips,
 		ttf:              ttf,
-		resolutions:      make(map[resolution]*glyphTable),
+		tables:           make(map[glyphTableKey]*glyphTable),
+		contexts:         make(map[resolution]int),
 		glyphAdvanceDips: make(map[rune]int),
 	}, nil
 }
@@ -62,23 +73,75 @@ func (f *font) advanceDips(r rune) int {
 	return advance
 }
 
-func (f *font) glyphTable(resolution resolution) *glyphTable {
-	t, found := f.resolutions[resolution]
+func (f *font) glyphTable(key glyphTableKey) *glyphTable {
+	t, found := f.tables[key]
 	if !found {
 		opt := truetype.Options{
 			Size:              float64(f.size),
-			DPI:               float64(resolution.intDipsToPixels(72)),
+			DPI:               float64(key.resolution.intDipsToPixels(72)),
 			Hinting:           fnt.HintingFull,
 			GlyphCacheEntries: 1,
 			SubPixelsX:        1,
 			SubPixelsY:        1,
 		}
-		t = newGlyphTable(truetype.NewFace(f.ttf, &opt))
-		f.resolutions[resolution] = t
+		if key.subpixel || key.mode.IsLCD() {
+			// Glyphs are rasterized at fractions of a pixel.
+			opt.SubPixelsX = 64
+		}
+		if key.subpixel {
+			// Hinting snaps stems to whole pixels, undoing the subpixel
+			// positioning.
+			opt.Hinting = fnt.HintingNone
+		}
+		t = newGlyphTable(truetype.NewFace(f.ttf, &opt), key.mode)
+		f.tables[key] = t
 	}
 	return t
 }
 
+// acquireGlyphTables records that a context has started drawing the font at
+// the resolution.
+func (f *font) acquireGlyphTables(r resolution) {
+	f.contexts[r]++
+}
+
+// releaseGlyphTables records that a context has stopped drawing the font at
+// the resolution. The glyph tables rasterized for the resolution are released
+// when the last context drawing at it stops.
+func (f *font) releaseGlyphTables(r resolution) {
+	switch f.contexts[r] {
+	case 0:
+		panic("releaseGlyphTables() called without a matching acquireGlyphTables()")
+	case 1:
+		delete(f.contexts, r)
+	default:
+		f.contexts[r]--
+		return
+	}
+	for key := range f.tables {
+		if key.resolution == r {
+			delete(f.tables, key)
+		}
+	}
+}
+
+// distanceFieldTable returns the glyph table of signed distance fields, which
+// is shared by all resolutions.
+func (f *font) distanceFieldTable() *glyphTable {
+	if f.distanceFields == nil {
+		opt := truetype.Options{
+			Size:              float64(f.size),
+			DPI:               72 * distanceFieldEmPixels / float64(f.size),
+			Hinting:           fnt.HintingNone,
+			GlyphCacheEntries: 1,
+			SubPixelsX:        1,
+			SubPixelsY:        1,
+		}
+		f.distanceFields = newDistanceFieldGlyphTable(truetype.NewFace(f.ttf, &opt))
+	}
+	return f.distanceFields
+}
+
 func (f *font) align(rect math.Rect, size math.Size, ascent int, h gxui.HorizontalAlignment, v gxui.VerticalAlignment) math.Point {
 	var origin math.Point
 	switch h {
@@ -106,19 +169,90 @@ func (f *font) DrawRunes(ctx *context, runes []rune, offsets []math.Point, col g
 			len(runes), len(offsets)))
 	}
 	resolution := ctx.resolution
-	table := f.glyphTable(resolution)
+	options := ctx.options
+	if options.DistanceFieldText {
+		emPixels := float32(f.size) * resolution.dipsToPixels() * ds.scale()
+		if emPixels >= options.DistanceFieldMinSize {
+			f.drawDistanceFieldRunes(ctx, runes, offsets, col, ds)
+			return
+		}
+	}
+
+	key := glyphTableKey{resolution: resolution, mode: options.TextAntiAliasing}
+
+	// Glyphs can only be placed at subpixels, or filtered for the subpixels of
+	// LCDs, when they are aligned to the pixel grid. When the canvas is only
+	// translated, the translation is applied here instead of by the blitter.
+	translation, aligned := ds.translation()
+	if aligned {
+		untransformed := *ds
+		untransformed.Transform = math.Mat3Ident
+		ds = &untransformed
+		key.subpixel = options.SubpixelPositioning
+	} else if key.mode.IsLCD() {
+		key.mode = gxui.TextAntiAliasGrayscale
+	}
+	table := f.glyphTable(key)
+	ctx.useFont(f)
+	dipsToPixels := resolution.dipsToPixels()
+
+	for i, r := range runes {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		k := glyphKey{rune: r}
+		var origin math.Point
+		switch {
+		case key.subpixel:
+			x := float32(offsets[i].X)*dipsToPixels + translation.X
+			y := float32(offsets[i].Y)*dipsToPixels + translation.Y
+			origin.X, k.subpixel = subpixelPosition(x)
+			origin.Y = math.Round(y)
+		case aligned:
+			origin = resolution.pointDipsToPixels(offsets[i]).Add(math.Point{
+				X: math.Round(translation.X),
+				Y: math.Round(translation.Y),
+			})
+		default:
+			origin = resolution.pointDipsToPixels(offsets[i])
+		}
+		page := table.get(k)
+		texture := page.texture()
+		entry := page.get(k)
+		srcRect := entry.bounds.Offset(entry.offset)
+		dstRect := entry.bounds.Offset(origin)
+		tc := ctx.getOrCreateTextureContext(texture)
+		ctx.blitter.blitGlyph(ctx, tc, glyphStyle{lcd: key.mode.IsLCD()}, col, srcRect, dstRect, ds)
+	}
+}
+
+// drawDistanceFieldRunes draws the runes from the distance field table, with
+// each glyph scaled from distanceFieldEmPixels to the font's size in pixels.
+func (f *font) drawDistanceFieldRunes(ctx *context, runes []rune, offsets []math.Point, col gxui.Color, ds *drawState) {
+	table := f.distanceFieldTable()
+	dipsToPixels := ctx.resolution.dipsToPixels()
+	scale := float32(f.size) * dipsToPixels / distanceFieldEmPixels
+	style := glyphStyle{
+		distanceField: true,
+		smoothing:     1 / (2 * distanceFieldSpread * scale * ds.scale()),
+	}
 
 	for i, r := range runes {
 		if unicode.IsSpace(r) {
 			continue
 		}
-		page := table.get(r)
+		k := glyphKey{rune: r}
+		page := table.get(k)
 		texture := page.texture()
-		entry := page.get(r)
+		entry := page.get(k)
 		srcRect := entry.bounds.Offset(entry.offset)
-		dstRect := entry.bounds.Offset(resolution.pointDipsToPixels(offsets[i]))
+		origin := offsets[i].Vec2().MulS(dipsToPixels)
+		glyph := *ds
+		glyph.Transform = math.CreateMat3Scale(scale, scale).
+			Mul(math.CreateMat3Translate(origin.X, origin.Y)).
+			Mul(ds.Transform)
 		tc := ctx.getOrCreateTextureContext(texture)
-		ctx.blitter.blitGlyph(ctx, tc, col, srcRect, dstRect, ds)
+		ctx.blitter.blitGlyph(ctx, tc, style, col, srcRect, entry.bounds, &glyph)
 	}
 }
 
@@ -0,0 +1,47 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl
+
+import (
+	"testing"
+
+	"github.com/google/gxui/math"
+	test "github.com/google/gxui/testing"
+)
+
+func TestGlyphTablesSharedByContexts(t *testing.T) {
+	f := &font{
+		tables:   make(map[glyphTableKey]*glyphTable),
+		contexts: make(map[resolution]int),
+	}
+	lo, hi := resolution(1<<16), resolution(2<<16)
+	newTestContext := func() *context {
+		c := &context{fonts: make(map[*font]bool)}
+		c.beginDraw(math.Size{W: 100, H: 100}, math.Size{W: 100, H: 100}, c.options)
+		c.useFont(f)
+		c.useFont(f) // Drawing again at the same resolution is not counted.
+		return c
+	}
+	a, b := newTestContext(), newTestContext()
+	f.tables[glyphTableKey{resolution: lo}] = &glyphTable{}
+
+	// a moves to a denser monitor, but b still draws at the old resolution.
+	a.beginDraw(math.Size{W: 100, H: 100}, math.Size{W: 200, H: 200}, a.options)
+	test.AssertEquals(t, hi, a.resolution)
+	test.AssertEquals(t, 1, len(f.tables))
+
+	a.useFont(f)
+	f.tables[glyphTableKey{resolution: hi}] = &glyphTable{}
+
+	// The last context leaves the resolution.
+	b.releaseFonts()
+	test.AssertEquals(t, 1, len(f.tables))
+	_, found := f.tables[glyphTableKey{resolution: hi}]
+	test.AssertEquals(t, true, found)
+
+	a.releaseFonts()
+	test.AssertEquals(t, 0, len(f.tables))
+	test.AssertEquals(t, 0, len(f.contexts))
+}
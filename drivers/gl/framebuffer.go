@@ -0,0 +1,91 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl
+
+import (
+	"fmt"
+
+	"github.com/google/gxui/math"
+	"github.com/goxjs/gl"
+)
+
+// framebuffer is an offscreen render target backed by a texture. The viewport
+// renders into a framebuffer so that the content outside of the dirty region
+// is preserved between frames.
+//
+// Multisampled framebuffers are backed by a multisampled renderbuffer instead
+// of a texture, so cannot be sampled. Their pixels are resolved into a
+// framebuffer backed by a texture with context.resolve.
+type framebuffer struct {
+	fbo          gl.Framebuffer
+	stencil      gl.Renderbuffer
+	stencilClips []stencilClip   // The stencil clips held by the stencil buffer.
+	tc           *textureContext // nil if the framebuffer is multisampled.
+	color        gl.Renderbuffer // The color buffer if the framebuffer is multisampled.
+	samples      int             // The samples per pixel, or 0 if not multisampled.
+	sizePixels   math.Size
+}
+
+func newFramebuffer(sizePixels math.Size) *framebuffer {
+	w, h := sizePixels.WH()
+	texture := gl.CreateTexture()
+	gl.BindTexture(gl.TEXTURE_2D, texture)
+	gl.TexImage2D(gl.TEXTURE_2D, 0, w, h, gl.RGBA, gl.UNSIGNED_BYTE, nil)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.NEAREST)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.NEAREST)
+	gl.BindTexture(gl.TEXTURE_2D, gl.Texture{})
+
+	stencil := gl.CreateRenderbuffer()
+	gl.BindRenderbuffer(gl.RENDERBUFFER, stencil)
+	gl.RenderbufferStorage(gl.RENDERBUFFER, gl.STENCIL_INDEX8, w, h)
+	gl.BindRenderbuffer(gl.RENDERBUFFER, gl.Renderbuffer{})
+
+	fbo := gl.CreateFramebuffer()
+	gl.BindFramebuffer(gl.FRAMEBUFFER, fbo)
+	gl.FramebufferTexture2D(gl.FRAMEBUFFER, gl.COLOR_ATTACHMENT0, gl.TEXTURE_2D, texture, 0)
+	gl.FramebufferRenderbuffer(gl.FRAMEBUFFER, gl.STENCIL_ATTACHMENT, gl.RENDERBUFFER, stencil)
+	if status := gl.CheckFramebufferStatus(gl.FRAMEBUFFER); status != gl.FRAMEBUFFER_COMPLETE {
+		panic(fmt.Errorf("Framebuffer incomplete. Status: 0x%x", status))
+	}
+	gl.BindFramebuffer(gl.FRAMEBUFFER, gl.Framebuffer{})
+	checkError()
+
+	globalStats.textureContextCount.inc()
+	return &framebuffer{
+		fbo:     fbo,
+		stencil: stencil,
+		tc: &textureContext{
+			texture:    texture,
+			sizePixels: sizePixels,
+			flipY:      true,
+			pma:        true,
+		},
+		sizePixels: sizePixels,
+	}
+}
+
+func (f *framebuffer) bind() {
+	gl.BindFramebuffer(gl.FRAMEBUFFER, f.fbo)
+}
+
+func (f *framebuffer) destroy() {
+	gl.DeleteFramebuffer(f.fbo)
+	f.fbo = gl.Framebuffer{}
+	gl.DeleteRenderbuffer(f.stencil)
+	f.stencil = gl.Renderbuffer{}
+	if f.tc != nil {
+		f.tc.destroy()
+	} else {
+		gl.DeleteRenderbuffer(f.color)
+		f.color = gl.Renderbuffer{}
+	}
+}
+
+// sampleable returns true if the pixels of the render target fb can be read
+// by shaders. The pixels of the window, where fb is nil, and of multisampled
+// framebuffers must first be copied to a texture with context.copyPixels.
+func sampleable(fb *framebuffer) bool {
+	return fb != nil && fb.tc != nil
+}
@@ -0,0 +1,38 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !js
+
+package gl
+
+import (
+	glfw33 "github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/google/gxui/math"
+	"github.com/goxjs/glfw"
+)
+
+// glfwMonitors returns the monitors attached to the system. The desktop glfw
+// windows and monitors wrap those of glfw 3.3, which enumerates the monitors.
+func glfwMonitors() []monitor {
+	var monitors []monitor
+	for _, m := range glfw33.GetMonitors() {
+		vm := m.GetVideoMode()
+		if vm == nil {
+			continue
+		}
+		x, y := m.GetPos()
+		scale, _ := m.GetContentScale()
+		monitors = append(monitors, monitor{
+			bounds: math.CreateRect(x, y, x+vm.Width, y+vm.Height),
+			scale:  scale,
+		})
+	}
+	return monitors
+}
+
+// clipboardString returns the contents of the system clipboard. glfw reports
+// an empty string if the clipboard does not hold text.
+func clipboardString(w *glfw.Window) (string, error) {
+	return w.GetClipboardString(), nil
+}
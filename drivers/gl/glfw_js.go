@@ -0,0 +1,22 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build js
+
+package gl
+
+import (
+	"github.com/goxjs/glfw"
+)
+
+// glfwMonitors returns no monitors, as the browser glfw cannot enumerate
+// them. The canvas is already sized for the device pixel ratio, so viewports
+// use a content scale of 1.
+func glfwMonitors() []monitor {
+	return nil
+}
+
+func clipboardString(w *glfw.Window) (string, error) {
+	return w.GetClipboardString()
+}
@@ -10,6 +10,7 @@ import (
 	"image/png"
 	"os"
 
+	"github.com/google/gxui"
 	"github.com/google/gxui/math"
 	fnt "golang.org/x/image/font"
 	"golang.org/x/image/math/fixed"
@@ -21,17 +22,34 @@ const (
 	glyphPageHeight    = 512
 	glyphSizeAlignment = 8
 	glyphPadding       = 1
+
+	// The number of horizontal positions within a pixel that glyphs are
+	// rasterized at when subpixel positioning is enabled.
+	glyphSubpixels = 4
 )
 
+// glyphKey identifies a glyph rasterized at a horizontal subpixel offset.
+type glyphKey struct {
+	rune     rune
+	subpixel int // The offset in 1/glyphSubpixels of a pixel.
+}
+
+// subpixelPosition splits the horizontal position x, in pixels, into the
+// whole pixel and the subpixel offset used by glyphKey.
+func subpixelPosition(x float32) (pixel, subpixel int) {
+	floor := math.Floorf(x)
+	return int(floor), int((x - floor) * glyphSubpixels)
+}
+
 type glyphEntry struct {
 	offset math.Point
 	bounds math.Rect
 }
 
 type glyphPage struct {
-	image     *image.Alpha
-	size      math.Size // in pixels
-	entries   map[rune]glyphEntry
+	image     draw.Image // *image.Alpha, or *image.RGBA holding LCD coverage.
+	size      math.Size  // in pixels
+	entries   map[glyphKey]glyphEntry
 	rowHeight int
 	tex       *texture
 	nextPoint math.Point
@@ -49,21 +67,84 @@ func align(v, pot int) int {
 	return (v + pot - 1) & ^(pot - 1)
 }
 
-func newGlyphPage(face fnt.Face, r rune) *glyphPage {
-	// Start the page big enough to hold the initial rune.
-	b, _, _ := face.GlyphBounds(r)
-	bounds := rectangle26_6toRect(b)
+// rasterizeGlyph returns the coverage of the glyph k, and the bounds of the
+// coverage relative to the glyph's origin. LCD coverage is returned as an
+// *image.RGBA with a coverage value per subpixel, other modes return an
+// *image.Alpha.
+func rasterizeGlyph(face fnt.Face, mode gxui.TextAntiAliasing, k glyphKey) (draw.Image, math.Rect) {
+	dot := fixed.Int26_6(k.subpixel * 64 / glyphSubpixels)
+	if !mode.IsLCD() {
+		b, mask, maskp, _, _ := face.Glyph(fixed.Point26_6{X: dot}, k.rune)
+		img := image.NewAlpha(image.Rect(0, 0, b.Dx(), b.Dy()))
+		if mask != nil {
+			draw.Draw(img, img.Bounds(), mask, maskp, draw.Src)
+		}
+		if mode == gxui.TextAntiAliasNone {
+			for i, a := range img.Pix {
+				if a >= 0x80 {
+					img.Pix[i] = 0xff
+				} else {
+					img.Pix[i] = 0
+				}
+			}
+		}
+		return img, math.CreateRect(b.Min.X, b.Min.Y, b.Max.X, b.Max.Y)
+	}
+
+	// The coverage of each subpixel is the coverage of a whole pixel centered
+	// on the subpixel, which filters the glyph with a box three subpixels
+	// wide. This is found by rasterizing the glyph shifted a third of a pixel
+	// either way.
+	third := fixed.Int26_6(64 / 3)
+	shifts := [3]fixed.Int26_6{third, 0, -third}
+	if mode == gxui.TextAntiAliasLCDBGR {
+		shifts[0], shifts[2] = shifts[2], shifts[0]
+	}
+	var bounds image.Rectangle
+	var masks [3]image.Image
+	var maskps [3]image.Point
+	var rects [3]image.Rectangle
+	for i, shift := range shifts {
+		rects[i], masks[i], maskps[i], _, _ = face.Glyph(fixed.Point26_6{X: dot + shift}, k.rune)
+		bounds = bounds.Union(rects[i])
+	}
+	img := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	channel := image.NewAlpha(img.Bounds())
+	for i := range masks {
+		if masks[i] == nil {
+			continue
+		}
+		draw.Draw(channel, channel.Bounds(), image.Transparent, image.ZP, draw.Src)
+		draw.Draw(channel, rects[i].Sub(bounds.Min), masks[i], maskps[i], draw.Src)
+		for j, a := range channel.Pix {
+			img.Pix[j*4+i] = a
+			if a > img.Pix[j*4+3] {
+				img.Pix[j*4+3] = a
+			}
+		}
+	}
+	return img, math.CreateRect(bounds.Min.X, bounds.Min.Y, bounds.Max.X, bounds.Max.Y)
+}
+
+// newGlyphPage returns a new page holding the glyph k, with the same image
+// type as mask.
+func newGlyphPage(k glyphKey, mask draw.Image, bounds math.Rect) *glyphPage {
+	// Start the page big enough to hold the initial glyph.
 	size := math.Size{W: glyphPageWidth, H: glyphPageHeight}.Max(bounds.Size())
 	size.W = align(size.W, glyphSizeAlignment)
 	size.H = align(size.H, glyphSizeAlignment)
 
 	page := &glyphPage{
-		image:     image.NewAlpha(image.Rect(0, 0, size.W, size.H)),
 		size:      size,
-		entries:   make(map[rune]glyphEntry),
+		entries:   make(map[glyphKey]glyphEntry),
 		rowHeight: 0,
 	}
-	page.add(face, r)
+	if _, ok := mask.(*image.RGBA); ok {
+		page.image = image.NewRGBA(image.Rect(0, 0, size.W, size.H))
+	} else {
+		page.image = image.NewAlpha(image.Rect(0, 0, size.W, size.H))
+	}
+	page.add(k, mask, bounds)
 	return page
 }
 
@@ -79,14 +160,13 @@ func (p *glyphPage) commit() {
 	}
 }
 
-func (p *glyphPage) add(face fnt.Face, r rune) bool {
-	if _, found := p.entries[r]; found {
+// add copies the glyph image mask, with the bounds relative to the glyph's
+// origin, into the page. add returns false if the page is full.
+func (p *glyphPage) add(k glyphKey, mask draw.Image, bounds math.Rect) bool {
+	if _, found := p.entries[k]; found {
 		panic("Glyph already added to glyph page")
 	}
 
-	b, mask, maskp, _, _ := face.Glyph(fixed.Point26_6{}, r)
-	bounds := math.CreateRect(b.Min.X, b.Min.Y, b.Max.X, b.Max.Y)
-
 	w, h := bounds.Size().WH()
 	x, y := p.nextPoint.X, p.nextPoint.Y
 
@@ -101,9 +181,9 @@ func (p *glyphPage) add(face fnt.Face, r rune) bool {
 		return false // Page full
 	}
 
-	draw.Draw(p.image, image.Rect(x, y, x+w, y+h), mask, maskp, draw.Src)
+	draw.Draw(p.image, image.Rect(x, y, x+w, y+h), mask, image.ZP, draw.Src)
 
-	p.entries[r] = glyphEntry{
+	p.entries[k] = glyphEntry{
 		offset: math.Point{X: x, Y: y}.Sub(bounds.Min),
 		bounds: bounds,
 	}
@@ -123,6 +203,6 @@ func (p *glyphPage) texture() *texture {
 	return p.tex
 }
 
-func (p *glyphPage) get(rune rune) glyphEntry {
-	return p.entries[rune]
+func (p *glyphPage) get(k glyphKey) glyphEntry {
+	return p.entries[k]
 }
@@ -0,0 +1,80 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl
+
+import (
+	"image"
+	"testing"
+
+	"github.com/google/gxui"
+	"github.com/google/gxui/math"
+	test "github.com/google/gxui/testing"
+	fnt "golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// testBarFace is a face whose glyphs are all a vertical bar, one pixel wide
+// and one pixel high, with its left edge at the dot.
+type testBarFace struct{ fnt.Face }
+
+func (testBarFace) Glyph(dot fixed.Point26_6, r rune) (image.Rectangle, image.Image, image.Point, fixed.Int26_6, bool) {
+	x, frac := int(dot.X)>>6, float64(int(dot.X)&63)/64
+	mask := image.NewAlpha(image.Rect(0, 0, 2, 1))
+	mask.Pix[0] = uint8((1-frac)*255 + 0.5)
+	mask.Pix[1] = uint8(frac*255 + 0.5)
+	return image.Rect(x, 0, x+2, 1), mask, image.Point{}, 1 << 6, true
+}
+
+func TestRasterizeGlyph(t *testing.T) {
+	for _, c := range []struct {
+		mode     gxui.TextAntiAliasing
+		subpixel int
+		pix      []uint8
+		bounds   math.Rect
+	}{
+		{gxui.TextAntiAliasGrayscale, 0, []uint8{255, 0}, math.CreateRect(0, 0, 2, 1)},
+		{gxui.TextAntiAliasGrayscale, 1, []uint8{191, 64}, math.CreateRect(0, 0, 2, 1)},
+		{gxui.TextAntiAliasNone, 1, []uint8{255, 0}, math.CreateRect(0, 0, 2, 1)},
+		{gxui.TextAntiAliasNone, 3, []uint8{0, 255}, math.CreateRect(0, 0, 2, 1)},
+		// Each subpixel is covered by a pixel-wide box centered on it: the
+		// red subpixel on the left of a pixel, and the blue on the right.
+		{gxui.TextAntiAliasLCD, 0, []uint8{
+			0, 0, 84, 84,
+			171, 255, 171, 255,
+			84, 0, 0, 84,
+		}, math.CreateRect(-1, 0, 2, 1)},
+		{gxui.TextAntiAliasLCDBGR, 0, []uint8{
+			84, 0, 0, 84,
+			171, 255, 171, 255,
+			0, 0, 84, 84,
+		}, math.CreateRect(-1, 0, 2, 1)},
+	} {
+		img, bounds := rasterizeGlyph(testBarFace{}, c.mode, glyphKey{rune: 'l', subpixel: c.subpixel})
+		switch img := img.(type) {
+		case *image.Alpha:
+			test.AssertEquals(t, c.pix, img.Pix)
+		case *image.RGBA:
+			test.AssertEquals(t, c.pix, img.Pix)
+		}
+		test.AssertEquals(t, c.bounds, bounds)
+	}
+}
+
+func TestSubpixelPosition(t *testing.T) {
+	for _, c := range []struct {
+		x               float32
+		pixel, subpixel int
+	}{
+		{10, 10, 0},
+		{10.3, 10, 1},
+		{10.5, 10, 2},
+		{10.99, 10, 3},
+		{-0.6, -1, 1},
+	} {
+		pixel, subpixel := subpixelPosition(c.x)
+		test.AssertEquals(t, c.pixel, pixel)
+		test.AssertEquals(t, c.subpixel, subpixel)
+	}
+}
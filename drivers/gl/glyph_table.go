@@ -4,32 +4,50 @@
 
 package gl
 
-import fnt "golang.org/x/image/font"
+import (
+	"image/draw"
+
+	"github.com/google/gxui"
+	"github.com/google/gxui/math"
+	fnt "golang.org/x/image/font"
+)
 
 type glyphTable struct {
-	face  fnt.Face
-	index map[rune]int
-	pages []*glyphPage
+	face          fnt.Face
+	mode          gxui.TextAntiAliasing
+	distanceField bool // Glyphs are stored as signed distance fields.
+	index         map[glyphKey]int
+	pages         []*glyphPage
+}
+
+func newGlyphTable(face fnt.Face, mode gxui.TextAntiAliasing) *glyphTable {
+	return &glyphTable{face: face, mode: mode, index: make(map[glyphKey]int)}
 }
 
-func newGlyphTable(face fnt.Face) *glyphTable {
-	return &glyphTable{face: face, index: make(map[rune]int)}
+// newDistanceFieldGlyphTable returns a glyph table that stores the signed
+// distance fields of the glyphs of face.
+func newDistanceFieldGlyphTable(face fnt.Face) *glyphTable {
+	t := newGlyphTable(face, gxui.TextAntiAliasGrayscale)
+	t.distanceField = true
+	return t
+}
+
+func (t *glyphTable) rasterize(k glyphKey) (draw.Image, math.Rect) {
+	if t.distanceField {
+		return rasterizeDistanceField(t.face, k.rune)
+	}
+	return rasterizeGlyph(t.face, t.mode, k)
 }
 
-func (t *glyphTable) get(r rune) *glyphPage {
-	if i, found := t.index[r]; found {
+func (t *glyphTable) get(k glyphKey) *glyphPage {
+	if i, found := t.index[k]; found {
 		return t.pages[i]
 	}
-	if len(t.pages) == 0 {
-		t.pages = append(t.pages, newGlyphPage(t.face, r))
-	} else {
-		page := t.pages[len(t.pages)-1]
-		if !page.add(t.face, r) {
-			page = newGlyphPage(t.face, r)
-			t.pages = append(t.pages, page)
-		}
+	mask, bounds := t.rasterize(k)
+	if len(t.pages) == 0 || !t.pages[len(t.pages)-1].add(k, mask, bounds) {
+		t.pages = append(t.pages, newGlyphPage(k, mask, bounds))
 	}
 	index := len(t.pages) - 1
-	t.index[r] = index
+	t.index[k] = index
 	return t.pages[index]
 }
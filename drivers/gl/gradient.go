@@ -0,0 +1,70 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl
+
+import (
+	"github.com/google/gxui"
+	"github.com/google/gxui/math"
+)
+
+// gradientMatrix returns the matrix that transforms a vertex position into
+// gradient space, given the min and size of the bounds that the gradient
+// positions are relative to.
+// For linear gradients the X component of the transformed position is the
+// offset along the gradient. For radial gradients the length of the
+// transformed position is the offset along the gradient.
+func gradientMatrix(g *gxui.Gradient, min, size math.Vec2) math.Mat3 {
+	const epsilon = 1e-6
+	size = math.Vec2{X: math.Maxf(size.X, epsilon), Y: math.Maxf(size.Y, epsilon)}
+	switch g.Kind {
+	case gxui.RadialGradient:
+		r := math.Vec2{X: math.Maxf(g.Radius.X, epsilon), Y: math.Maxf(g.Radius.Y, epsilon)}
+		return math.CreateMat3(
+			1/(size.X*r.X), 0, 0,
+			0, 1/(size.Y*r.Y), 0,
+			-(min.X/size.X+g.Center.X)/r.X, -(min.Y/size.Y+g.Center.Y)/r.Y, 1,
+		)
+	default:
+		d := g.End.Sub(g.Start)
+		l := d.SqrLen()
+		if l == 0 {
+			l = 1
+		}
+		return math.CreateMat3(
+			d.X/(l*size.X), 0, 0,
+			d.Y/(l*size.Y), 0, 0,
+			-((min.X/size.X+g.Start.X)*d.X+(min.Y/size.Y+g.Start.Y)*d.Y)/l, 0, 1,
+		)
+	}
+}
+
+func gradientUniforms(g *gxui.Gradient, mPos math.Mat3, min, size math.Vec2) uniformBindings {
+	stops := g.Stops
+	if len(stops) > gxui.MaxGradientStops {
+		stops = stops[:gxui.MaxGradientStops]
+	}
+	offsets := make([]float32, gxui.MaxGradientStops)
+	colors := make([]float32, gxui.MaxGradientStops*4)
+	for i, s := range stops {
+		offsets[i] = s.Offset
+		colors[i*4+0] = s.Color.R
+		colors[i*4+1] = s.Color.G
+		colors[i*4+2] = s.Color.B
+		colors[i*4+3] = s.Color.A
+	}
+	radial := float32(0)
+	if g.Kind == gxui.RadialGradient {
+		radial = 1
+	}
+	return uniformBindings{
+		"mPos":        mPos,
+		"mGradient":   gradientMatrix(g, min, size),
+		"Radial":      radial,
+		"Spread":      float32(g.Spread),
+		"StopCount":   float32(len(stops)),
+		"StopOffsets": offsets,
+		"StopColors":  colors,
+	}
+}
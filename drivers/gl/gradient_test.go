@@ -0,0 +1,42 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl
+
+import (
+	"testing"
+
+	"github.com/google/gxui"
+	"github.com/google/gxui/math"
+)
+
+func gradientAt(g *gxui.Gradient, min, size, p math.Vec2) math.Vec2 {
+	return math.Vec3{X: p.X, Y: p.Y, Z: 1}.MulM(gradientMatrix(g, min, size)).XY()
+}
+
+func assertNear(t *testing.T, expected, got float32) {
+	if math.Absf(expected-got) > 1e-4 {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestGradientMatrixLinear(t *testing.T) {
+	g := &gxui.Gradient{Kind: gxui.LinearGradient, End: math.Vec2{X: 1, Y: 0}}
+	min, size := math.Vec2{X: 10, Y: 0}, math.Vec2{X: 100, Y: 50}
+	assertNear(t, 0, gradientAt(g, min, size, v(10, 25)).X)
+	assertNear(t, 0.5, gradientAt(g, min, size, v(60, 40)).X)
+	assertNear(t, 1, gradientAt(g, min, size, v(110, 0)).X)
+}
+
+func TestGradientMatrixRadial(t *testing.T) {
+	g := &gxui.Gradient{
+		Kind:   gxui.RadialGradient,
+		Center: math.Vec2{X: 0.5, Y: 0.5},
+		Radius: math.Vec2{X: 0.5, Y: 0.5},
+	}
+	min, size := math.Vec2{X: 0, Y: 0}, math.Vec2{X: 200, Y: 100}
+	assertNear(t, 0, gradientAt(g, min, size, v(100, 50)).Len())
+	assertNear(t, 1, gradientAt(g, min, size, v(200, 50)).Len())
+	assertNear(t, 1, gradientAt(g, min, size, v(100, 0)).Len())
+}
@@ -0,0 +1,158 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl
+
+import (
+	"github.com/google/gxui"
+	"github.com/google/gxui/math"
+
+	"github.com/goxjs/gl"
+)
+
+// layer is an offscreen render target begun by Canvas.PushLayer.
+type layer struct {
+	framebuffer *framebuffer
+	parent      *framebuffer // The render target to composite the layer into.
+	opacity     float32
+	mode        gxui.BlendMode
+}
+
+// pushLayer redirects drawing to a new transparent layer, clipped to ds. Only
+// the pixels inside the current clip are cleared, as only those are
+// composited.
+func (c *context) pushLayer(opacity float32, mode gxui.BlendMode, ds *drawState) {
+	c.blitter.commit(c)
+
+	fb := c.acquireFramebuffer()
+	c.layers = append(c.layers, layer{
+		framebuffer: fb,
+		parent:      c.framebuffer,
+		opacity:     opacity,
+		mode:        mode,
+	})
+	c.bindFramebuffer(fb)
+	gl.ClearColor(0, 0, 0, 0)
+	gl.Clear(gl.COLOR_BUFFER_BIT)
+	c.clearStencil()
+	c.applyStencil(ds)
+}
+
+// popLayer composites the last layer into its parent render target, inside
+// the clip of ds.
+func (c *context) popLayer(ds *drawState) {
+	c.blitter.commit(c)
+
+	l := c.layers[len(c.layers)-1]
+	c.layers = c.layers[:len(c.layers)-1]
+	c.bindFramebuffer(l.parent)
+	c.apply(ds)
+
+	switch l.mode {
+	case gxui.BlendAdd:
+		gl.BlendFunc(gl.ONE, gl.ONE)
+	case gxui.BlendMultiply:
+		gl.BlendFunc(gl.DST_COLOR, gl.ONE_MINUS_SRC_ALPHA)
+	case gxui.BlendScreen:
+		gl.BlendFunc(gl.ONE, gl.ONE_MINUS_SRC_COLOR)
+	}
+	if clip := ds.ClipPixels; clip.W() > 0 && clip.H() > 0 {
+		window := newDrawState(clip)
+		c.blitter.blitOpacity(c, l.framebuffer.tc, clip, clip, l.opacity, &window)
+	}
+	gl.BlendFunc(gl.ONE, gl.ONE_MINUS_SRC_ALPHA)
+
+	c.releaseFramebuffer(l.framebuffer)
+	c.stats.layerCount++
+}
+
+// blurBackdrop blurs the pixels of the current render target inside the
+// window rectangle r, clipped to ds, using a gaussian with a standard
+// deviation of half the radius in pixels. The blur is applied in two passes,
+// horizontally into a temporary framebuffer, then vertically back into the
+// render target. Render targets that cannot be sampled, such as the window and
+// multisampled framebuffers, are first copied into a framebuffer that can.
+func (c *context) blurBackdrop(r math.Rect, radius float32, ds *drawState) {
+	target := c.framebuffer
+	r = ds.intersectClip(r)
+	if radius <= 0 || r.W() <= 0 || r.H() <= 0 {
+		return
+	}
+	c.blitter.commit(c)
+
+	sigma := radius / 2
+	step := math.Maxf(1, 3*sigma/blurTaps)
+	// The first pass also blurs a margin around r, so that the second pass
+	// samples blurred pixels at the edges of r.
+	margin := int(math.Ceilf(step * blurTaps))
+	src := r.ExpandI(margin)
+	src = c.clampToTarget(src)
+
+	backdrop := target
+	if !sampleable(target) {
+		// The first pass samples a further margin around src.
+		backdrop = c.acquireFramebuffer()
+		c.copyPixels(backdrop, c.clampToTarget(src.ExpandI(margin)))
+	}
+
+	tmp := c.acquireFramebuffer()
+	gl.BlendFunc(gl.ONE, gl.ZERO) // Replace
+
+	c.bindFramebuffer(tmp)
+	horizontal := newDrawState(src)
+	c.apply(&horizontal)
+	c.blitter.blitBlur(c, backdrop.tc, src, math.Vec2{X: step}, sigma/step, &horizontal)
+
+	c.bindFramebuffer(target)
+	vertical := newDrawState(r)
+	vertical.StencilClips = ds.StencilClips
+	c.apply(&vertical)
+	c.blitter.blitBlur(c, tmp.tc, r, math.Vec2{Y: step}, sigma/step, &vertical)
+
+	gl.BlendFunc(gl.ONE, gl.ONE_MINUS_SRC_ALPHA)
+	c.apply(ds)
+	c.releaseFramebuffer(tmp)
+	if backdrop != target {
+		c.releaseFramebuffer(backdrop)
+	}
+}
+
+func (c *context) clampToTarget(r math.Rect) math.Rect {
+	return math.Rect{Min: r.Min.Max(math.ZeroPoint), Max: r.Max.Min(c.sizePixels.Rect().Max)}
+}
+
+// copyPixels copies the pixels of the bound render target inside the
+// rectangle r to the same pixels of dst.
+func (c *context) copyPixels(dst *framebuffer, r math.Rect) {
+	if src := c.framebuffer; src != nil && src.samples > 0 {
+		c.resolve(src, dst, r)
+		return
+	}
+	h := c.sizePixels.H
+	gl.BindTexture(gl.TEXTURE_2D, dst.tc.texture)
+	gl.CopyTexSubImage2D(gl.TEXTURE_2D, 0, r.Min.X, h-r.Max.Y, r.Min.X, h-r.Max.Y, r.W(), r.H())
+	gl.BindTexture(gl.TEXTURE_2D, gl.Texture{})
+}
+
+// acquireFramebuffer returns a window-sized framebuffer from the pool of
+// free framebuffers, or a new one if the pool is empty.
+func (c *context) acquireFramebuffer() *framebuffer {
+	for len(c.freeLayers) > 0 {
+		fb := c.freeLayers[len(c.freeLayers)-1]
+		c.freeLayers = c.freeLayers[:len(c.freeLayers)-1]
+		if fb.sizePixels == c.sizePixels {
+			// The stencil buffer is cleared when the framebuffer is used for a
+			// layer. Effects do not use it.
+			fb.stencilClips = nil
+			return fb
+		}
+		fb.destroy()
+	}
+	return newFramebuffer(c.sizePixels)
+}
+
+// releaseFramebuffer returns fb to the pool of free framebuffers.
+func (c *context) releaseFramebuffer(fb *framebuffer) {
+	c.freeLayers = append(c.freeLayers, fb)
+}
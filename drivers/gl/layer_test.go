@@ -0,0 +1,46 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl
+
+import (
+	"testing"
+
+	"github.com/google/gxui"
+	"github.com/google/gxui/math"
+	test "github.com/google/gxui/testing"
+)
+
+func TestFramebufferPool(t *testing.T) {
+	size := math.Size{W: 20, H: 10}
+	c := &context{sizePixels: size}
+	a, b := &framebuffer{sizePixels: size}, &framebuffer{sizePixels: size}
+	c.releaseFramebuffer(a)
+	c.releaseFramebuffer(b)
+
+	// The last released framebuffer is reused first.
+	test.AssertEquals(t, b, c.acquireFramebuffer())
+	test.AssertEquals(t, []*framebuffer{a}, c.freeLayers)
+
+	// Reused framebuffers forget the stencil clips of their last use.
+	a.stencilClips = []stencilClip{{rect: math.CreateRect(0, 0, 5, 5)}}
+	test.AssertEquals(t, a, c.acquireFramebuffer())
+	test.AssertEquals(t, true, a.stencilClips == nil)
+	test.AssertEquals(t, 0, len(c.freeLayers))
+}
+
+func TestBlurBackdropSource(t *testing.T) {
+	size := math.Size{W: 20, H: 10}
+
+	// Viewports with the default options draw to a multisampled framebuffer,
+	// so the backdrop is copied before it is blurred.
+	samples := gxui.DefaultRenderOptions.Samples
+	test.AssertEquals(t, false, sampleable(&framebuffer{sizePixels: size, samples: samples}))
+	test.AssertEquals(t, false, sampleable(nil)) // The window
+	test.AssertEquals(t, true, sampleable(&framebuffer{sizePixels: size, tc: &textureContext{}}))
+
+	// The copied pixels are clamped to the render target.
+	c := &context{sizePixels: size}
+	test.AssertEquals(t, math.CreateRect(0, 2, 20, 10), c.clampToTarget(math.CreateRect(-5, 2, 25, 15)))
+}
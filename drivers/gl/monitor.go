@@ -0,0 +1,42 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl
+
+import (
+	"github.com/google/gxui/math"
+)
+
+// monitor is a display attached to the system.
+type monitor struct {
+	bounds math.Rect // The area of the monitor, in screen coordinates.
+	scale  float32   // The ratio of pixels to DIPs recommended by the platform.
+}
+
+// queryMonitors returns the monitors attached to the system. It must only be
+// called on the driver routine. Tests replace queryMonitors to simulate
+// monitors.
+var queryMonitors = glfwMonitors
+
+// monitorScale returns the content scale of the monitor showing the window
+// with the bounds in screen coordinates.
+func monitorScale(window math.Rect) float32 {
+	return contentScale(queryMonitors(), window)
+}
+
+// contentScale returns the scale of the monitor that holds the largest area of
+// the window bounds, or of the first monitor if the window is off-screen. If
+// there are no monitors, contentScale returns 1.
+func contentScale(monitors []monitor, window math.Rect) float32 {
+	scale, largest := float32(1), -1
+	for _, m := range monitors {
+		w := math.Min(m.bounds.Max.X, window.Max.X) - math.Max(m.bounds.Min.X, window.Min.X)
+		h := math.Min(m.bounds.Max.Y, window.Max.Y) - math.Max(m.bounds.Min.Y, window.Min.Y)
+		area := math.Max(w, 0) * math.Max(h, 0)
+		if area > largest && m.scale > 0 {
+			scale, largest = m.scale, area
+		}
+	}
+	return scale
+}
@@ -0,0 +1,40 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl
+
+import (
+	"testing"
+
+	"github.com/google/gxui/math"
+	test "github.com/google/gxui/testing"
+)
+
+func TestContentScale(t *testing.T) {
+	monitors := []monitor{
+		{bounds: math.CreateRect(0, 0, 1920, 1080), scale: 1},
+		{bounds: math.CreateRect(1920, 0, 3840, 2160), scale: 2},
+	}
+	for _, c := range []struct {
+		window math.Rect
+		scale  float32
+	}{
+		{math.CreateRect(100, 100, 900, 700), 1},
+		{math.CreateRect(2000, 100, 2800, 700), 2},
+		{math.CreateRect(1500, 100, 2300, 700), 1}, // Mostly on the first
+		{math.CreateRect(1700, 100, 2500, 700), 2}, // Mostly on the second
+		{math.CreateRect(-900, -700, -100, -100), 1},
+	} {
+		test.AssertEquals(t, c.scale, contentScale(monitors, c.window))
+	}
+	test.AssertEquals(t, float32(1), contentScale(nil, math.CreateRect(0, 0, 10, 10)))
+}
+
+func TestMonitorScaleQueriesMonitors(t *testing.T) {
+	defer func(f func() []monitor) { queryMonitors = f }(queryMonitors)
+	queryMonitors = func() []monitor {
+		return []monitor{{bounds: math.CreateRect(0, 0, 2560, 1440), scale: 1.5}}
+	}
+	test.AssertEquals(t, float32(1.5), monitorScale(math.CreateRect(0, 0, 800, 600)))
+}
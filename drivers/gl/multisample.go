@@ -0,0 +1,72 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !js
+
+package gl
+
+import (
+	"fmt"
+
+	gl21 "github.com/go-gl/gl/v2.1/gl"
+	"github.com/google/gxui/math"
+	"github.com/goxjs/gl"
+)
+
+// maxSamples returns the largest number of samples per pixel of multisampled
+// framebuffers, or 0 if they are not supported. The desktop gl wraps OpenGL
+// 2.1, where multisampled framebuffers are provided by the
+// framebuffer_multisample and framebuffer_blit extensions, which are core
+// from OpenGL 3.0.
+func maxSamples() int {
+	var n int32
+	gl21.GetIntegerv(gl21.MAX_SAMPLES, &n)
+	gl.GetError() // MAX_SAMPLES is an invalid enum without the extensions.
+	return int(n)
+}
+
+func newMultisampleFramebuffer(sizePixels math.Size, samples int) *framebuffer {
+	w, h := int32(sizePixels.W), int32(sizePixels.H)
+
+	color := gl.CreateRenderbuffer()
+	gl.BindRenderbuffer(gl.RENDERBUFFER, color)
+	gl21.RenderbufferStorageMultisample(gl21.RENDERBUFFER, int32(samples), gl21.RGBA8, w, h)
+
+	stencil := gl.CreateRenderbuffer()
+	gl.BindRenderbuffer(gl.RENDERBUFFER, stencil)
+	gl21.RenderbufferStorageMultisample(gl21.RENDERBUFFER, int32(samples), gl21.STENCIL_INDEX8, w, h)
+	gl.BindRenderbuffer(gl.RENDERBUFFER, gl.Renderbuffer{})
+
+	fbo := gl.CreateFramebuffer()
+	gl.BindFramebuffer(gl.FRAMEBUFFER, fbo)
+	gl.FramebufferRenderbuffer(gl.FRAMEBUFFER, gl.COLOR_ATTACHMENT0, gl.RENDERBUFFER, color)
+	gl.FramebufferRenderbuffer(gl.FRAMEBUFFER, gl.STENCIL_ATTACHMENT, gl.RENDERBUFFER, stencil)
+	if status := gl.CheckFramebufferStatus(gl.FRAMEBUFFER); status != gl.FRAMEBUFFER_COMPLETE {
+		panic(fmt.Errorf("Multisampled framebuffer incomplete. Status: 0x%x", status))
+	}
+	gl.BindFramebuffer(gl.FRAMEBUFFER, gl.Framebuffer{})
+	checkError()
+
+	return &framebuffer{
+		fbo:        fbo,
+		stencil:    stencil,
+		color:      color,
+		samples:    samples,
+		sizePixels: sizePixels,
+	}
+}
+
+// resolve averages the samples of the pixels of src inside the rectangle r,
+// writing them to the same pixels of dst. The bound render target is left
+// unchanged.
+func (c *context) resolve(src, dst *framebuffer, r math.Rect) {
+	h := src.sizePixels.H
+	x0, y0, x1, y1 := int32(r.Min.X), int32(h-r.Max.Y), int32(r.Max.X), int32(h-r.Min.Y)
+	gl21.BindFramebuffer(gl21.READ_FRAMEBUFFER, src.fbo.Value)
+	gl21.BindFramebuffer(gl21.DRAW_FRAMEBUFFER, dst.fbo.Value)
+	gl.Disable(gl.SCISSOR_TEST) // Blits are scissored.
+	gl21.BlitFramebuffer(x0, y0, x1, y1, x0, y0, x1, y1, gl21.COLOR_BUFFER_BIT, gl21.NEAREST)
+	gl.Enable(gl.SCISSOR_TEST)
+	c.bindFramebuffer(c.framebuffer)
+}
@@ -0,0 +1,24 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build js
+
+package gl
+
+import (
+	"github.com/google/gxui/math"
+)
+
+// maxSamples returns 0, as WebGL 1 cannot multisample framebuffers.
+func maxSamples() int {
+	return 0
+}
+
+func newMultisampleFramebuffer(sizePixels math.Size, samples int) *framebuffer {
+	panic("Multisampled framebuffers are not supported")
+}
+
+func (c *context) resolve(src, dst *framebuffer, r math.Rect) {
+	panic("Multisampled framebuffers are not supported")
+}
@@ -0,0 +1,157 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl
+
+import (
+	"github.com/google/gxui"
+	"github.com/google/gxui/math"
+)
+
+// pathTolerance is the maximum distance in DIPs between a curve and the line
+// segments it is flattened to.
+const pathTolerance = 0.1
+
+// maxCurveSteps limits the number of line segments a single curve or arc is
+// flattened to.
+const maxCurveSteps = 256
+
+type pathContour struct {
+	points []math.Vec2
+	closed bool
+}
+
+func curveSteps(f float32) int {
+	return math.Clamp(int(math.Ceilf(f)), 1, maxCurveSteps)
+}
+
+// flattenPath returns the contours of the path with all curves and arcs
+// replaced by line segments no further than tolerance from the curve.
+func flattenPath(p gxui.Path2D, tolerance float32) []pathContour {
+	contours := []pathContour{}
+	current := []math.Vec2{}
+	start, hasStart := math.Vec2{}, false
+
+	flush := func(closed bool) {
+		if len(current) > 1 {
+			contours = append(contours, pathContour{current, closed})
+		}
+		current = []math.Vec2{}
+	}
+	// begin returns the current point, starting a new contour at the end of
+	// the last closed contour or at def if there is no current point.
+	begin := func(def math.Vec2) math.Vec2 {
+		if len(current) == 0 {
+			if hasStart {
+				def = start
+			}
+			current = append(current, def)
+			start, hasStart = def, true
+		}
+		return current[len(current)-1]
+	}
+
+	for _, s := range p.Segments {
+		pts := s.Points
+		switch s.Command {
+		case gxui.PathMoveTo:
+			flush(false)
+			current = append(current, pts[0])
+			start, hasStart = pts[0], true
+		case gxui.PathLineTo:
+			begin(pts[0])
+			current = append(current, pts[0])
+		case gxui.PathQuadTo:
+			p0 := begin(pts[0])
+			dd := p0.Sub(pts[0].MulS(2)).Add(pts[1]).Len()
+			n := curveSteps(math.Sqrtf(dd / (4 * tolerance)))
+			for i := 1; i <= n; i++ {
+				t := float32(i) / float32(n)
+				u := 1 - t
+				current = append(current, p0.MulS(u*u).
+					Add(pts[0].MulS(2*u*t)).
+					Add(pts[1].MulS(t*t)))
+			}
+		case gxui.PathCubicTo:
+			p0 := begin(pts[0])
+			dd := math.Maxf(
+				p0.Sub(pts[0].MulS(2)).Add(pts[1]).Len(),
+				pts[0].Sub(pts[1].MulS(2)).Add(pts[2]).Len(),
+			)
+			n := curveSteps(math.Sqrtf(3 * dd / (4 * tolerance)))
+			for i := 1; i <= n; i++ {
+				t := float32(i) / float32(n)
+				u := 1 - t
+				current = append(current, p0.MulS(u*u*u).
+					Add(pts[0].MulS(3*u*u*t)).
+					Add(pts[1].MulS(3*u*t*t)).
+					Add(pts[2].MulS(t*t*t)))
+			}
+		case gxui.PathArc:
+			center, radii := pts[0], pts[1]
+			at := func(θ float32) math.Vec2 {
+				return center.Add(math.Vec2{X: radii.X * math.Cosf(θ), Y: radii.Y * math.Sinf(θ)})
+			}
+			r := math.Maxf(math.Absf(radii.X), math.Absf(radii.Y))
+			n := 1
+			if r > tolerance {
+				step := 2 * math.Acosf(1-tolerance/r)
+				n = curveSteps(math.Absf(s.Sweep) / step)
+			}
+			// Join any current point to the start of the arc with a line.
+			first := at(s.Start)
+			joined := len(current) > 0 || hasStart
+			begin(first)
+			if joined {
+				current = append(current, first)
+			}
+			for i := 1; i <= n; i++ {
+				current = append(current, at(s.Start+s.Sweep*float32(i)/float32(n)))
+			}
+		case gxui.PathClose:
+			if len(current) > 0 {
+				flush(true)
+			}
+		}
+	}
+	flush(false)
+	for i := range contours {
+		contours[i].points = pruneDuplicateVec2s(contours[i].points)
+	}
+	return contours
+}
+
+// pathToShape returns the shapes for filling and stroking the path, and the
+// bounds of the path in DIPs. Paths are stroked along their centerline.
+func pathToShape(p gxui.Path2D, pen gxui.Pen) (fillShape, edgeShape *shape, bounds math.Rect) {
+	contours := flattenPath(p, pathTolerance)
+	if len(contours) == 0 {
+		return nil, nil, math.Rect{}
+	}
+
+	min, max := contours[0].points[0], contours[0].points[0]
+	fill := make([][]math.Vec2, len(contours))
+	for i, c := range contours {
+		fill[i] = c.points
+		for _, v := range c.points {
+			min = math.Vec2{X: math.Minf(min.X, v.X), Y: math.Minf(min.Y, v.Y)}
+			max = math.Vec2{X: math.Maxf(max.X, v.X), Y: math.Maxf(max.Y, v.Y)}
+		}
+	}
+	bounds = math.Rect{
+		Min: math.Point{X: int(math.Floorf(min.X)), Y: int(math.Floorf(min.Y))},
+		Max: math.Point{X: int(math.Ceilf(max.X)), Y: int(math.Ceilf(max.Y))},
+	}
+
+	fillShape = trianglesToShape(appendVec2(nil, triangulateContours(fill, p.FillRule)...))
+
+	if pen.Width > 0 && pen.Color.A > 0 {
+		var tris []float32
+		for _, c := range contours {
+			tris = appendStroke(tris, c.points, c.closed, pen)
+		}
+		edgeShape = trianglesToShape(tris)
+	}
+	return fillShape, edgeShape, bounds
+}
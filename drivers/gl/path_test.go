@@ -0,0 +1,54 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl
+
+import (
+	"testing"
+
+	"github.com/google/gxui"
+	"github.com/google/gxui/math"
+	test "github.com/google/gxui/testing"
+)
+
+func area(tris []math.Vec2) float32 {
+	a := float32(0)
+	for i := 0; i+2 < len(tris); i += 3 {
+		a += math.Absf(tris[i+1].Sub(tris[i]).Cross(tris[i+2].Sub(tris[i]))) / 2
+	}
+	return a
+}
+
+func square(x, y, size float32, clockwise bool) []math.Vec2 {
+	s := []math.Vec2{v(x, y), v(x+size, y), v(x+size, y+size), v(x, y+size)}
+	if !clockwise {
+		s[1], s[3] = s[3], s[1]
+	}
+	return s
+}
+
+func TestTriangulateContoursHoles(t *testing.T) {
+	outer, sameDir, oppositeDir := square(0, 0, 10, true), square(2, 2, 4, true), square(2, 2, 4, false)
+	assertNear(t, 84, area(triangulateContours([][]math.Vec2{outer, sameDir}, gxui.EvenOddFill)))
+	assertNear(t, 100, area(triangulateContours([][]math.Vec2{outer, sameDir}, gxui.NonZeroFill)))
+	assertNear(t, 84, area(triangulateContours([][]math.Vec2{outer, oppositeDir}, gxui.NonZeroFill)))
+}
+
+func TestTriangulateContoursSelfIntersecting(t *testing.T) {
+	// A bow-tie made of two triangles, each with an area of 25.
+	bowtie := []math.Vec2{v(0, 0), v(10, 10), v(10, 0), v(0, 10)}
+	assertNear(t, 50, area(triangulateContours([][]math.Vec2{bowtie}, gxui.NonZeroFill)))
+}
+
+func TestFlattenPathArc(t *testing.T) {
+	p := gxui.Path2D{}
+	p.Arc(v(0, 0), v(10, 10), 0, math.TwoPi)
+	p.Close()
+	contours := flattenPath(p, pathTolerance)
+	test.AssertEquals(t, 1, len(contours))
+	test.AssertEquals(t, true, contours[0].closed)
+	for _, p := range contours[0].points {
+		assertNear(t, 10, p.Len())
+	}
+}
@@ -141,8 +141,9 @@ func segment(penWidth, r float32, a, b, c math.Vec2, aIsLast bool, vsEdgePos []f
 	return vsEdgePos, fillEdge
 }
 
-func closedPolyToShape(p gxui.Polygon, penWidth float32) (fillShape, edgeShape *shape) {
+func closedPolyToShape(p gxui.Polygon, pen gxui.Pen) (fillShape, edgeShape *shape) {
 	p = pruneDuplicates(p)
+	penWidth := pen.Width
 
 	fillEdge := []math.Vec2{}
 	vsEdgePos := []float32{}
@@ -155,6 +156,11 @@ func closedPolyToShape(p gxui.Polygon, penWidth float32) (fillShape, edgeShape *
 		vsEdgePos, fillEdge = segment(penWidth, r, a, b, c, i == len(p), vsEdgePos, fillEdge)
 	}
 
+	if isStyledPen(pen) {
+		edgeShape = strokeToShape(stripCenterline(vsEdgePos), true, pen)
+		vsEdgePos = nil
+	}
+
 	// Close the edge
 	if len(vsEdgePos) >= 4 {
 		vsEdgePos = append(vsEdgePos, vsEdgePos[:4]...)
@@ -181,8 +187,9 @@ func closedPolyToShape(p gxui.Polygon, penWidth float32) (fillShape, edgeShape *
 	return fillShape, edgeShape
 }
 
-func openPolyToShape(p gxui.Polygon, penWidth float32) *shape {
+func openPolyToShape(p gxui.Polygon, pen gxui.Pen) *shape {
 	p = pruneDuplicates(p)
+	penWidth := pen.Width
 	if len(p) < 2 {
 		return nil
 	}
@@ -208,6 +215,9 @@ func openPolyToShape(p gxui.Polygon, penWidth float32) *shape {
 		inner := c.Sub(caDir.Tangent().MulS(penWidth))
 		vsEdgePos = appendVec2(vsEdgePos, c, inner)
 	}
+	if isStyledPen(pen) {
+		return strokeToShape(stripCenterline(vsEdgePos), false, pen)
+	}
 	if len(vsEdgePos) > 0 {
 		return newShape(newVertexBuffer(
 			newVertexStream("aPosition", stFloatVec2, vsEdgePos),
@@ -6,6 +6,7 @@ package gl
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/goxjs/gl"
 )
@@ -53,6 +54,9 @@ func newShaderProgram(ctx *context, vsSource, fsSource string) *shaderProgram {
 	for i := range uniforms {
 		name, size, ty := gl.GetActiveUniform(program, uint32(i))
 		location := gl.GetUniformLocation(program, name)
+		// Arrays are reported with the index of the first element. Bind the
+		// whole array by its unadorned name.
+		name = strings.TrimSuffix(name, "[0]")
 		uniforms[i] = shaderUniform{
 			name:        name,
 			size:        size,
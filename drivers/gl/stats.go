@@ -120,6 +120,11 @@ type contextStats struct {
 	shaderProgramCount int
 	frameCount         int
 	drawCallCount      int
+	partialFrameCount  int // Frames that only repainted a dirty region
+	repaintedPixels    int // Pixels repainted in the last frame
+	framePixels        int // Pixels in the last frame
+	culledCanvasCount  int // Canvases skipped in the last frame as outside the dirty region
+	layerCount         int // Layers composited in the last frame
 	timers             []timer
 }
 
@@ -141,6 +146,13 @@ func (s contextStats) String() string {
 	}
 	fmt.Fprintf(buffer, "Draw calls per frame: %d\n", s.drawCallCount)
 	fmt.Fprintf(buffer, "Frame count: %d\n", s.frameCount)
+	fmt.Fprintf(buffer, "Partial frame count: %d\n", s.partialFrameCount)
+	if s.framePixels > 0 {
+		fmt.Fprintf(buffer, "Repainted pixels: %d/%d (%.1f%%)\n", s.repaintedPixels, s.framePixels,
+			100*float32(s.repaintedPixels)/float32(s.framePixels))
+	}
+	fmt.Fprintf(buffer, "Culled canvases per frame: %d\n", s.culledCanvasCount)
+	fmt.Fprintf(buffer, "Layers per frame: %d\n", s.layerCount)
 	fmt.Fprintf(buffer, "Textures: %d\n", s.textureCount)
 	fmt.Fprintf(buffer, "Vertex stream count: %d\n", s.vertexStreamCount)
 	fmt.Fprintf(buffer, "Index buffer count: %d\n", s.indexBufferCount)
@@ -0,0 +1,121 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl
+
+import (
+	"github.com/google/gxui"
+	"github.com/google/gxui/math"
+
+	"github.com/goxjs/gl"
+)
+
+// stencilClip is a clip rectangle that is rotated or skewed in window
+// coordinates, so cannot be applied with the scissor test.
+type stencilClip struct {
+	rect      math.Rect // In local pixels.
+	transform math.Mat3 // Maps rect to window pixels.
+}
+
+// stencilPrefix returns the number of leading clips that have and want share.
+func stencilPrefix(have, want []stencilClip) int {
+	n := 0
+	for n < len(have) && n < len(want) && have[n] == want[n] {
+		n++
+	}
+	return n
+}
+
+// stencilClips returns the clips held by the stencil buffer of the bound
+// render target. Each pixel of the stencil buffer holds the number of these
+// clips containing it.
+func (c *context) stencilClips() *[]stencilClip {
+	if c.framebuffer != nil {
+		return &c.framebuffer.stencilClips
+	}
+	return &c.windowStencilClips
+}
+
+// clearStencil clears the stencil buffer of the bound render target inside
+// the scissor rectangle.
+func (c *context) clearStencil() {
+	gl.ClearStencil(0)
+	gl.Clear(gl.STENCIL_BUFFER_BIT)
+	*c.stencilClips() = nil
+}
+
+// applyStencil updates the stencil buffer of the bound render target to hold
+// the stencil clips of ds, and sets the stencil test to only pass the pixels
+// inside all of them. Clips are removed by decrementing only the pixels that
+// they incremented, so the scissor rectangle must contain the pixels of the
+// removed clips.
+func (c *context) applyStencil(ds *drawState) {
+	have, want := c.stencilClips(), ds.StencilClips
+	n := stencilPrefix(*have, want)
+	if n != len(*have) || n != len(want) {
+		c.blitter.commit(c)
+		gl.ColorMask(false, false, false, false)
+		gl.Enable(gl.STENCIL_TEST)
+		for i := len(*have); i > n; i-- {
+			gl.StencilFunc(gl.EQUAL, i, 0xff)
+			gl.StencilOp(gl.KEEP, gl.KEEP, gl.DECR)
+			c.drawStencilClip((*have)[i-1])
+		}
+		for i := n; i < len(want); i++ {
+			gl.StencilFunc(gl.EQUAL, i, 0xff)
+			gl.StencilOp(gl.KEEP, gl.KEEP, gl.INCR)
+			c.drawStencilClip(want[i])
+		}
+		gl.StencilOp(gl.KEEP, gl.KEEP, gl.KEEP)
+		gl.ColorMask(true, true, true, true)
+		*have = want
+		c.stencilDepth = -1 // The stencil function was changed.
+	}
+
+	if depth := len(want); c.stencilDepth != depth {
+		c.blitter.commit(c)
+		c.stencilDepth = depth
+		if depth == 0 {
+			gl.Disable(gl.STENCIL_TEST)
+		} else {
+			gl.Enable(gl.STENCIL_TEST)
+			gl.StencilFunc(gl.EQUAL, depth, 0xff)
+		}
+	}
+}
+
+func (c *context) drawStencilClip(clip stencilClip) {
+	ds := drawState{Transform: clip.transform}
+	c.blitter.blitRect(c, clip.rect, gxui.White, &ds)
+}
+
+// strokeMark is the stencil bit marking the pixels covered by a stroke. The
+// lower bits count the stencil clips containing the pixel.
+const strokeMark = 0x80
+
+// drawOnce calls draw twice, so that the pixels covered by its triangles are
+// only drawn once, even where the triangles overlap. The first call marks the
+// covered pixels inside the stencil clips of ds, with color writes disabled.
+// The second draws only the marked pixels, clearing each mark as it draws.
+func (c *context) drawOnce(ds *drawState, draw func()) {
+	c.blitter.commit(c)
+	depth := len(ds.StencilClips)
+	gl.Enable(gl.STENCIL_TEST)
+	gl.StencilMask(strokeMark)
+
+	gl.ColorMask(false, false, false, false)
+	gl.StencilFunc(gl.EQUAL, strokeMark|depth, strokeMark-1)
+	gl.StencilOp(gl.KEEP, gl.KEEP, gl.REPLACE)
+	draw()
+
+	gl.ColorMask(true, true, true, true)
+	gl.StencilFunc(gl.EQUAL, strokeMark|depth, 0xff)
+	gl.StencilOp(gl.KEEP, gl.KEEP, gl.ZERO)
+	draw()
+
+	gl.StencilOp(gl.KEEP, gl.KEEP, gl.KEEP)
+	gl.StencilMask(0xff)
+	c.stencilDepth = -1 // The stencil function was changed.
+	c.applyStencil(ds)
+}
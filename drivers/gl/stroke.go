@@ -0,0 +1,238 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl
+
+import (
+	"github.com/google/gxui"
+	"github.com/google/gxui/math"
+)
+
+// isStyledPen returns true if the pen requires the stroker to honor dashes,
+// caps, joins or the miter limit. Other pens use the edge strips generated
+// directly by segment().
+func isStyledPen(p gxui.Pen) bool {
+	return p.Dash != nil || p.Cap != gxui.ButtCap || p.Join != gxui.MiterJoin || p.MiterLimit != 0
+}
+
+// stripCenterline returns the line running through the middle of the
+// triangle-strip edge built by segment(), which is a list of outer and inner
+// vertex pairs. Stroking the center line with the pen width covers the same
+// area as the strip.
+func stripCenterline(vsEdgePos []float32) []math.Vec2 {
+	points := make([]math.Vec2, 0, len(vsEdgePos)/4)
+	for i := 0; i+3 < len(vsEdgePos); i += 4 {
+		outer := math.Vec2{X: vsEdgePos[i+0], Y: vsEdgePos[i+1]}
+		inner := math.Vec2{X: vsEdgePos[i+2], Y: vsEdgePos[i+3]}
+		points = append(points, outer.Add(inner).MulS(0.5))
+	}
+	return pruneDuplicateVec2s(points)
+}
+
+func pruneDuplicateVec2s(points []math.Vec2) []math.Vec2 {
+	pruned := make([]math.Vec2, 0, len(points))
+	for i, p := range points {
+		if i == 0 || pruned[len(pruned)-1].Sub(p).Len() > 0.001 {
+			pruned = append(pruned, p)
+		}
+	}
+	return pruned
+}
+
+// strokeToShape returns the triangles of the path stroked with the pen, or nil
+// if the stroke is empty.
+func strokeToShape(path []math.Vec2, closed bool, pen gxui.Pen) *shape {
+	return trianglesToShape(appendStroke(nil, path, closed, pen))
+}
+
+// trianglesToShape returns a shape drawing the triangle list tris, or nil if
+// tris is empty.
+func trianglesToShape(tris []float32) *shape {
+	if len(tris) == 0 {
+		return nil
+	}
+	return newShape(newVertexBuffer(
+		newVertexStream("aPosition", stFloatVec2, tris),
+	), nil, dmTriangles)
+}
+
+// appendStroke appends the triangles of the path stroked with the pen, split
+// into dashes if the pen is dashed, to tris.
+func appendStroke(tris []float32, path []math.Vec2, closed bool, pen gxui.Pen) []float32 {
+	if pen.Dash != nil {
+		for _, dash := range dashPath(path, closed, pen.Dash) {
+			tris = strokePath(tris, dash, false, pen)
+		}
+		return tris
+	}
+	return strokePath(tris, path, closed, pen)
+}
+
+// dashPath splits the path into the open sub-paths of the dashes.
+func dashPath(path []math.Vec2, closed bool, d *gxui.DashPattern) [][]math.Vec2 {
+	lengths := d.Lengths
+	if len(lengths)%2 == 1 {
+		lengths = append(append([]float32{}, lengths...), lengths...)
+	}
+	total := float32(0)
+	for _, l := range lengths {
+		total += math.Maxf(l, 0)
+	}
+	if closed && len(path) > 0 {
+		path = append(append([]math.Vec2{}, path...), path[0])
+	}
+	if total <= 0 || len(path) < 2 {
+		return [][]math.Vec2{path}
+	}
+
+	// Find the position in the pattern at the start of the path.
+	offset := math.Modf(d.Offset, total)
+	idx := 0
+	for offset >= math.Maxf(lengths[idx], 0) {
+		offset -= math.Maxf(lengths[idx], 0)
+		idx = (idx + 1) % len(lengths)
+	}
+	remaining := math.Maxf(lengths[idx], 0) - offset
+	on := idx%2 == 0
+
+	var dashes [][]math.Vec2
+	var current []math.Vec2
+	if on {
+		current = []math.Vec2{path[0]}
+	}
+	for i := 1; i < len(path); i++ {
+		a, b := path[i-1], path[i]
+		segLen := b.Sub(a).Len()
+		pos := float32(0)
+		for segLen-pos > remaining {
+			pos += remaining
+			p := a.Add(b.Sub(a).MulS(pos / segLen))
+			if on {
+				dashes = append(dashes, append(current, p))
+				current = nil
+			} else {
+				current = []math.Vec2{p}
+			}
+			on = !on
+			idx = (idx + 1) % len(lengths)
+			remaining = math.Maxf(lengths[idx], 0)
+		}
+		remaining -= segLen - pos
+		if on {
+			current = append(current, b)
+		}
+	}
+	if on && len(current) > 1 {
+		dashes = append(dashes, current)
+	}
+	return dashes
+}
+
+// strokePath appends the triangles of the path stroked with the pen to tris.
+// Closed paths have joins at every vertex and no caps.
+func strokePath(tris []float32, path []math.Vec2, closed bool, pen gxui.Pen) []float32 {
+	path = pruneDuplicateVec2s(path)
+	hw := pen.Width / 2
+	if closed && len(path) > 2 && path[0].Sub(path[len(path)-1]).Len() <= 0.001 {
+		path = path[:len(path)-1]
+	}
+	if len(path) < 2 {
+		if len(path) == 1 && pen.Cap == gxui.RoundCap {
+			// A zero-length dash with round caps is a dot.
+			tris = appendArc(tris, path[0], math.Vec2{X: hw}, 2*math.Pi)
+		}
+		return tris
+	}
+
+	n := len(path)
+	segments := n - 1
+	if closed {
+		segments = n
+	}
+	for i := 0; i < segments; i++ {
+		a, b := path[i], path[(i+1)%n]
+		dir := b.Sub(a).Normalize()
+		if !closed && pen.Cap == gxui.SquareCap {
+			if i == 0 {
+				a = a.Sub(dir.MulS(hw))
+			}
+			if i == segments-1 {
+				b = b.Add(dir.MulS(hw))
+			}
+		}
+		t := dir.Tangent().MulS(hw)
+		tris = appendVec2(tris,
+			a.Add(t), a.Sub(t), b.Add(t),
+			b.Add(t), a.Sub(t), b.Sub(t),
+		)
+	}
+
+	for i := 0; i < n; i++ {
+		if !closed && (i == 0 || i == n-1) {
+			continue
+		}
+		tris = appendJoin(tris, path[(i+n-1)%n], path[i], path[(i+1)%n], pen)
+	}
+
+	if !closed && pen.Cap == gxui.RoundCap {
+		start := path[1].Sub(path[0]).Normalize().Tangent().MulS(hw)
+		end := path[n-1].Sub(path[n-2]).Normalize().Tangent().MulS(-hw)
+		tris = appendArc(tris, path[0], start, math.Pi)
+		tris = appendArc(tris, path[n-1], end, math.Pi)
+	}
+	return tris
+}
+
+// appendJoin appends the join geometry for the outer corner at b, between the
+// segments a→b and b→c.
+func appendJoin(tris []float32, a, b, c math.Vec2, pen gxui.Pen) []float32 {
+	hw := pen.Width / 2
+	d0, d1 := b.Sub(a).Normalize(), c.Sub(b).Normalize()
+	cross := d0.Cross(d1)
+	if math.Absf(cross) < 1e-6 && d0.Dot(d1) > 0 {
+		return tris // Straight
+	}
+	side := float32(1)
+	if cross > 0 {
+		side = -1
+	}
+	n0, n1 := d0.Tangent().MulS(hw*side), d1.Tangent().MulS(hw*side)
+	o0, o1 := b.Add(n0), b.Add(n1)
+
+	switch pen.Join {
+	case gxui.RoundJoin:
+		sweep := math.Acosf(math.Clampf(n0.Dot(n1)/(hw*hw), -1, 1))
+		if n0.Tangent().Dot(n1) < 0 {
+			sweep = -sweep
+		}
+		return appendArc(tris, b, n0, sweep)
+	case gxui.MiterJoin:
+		limit := pen.MiterLimit
+		if limit <= 0 {
+			limit = gxui.DefaultMiterLimit
+		}
+		k := n0.Add(n1).Normalize()
+		cosHalf := k.Dot(n0) / hw
+		if cosHalf > 0 && 1/cosHalf <= limit {
+			m := b.Add(k.MulS(hw / cosHalf))
+			return appendVec2(tris, b, o0, m, b, m, o1)
+		}
+	}
+	return appendVec2(tris, b, o0, o1) // Bevel
+}
+
+// appendArc appends a triangle fan around center, starting at the radius
+// vector from and sweeping by the angle sweep in radians.
+func appendArc(tris []float32, center, from math.Vec2, sweep float32) []float32 {
+	steps := 1 + int(from.Len()*math.Absf(sweep)/2)
+	prev := center.Add(from)
+	for i := 1; i <= steps; i++ {
+		θ := sweep * float32(i) / float32(steps)
+		dir := from.MulS(math.Cosf(θ)).Add(from.Tangent().MulS(math.Sinf(θ)))
+		p := center.Add(dir)
+		tris = appendVec2(tris, center, prev, p)
+		prev = p
+	}
+	return tris
+}
@@ -0,0 +1,57 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl
+
+import (
+	"testing"
+
+	"github.com/google/gxui"
+	"github.com/google/gxui/math"
+	test "github.com/google/gxui/testing"
+)
+
+func TestDashPath(t *testing.T) {
+	path := []math.Vec2{v(0, 0), v(10, 0)}
+	dashes := dashPath(path, false, &gxui.DashPattern{Lengths: []float32{3, 2}})
+	test.AssertEquals(t, [][]math.Vec2{
+		{v(0, 0), v(3, 0)},
+		{v(5, 0), v(8, 0)},
+	}, dashes)
+}
+
+func TestDashPathOffset(t *testing.T) {
+	path := []math.Vec2{v(0, 0), v(10, 0)}
+	dashes := dashPath(path, false, &gxui.DashPattern{Lengths: []float32{3, 2}, Offset: 4})
+	test.AssertEquals(t, [][]math.Vec2{
+		{v(1, 0), v(4, 0)},
+		{v(6, 0), v(9, 0)},
+	}, dashes)
+}
+
+func TestDashPathAroundCorner(t *testing.T) {
+	path := []math.Vec2{v(0, 0), v(4, 0), v(4, 4)}
+	dashes := dashPath(path, false, &gxui.DashPattern{Lengths: []float32{6, 1}})
+	test.AssertEquals(t, [][]math.Vec2{
+		{v(0, 0), v(4, 0), v(4, 2)},
+		{v(4, 3), v(4, 4)},
+	}, dashes)
+}
+
+func TestStrokePathJoins(t *testing.T) {
+	path := []math.Vec2{v(0, 0), v(10, 0), v(10, 10)}
+	count := func(pen gxui.Pen) int {
+		return len(strokePath(nil, path, false, pen)) / 6 // Triangles
+	}
+	pen := gxui.CreatePen(2, gxui.Black)
+	pen.Join = gxui.BevelJoin
+	test.AssertEquals(t, 5, count(pen)) // 2 quads + bevel
+
+	pen.Join = gxui.MiterJoin
+	pen.MiterLimit = 10
+	test.AssertEquals(t, 6, count(pen)) // 2 quads + miter
+
+	pen.MiterLimit = 1
+	test.AssertEquals(t, 5, count(pen)) // Miter exceeds limit, bevel
+}
@@ -7,6 +7,9 @@ package gl
 import (
 	"container/list"
 	"fmt"
+	"sort"
+
+	"github.com/google/gxui"
 	"github.com/google/gxui/math"
 )
 
@@ -124,3 +127,114 @@ func triangulate(edges []math.Vec2) []math.Vec2 {
 	// assert.True(l.Len() < 3, "Failed to prune an ear! edges: %#v, out: %v", edges, out)
 	return out
 }
+
+// pathEdge is a non-horizontal edge of a contour, with top above bottom.
+type pathEdge struct {
+	top, bottom math.Vec2
+	winding     int // +1 if the contour runs downwards, -1 if upwards.
+}
+
+func (e pathEdge) xAt(y float32) float32 {
+	f := (y - e.top.Y) / (e.bottom.Y - e.top.Y)
+	return e.top.X + (e.bottom.X-e.top.X)*f
+}
+
+type float32Slice []float32
+
+func (s float32Slice) Len() int           { return len(s) }
+func (s float32Slice) Less(i, j int) bool { return s[i] < s[j] }
+func (s float32Slice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+type slabEdge struct {
+	x0, x1  float32 // X at the top and bottom of the slab
+	winding int
+}
+
+type slabEdges []slabEdge
+
+func (s slabEdges) Len() int           { return len(s) }
+func (s slabEdges) Less(i, j int) bool { return s[i].x0+s[i].x1 < s[j].x0+s[j].x1 }
+func (s slabEdges) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// triangulateContours returns the triangles filling the closed contours using
+// the fill rule. Unlike triangulate, the contours may intersect each other or
+// themselves, and inner contours form holes.
+//
+// The area is split into horizontal slabs at every vertex and intersection,
+// so that no edges cross inside a slab. Each slab is then filled with the
+// trapezoids between adjacent edges where the winding number is inside.
+func triangulateContours(contours [][]math.Vec2, rule gxui.FillRule) []math.Vec2 {
+	edges := []pathEdge{}
+	ys := float32Slice{}
+	for _, contour := range contours {
+		contour = pruneEdgeDuplicates(contour)
+		if len(contour) < 3 {
+			continue
+		}
+		for i, a := range contour {
+			b := contour[(i+1)%len(contour)]
+			ys = append(ys, a.Y)
+			switch {
+			case b.Y-a.Y > epsilon:
+				edges = append(edges, pathEdge{a, b, 1})
+			case a.Y-b.Y > epsilon:
+				edges = append(edges, pathEdge{b, a, -1})
+			}
+		}
+	}
+
+	// Slabs must also be split where edges cross.
+	for i, e := range edges {
+		for _, f := range edges[i+1:] {
+			if e.bottom.Y <= f.top.Y || f.bottom.Y <= e.top.Y {
+				continue
+			}
+			r, s := e.bottom.Sub(e.top), f.bottom.Sub(f.top)
+			rxs := r.Cross(s)
+			if math.Absf(rxs) < epsilon {
+				continue // Parallel
+			}
+			qp := f.top.Sub(e.top)
+			t, u := qp.Cross(s)/rxs, qp.Cross(r)/rxs
+			if t > 0 && t < 1 && u > 0 && u < 1 {
+				ys = append(ys, e.top.Y+r.Y*t)
+			}
+		}
+	}
+	sort.Sort(ys)
+
+	inside := func(w int) bool {
+		if rule == gxui.EvenOddFill {
+			return w%2 != 0
+		}
+		return w != 0
+	}
+
+	out := []math.Vec2{}
+	slab := slabEdges{}
+	for i := 1; i < len(ys); i++ {
+		y0, y1 := ys[i-1], ys[i]
+		if y1-y0 < epsilon {
+			continue
+		}
+		slab = slab[:0]
+		for _, e := range edges {
+			if e.top.Y <= y0+epsilon && e.bottom.Y >= y1-epsilon {
+				slab = append(slab, slabEdge{e.xAt(y0), e.xAt(y1), e.winding})
+			}
+		}
+		sort.Sort(slab)
+		w := 0
+		for j := 0; j+1 < len(slab); j++ {
+			w += slab[j].winding
+			if inside(w) {
+				l, r := slab[j], slab[j+1]
+				out = append(out,
+					math.Vec2{X: l.x0, Y: y0}, math.Vec2{X: r.x0, Y: y0}, math.Vec2{X: l.x1, Y: y1},
+					math.Vec2{X: l.x1, Y: y1}, math.Vec2{X: r.x0, Y: y0}, math.Vec2{X: r.x1, Y: y1},
+				)
+			}
+		}
+	}
+	return out
+}
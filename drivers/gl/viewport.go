@@ -6,7 +6,7 @@ package gl
 
 import (
 	"sync"
-	"sync/atomic"
+	"time"
 	"unicode"
 
 	"github.com/google/gxui"
@@ -29,46 +29,64 @@ type viewport struct {
 	context                 *context
 	window                  *glfw.Window
 	canvas                  *canvas
+	framebuffer             *framebuffer
+	multisample             *framebuffer // Drawn to and resolved into framebuffer, if multisampled.
 	fullscreen              bool
-	scaling                 float32
+	scaling                 float32 // monitorScaling * userScaling
+	monitorScaling          float32 // The content scale of the monitor showing the window
+	userScaling             float32 // The scale set with SetScale
 	sizeDipsUnscaled        math.Size
 	sizeDips                math.Size
 	sizePixels              math.Size
 	position                math.Point
 	title                   string
+	cursor                  viewportCursor
 	pendingMouseMoveEvent   *gxui.MouseEvent
 	pendingMouseScrollEvent *gxui.MouseEvent
 	scrollAccumX            float64
 	scrollAccumY            float64
+	scrollDeltaX            float64
+	scrollDeltaY            float64
 	destroyed               bool
 	redrawCount             uint32
+	pendingDirty            dirtyRegion // The dirty regions not yet rendered
+	renderOptions           gxui.RenderOptions
+	frameRequests           []func(time.Time)
+	framePending            bool   // A frame is being produced for frame requests
+	presentCount            uint32 // The number of frames presented
+	framePresentCount       uint32 // presentCount when the pending frame began
 
 	// Broadcasts to application thread
-	onClose       gxui.Event // ()
-	onResize      gxui.Event // ()
-	onMouseMove   gxui.Event // (gxui.MouseEvent)
-	onMouseEnter  gxui.Event // (gxui.MouseEvent)
-	onMouseExit   gxui.Event // (gxui.MouseEvent)
-	onMouseDown   gxui.Event // (gxui.MouseEvent)
-	onMouseUp     gxui.Event // (gxui.MouseEvent)
-	onMouseScroll gxui.Event // (gxui.MouseEvent)
-	onKeyDown     gxui.Event // (gxui.KeyboardEvent)
-	onKeyUp       gxui.Event // (gxui.KeyboardEvent)
-	onKeyRepeat   gxui.Event // (gxui.KeyboardEvent)
-	onKeyStroke   gxui.Event // (gxui.KeyStrokeEvent)
+	onClose        gxui.Event // ()
+	onResize       gxui.Event // ()
+	onScaleChanged gxui.Event // ()
+	onMouseMove    gxui.Event // (gxui.MouseEvent)
+	onMouseEnter   gxui.Event // (gxui.MouseEvent)
+	onMouseExit    gxui.Event // (gxui.MouseEvent)
+	onMouseDown    gxui.Event // (gxui.MouseEvent)
+	onMouseUp      gxui.Event // (gxui.MouseEvent)
+	onMouseScroll  gxui.Event // (gxui.MouseEvent)
+	onKeyDown      gxui.Event // (gxui.KeyboardEvent)
+	onKeyUp        gxui.Event // (gxui.KeyboardEvent)
+	onKeyRepeat    gxui.Event // (gxui.KeyboardEvent)
+	onKeyStroke    gxui.Event // (gxui.KeyStrokeEvent)
 	// Broadcasts to driver thread
 	onDestroy gxui.Event
 }
 
-func newViewport(driver *driver, width, height int, title string, fullscreen bool) *viewport {
+func newViewport(driver *driver, width, height int, title string, fullscreen bool, options gxui.RenderOptions) *viewport {
 	v := &viewport{
-		fullscreen: fullscreen,
-		scaling:    1,
-		title:      title,
+		fullscreen:     fullscreen,
+		scaling:        1,
+		monitorScaling: 1,
+		userScaling:    1,
+		title:          title,
 	}
 
 	glfw.DefaultWindowHints()
-	glfw.WindowHint(glfw.Samples, 4)
+	glfw.WindowHint(glfw.StencilBits, 8) // For clips that are not axis-aligned.
+	// Viewports are drawn to framebuffers, which are multisampled instead.
+	glfw.WindowHint(glfw.Samples, 0)
 	var monitor *glfw.Monitor
 	if fullscreen {
 		monitor = glfw.GetPrimaryMonitor()
@@ -85,6 +103,14 @@ func newViewport(driver *driver, width, height int, title string, fullscreen boo
 
 	wnd.MakeContextCurrent()
 
+	// Wait for the display's refresh when presenting, so that frames are
+	// produced at the rate of the display.
+	glfw.SwapInterval(1)
+
+	// The platform may not provide the number of samples requested.
+	options.Samples = math.Clamp(options.Samples, 1, math.Max(maxSamples(), 1))
+	v.renderOptions = options
+
 	v.context = newContext()
 
 	cursorPoint := func(x, y float64) math.Point {
@@ -101,19 +127,17 @@ func newViewport(driver *driver, width, height int, title string, fullscreen boo
 		v.Lock()
 		v.position = math.NewPoint(x, y)
 		v.Unlock()
+		v.updateScaling()
 	})
-	wnd.SetSizeCallback(func(_ *glfw.Window, w, h int) {
-		v.Lock()
-		v.sizeDipsUnscaled = math.Size{W: w, H: h}
-		v.sizeDips = v.sizeDipsUnscaled.ScaleS(1 / v.scaling)
-		v.Unlock()
-		v.onResize.Fire()
+	// The window and framebuffer sizes are reported by separate callbacks,
+	// but change together. Both are read by each callback, so that the scale
+	// is never calculated from the new size of one and the old of the other.
+	wnd.SetSizeCallback(func(w *glfw.Window, _, _ int) {
+		v.resize(w.GetSize())
 	})
-	wnd.SetFramebufferSizeCallback(func(_ *glfw.Window, w, h int) {
-		v.Lock()
-		v.sizePixels = math.Size{W: w, H: h}
-		v.Unlock()
-		gl.Viewport(0, 0, w, h)
+	wnd.SetFramebufferSizeCallback(func(w *glfw.Window, fw, fh int) {
+		v.resize(w.GetSize())
+		gl.Viewport(0, 0, fw, fh)
 		gl.ClearColor(clearColorR, clearColorG, clearColorB, 1.0)
 		gl.Clear(gl.COLOR_BUFFER_BIT)
 	})
@@ -156,7 +180,9 @@ func newViewport(driver *driver, width, height int, title string, fullscreen boo
 				ev := *v.pendingMouseScrollEvent
 				v.pendingMouseScrollEvent = nil
 				ev.ScrollX, ev.ScrollY = int(v.scrollAccumX), int(v.scrollAccumY)
-				if ev.ScrollX != 0 || ev.ScrollY != 0 {
+				ev.ScrollDeltaX, ev.ScrollDeltaY = float32(v.scrollDeltaX), float32(v.scrollDeltaY)
+				v.scrollDeltaX, v.scrollDeltaY = 0, 0
+				if ev.ScrollX != 0 || ev.ScrollY != 0 || ev.ScrollDeltaX != 0 || ev.ScrollDeltaY != 0 {
 					v.scrollAccumX -= float64(ev.ScrollX)
 					v.scrollAccumY -= float64(ev.ScrollY)
 					v.Unlock()
@@ -169,6 +195,8 @@ func newViewport(driver *driver, width, height int, title string, fullscreen boo
 		v.pendingMouseScrollEvent.Point = p
 		v.scrollAccumX += xoff * platform.ScrollSpeed
 		v.scrollAccumY += yoff * platform.ScrollSpeed
+		v.scrollDeltaX += xoff * platform.ScrollSpeed
+		v.scrollDeltaY += yoff * platform.ScrollSpeed
 		v.pendingMouseScrollEvent.State = getMouseState(w)
 		v.Unlock()
 	})
@@ -220,7 +248,7 @@ func newViewport(driver *driver, width, height int, title string, fullscreen boo
 	})
 	wnd.SetRefreshCallback(func(w *glfw.Window) {
 		if v.canvas != nil {
-			v.render()
+			v.render(fullRedraw)
 		}
 	})
 
@@ -241,6 +269,7 @@ func newViewport(driver *driver, width, height int, title string, fullscreen boo
 	v.driver = driver
 	v.onClose = driver.createAppEvent(func() {})
 	v.onResize = driver.createAppEvent(func() {})
+	v.onScaleChanged = driver.createAppEvent(func() {})
 	v.onMouseMove = gxui.CreateEvent(func(gxui.MouseEvent) {})
 	v.onMouseEnter = driver.createAppEvent(func(gxui.MouseEvent) {})
 	v.onMouseExit = driver.createAppEvent(func(gxui.MouseEvent) {})
@@ -256,24 +285,114 @@ func newViewport(driver *driver, width, height int, title string, fullscreen boo
 	v.sizeDips = v.sizeDipsUnscaled.ScaleS(1 / v.scaling)
 	v.sizePixels = math.Size{W: fw, H: fh}
 	v.position = math.Point{X: posX, Y: posY}
+	v.updateScaling()
 	return v
 }
 
+// resize reads the size of the window's framebuffer, and applies it with the
+// window size w, h. resize must only be called on the driver routine.
+func (v *viewport) resize(w, h int) {
+	fw, fh := v.window.GetFramebufferSize()
+	v.setSizes(math.Size{W: w, H: h}, math.Size{W: fw, H: fh})
+}
+
+// setSizes sets the size of the window in screen coordinates and of its
+// framebuffer in pixels, then updates the scaling. OnResize is fired once if
+// either size or the scale has changed.
+func (v *viewport) setSizes(sizeUnscaled, sizePixels math.Size) {
+	v.Lock()
+	changed := sizeUnscaled != v.sizeDipsUnscaled || sizePixels != v.sizePixels
+	v.sizeDipsUnscaled = sizeUnscaled
+	v.sizeDips = v.sizeDipsUnscaled.ScaleS(1 / v.scaling)
+	v.sizePixels = sizePixels
+	v.Unlock()
+
+	if !v.updateScaling() && changed {
+		v.onResize.Fire()
+	}
+}
+
+// updateScaling applies the content scale of the monitor showing the window,
+// firing OnResize and OnScaleChanged and returning true if the scale has
+// changed. On platforms where the framebuffer is already larger than the
+// window, such as OS X, the content scale is reduced by the difference.
+// updateScaling must only be called on the driver routine.
+func (v *viewport) updateScaling() bool {
+	v.Lock()
+	bounds := v.sizeDipsUnscaled.Rect().Offset(v.position)
+	sizePixels := v.sizePixels
+	v.Unlock()
+
+	scale := monitorScale(bounds)
+	if bounds.W() > 0 && sizePixels.W > 0 {
+		scale *= float32(bounds.W()) / float32(sizePixels.W)
+	}
+
+	v.Lock()
+	changed := scale != v.monitorScaling
+	if changed {
+		v.monitorScaling = scale
+		v.scaling = v.monitorScaling * v.userScaling
+		v.sizeDips = v.sizeDipsUnscaled.ScaleS(1 / v.scaling)
+	}
+	v.Unlock()
+
+	if changed {
+		v.onResize.Fire()
+		v.onScaleChanged.Fire()
+	}
+	return changed
+}
+
 // Driver methods
 // These methods are all called on the driver routine
-func (v *viewport) render() {
+
+// render draws the canvas to the window. The canvas is drawn to an offscreen
+// framebuffer which is then copied to the window, so only the pixels of the
+// dirty region need to be repainted. Multisampled viewports draw to a
+// multisampled framebuffer, and the samples of the repainted pixels are
+// resolved into the offscreen framebuffer.
+func (v *viewport) render(dirty dirtyRegion) {
 	if v.destroyed {
 		return
 	}
 
+	sizePixels := v.SizePixels()
+	if sizePixels.W <= 0 || sizePixels.H <= 0 {
+		return // Minimized
+	}
+
 	v.window.MakeContextCurrent()
 
+	options := v.RenderOptions()
+
 	ctx := v.context
-	ctx.beginDraw(v.SizeDips(), v.SizePixels())
+	ctx.beginDraw(v.SizeDips(), sizePixels, options)
 
-	dss := drawStateStack{drawState{
-		ClipPixels: v.sizePixels.Rect(),
-	}}
+	if !v.framebuffersValid(sizePixels, options.Samples) {
+		v.destroyFramebuffers()
+		v.framebuffer = newFramebuffer(sizePixels)
+		if options.Samples > 1 {
+			v.multisample = newMultisampleFramebuffer(sizePixels, options.Samples)
+		}
+		dirty = fullRedraw
+	}
+
+	clip := dirty.clipPixels(ctx.resolution, sizePixels)
+	if !dirty.all {
+		ctx.stats.partialFrameCount++
+	}
+
+	if v.multisample != nil {
+		ctx.bindFramebuffer(v.multisample)
+	} else {
+		ctx.bindFramebuffer(v.framebuffer)
+	}
+	dss := drawStateStack{newDrawState(clip)}
+	ctx.apply(dss.head())
+	gl.ClearColor(clearColorR, clearColorG, clearColorB, 1.0)
+	gl.Clear(gl.COLOR_BUFFER_BIT)
+	ctx.clearStencil()
 
 	v.canvas.draw(ctx, &dss)
 	if len(dss) != 1 {
@@ -283,39 +402,165 @@ func (v *viewport) render() {
 	ctx.apply(dss.head())
 	ctx.blitter.commit(ctx)
 
+	if v.multisample != nil {
+		ctx.resolve(v.multisample, v.framebuffer, clip)
+	}
+
+	// Copy the framebuffer to the window.
+	ctx.bindFramebuffer(nil)
+	present := newDrawState(sizePixels.Rect())
+	ctx.apply(&present)
+	ctx.blitter.blit(ctx, v.framebuffer.tc, present.ClipPixels, present.ClipPixels, &present)
+
 	if viewportDebugEnabled {
 		v.drawFrameUpdate(ctx)
 	}
 
+	ctx.stats.repaintedPixels = clip.Size().Area()
+	ctx.stats.framePixels = sizePixels.Area()
 	ctx.endDraw()
 
 	v.window.SwapBuffers()
+	v.presentCount++
+}
+
+// frame begins a frame for the pending frame requests, calling them on the UI
+// go-routine. The changes they make to the window are drawn by endFrame.
+func (v *viewport) frame() {
+	v.Lock()
+	requests := v.frameRequests
+	v.frameRequests = nil
+	v.Unlock()
+	if v.destroyed {
+		return
+	}
+	v.framePresentCount = v.presentCount
+	now := time.Now()
+	v.driver.Call(func() {
+		for _, f := range requests {
+			f(now)
+		}
+		// Redraws requested by f are queued on the UI go-routine, and draw
+		// their canvas before endFrame is called.
+		v.driver.Call(func() { v.driver.asyncDriver(v.endFrame) })
+	})
+}
+
+// endFrame presents the frame begun by frame, even if the canvas has not
+// changed, then begins the next frame if there are more frame requests. As
+// presenting waits for the display's refresh, frames follow the display rate.
+func (v *viewport) endFrame() {
+	if v.destroyed {
+		return
+	}
+	if v.presentCount == v.framePresentCount && v.canvas != nil {
+		v.window.MakeContextCurrent()
+		v.render(dirtyRegion{})
+	}
+	v.Lock()
+	next := len(v.frameRequests) > 0
+	v.framePending = next
+	v.Unlock()
+	if next {
+		v.frame()
+	}
+}
+
+// framebuffersValid returns true if the viewport's framebuffers can be drawn
+// to at the size with the number of samples per pixel. Otherwise they must be
+// created again, and the viewport repainted in full.
+func (v *viewport) framebuffersValid(sizePixels math.Size, samples int) bool {
+	if v.framebuffer == nil || v.framebuffer.sizePixels != sizePixels {
+		return false
+	}
+	if samples <= 1 {
+		return v.multisample == nil
+	}
+	return v.multisample != nil && v.multisample.sizePixels == sizePixels && v.multisample.samples == samples
+}
+
+func (v *viewport) destroyFramebuffers() {
+	if v.framebuffer != nil {
+		v.framebuffer.destroy()
+		v.framebuffer = nil
+	}
+	if v.multisample != nil {
+		v.multisample.destroy()
+		v.multisample = nil
+	}
 }
 
 func (v *viewport) drawFrameUpdate(ctx *context) {
 	dx := (ctx.stats.frameCount * 10) & 0xFF
 	r := math.CreateRect(dx-5, 0, dx+5, 3)
-	ds := &drawState{}
-	ctx.blitter.blitRect(ctx, r, gxui.White, ds)
+	ds := newDrawState(math.Rect{})
+	ctx.blitter.blitRect(ctx, r, gxui.White, &ds)
 }
 
 // gxui.viewport compliance
 // These methods are all called on the application routine
 func (v *viewport) SetCanvas(cc gxui.Canvas) {
-	cnt := atomic.AddUint32(&v.redrawCount, 1)
+	v.setCanvas(cc, math.Rect{}, true)
+}
+
+func (v *viewport) SetCanvasRegion(cc gxui.Canvas, dirty math.Rect) {
+	v.setCanvas(cc, dirty, false)
+}
+
+func (v *viewport) setCanvas(cc gxui.Canvas, dirty math.Rect, all bool) {
 	c := cc.(*canvas)
+	v.Lock()
+	v.redrawCount++
+	cnt := v.redrawCount
+	v.pendingDirty.merge(dirtyRegion{rect: dirty, all: all})
+	v.Unlock()
 	v.driver.asyncDriver(func() {
-		// Only use the canvas of the most recent SetCanvas call.
+		// Only use the canvas of the most recent SetCanvas call. The dirty
+		// regions of any skipped calls are accumulated into this render.
 		v.window.MakeContextCurrent()
-		if atomic.LoadUint32(&v.redrawCount) == cnt {
-			v.canvas = c
-			if v.canvas != nil {
-				v.render()
-			}
+		v.Lock()
+		if v.redrawCount != cnt {
+			v.Unlock()
+			return
+		}
+		dirty := v.pendingDirty
+		v.pendingDirty = dirtyRegion{}
+		v.Unlock()
+		v.canvas = c
+		if v.canvas != nil {
+			v.render(dirty)
+		}
+	})
+}
+
+func (v *viewport) RenderOptions() gxui.RenderOptions {
+	v.Lock()
+	defer v.Unlock()
+	return v.renderOptions
+}
+
+func (v *viewport) SetRenderOptions(o gxui.RenderOptions) {
+	v.Lock()
+	o.Samples = v.renderOptions.Samples
+	v.renderOptions = o
+	v.Unlock()
+	v.driver.asyncDriver(func() {
+		if v.canvas != nil {
+			v.render(fullRedraw)
 		}
 	})
 }
 
+func (v *viewport) RequestFrame(f func(time.Time)) {
+	v.Lock()
+	defer v.Unlock()
+	v.frameRequests = append(v.frameRequests, f)
+	if !v.framePending {
+		v.framePending = true
+		v.driver.asyncDriver(v.frame)
+	}
+}
+
 func (v *viewport) Scale() float32 {
 	v.Lock()
 	defer v.Unlock()
@@ -325,10 +570,12 @@ func (v *viewport) Scale() float32 {
 func (v *viewport) SetScale(s float32) {
 	v.Lock()
 	defer v.Unlock()
-	if s != v.scaling {
-		v.scaling = s
-		v.sizeDips = v.sizeDipsUnscaled.ScaleS(1 / s)
+	if s != v.userScaling {
+		v.userScaling = s
+		v.scaling = v.monitorScaling * v.userScaling
+		v.sizeDips = v.sizeDipsUnscaled.ScaleS(1 / v.scaling)
 		v.onResize.Fire()
+		v.onScaleChanged.Fire()
 	}
 }
 
@@ -382,6 +629,15 @@ func (v *viewport) SetPosition(pos math.Point) {
 	})
 }
 
+func (v *viewport) SetCursor(c gxui.Cursor) {
+	v.driver.asyncDriver(func() {
+		if v.destroyed {
+			return
+		}
+		v.cursor.set(v.driver, v.window, c)
+	})
+}
+
 func (v *viewport) Fullscreen() bool {
 	return v.fullscreen
 }
@@ -403,6 +659,10 @@ func (v *viewport) OnResize(f func()) gxui.EventSubscription {
 	return v.onResize.Listen(f)
 }
 
+func (v *viewport) OnScaleChanged(f func()) gxui.EventSubscription {
+	return v.onScaleChanged.Listen(f)
+}
+
 func (v *viewport) OnClose(f func()) gxui.EventSubscription {
 	return v.onClose.Listen(f)
 }
@@ -452,8 +712,10 @@ func (v *viewport) Destroy() {
 		if !v.destroyed {
 			v.window.MakeContextCurrent()
 			v.canvas = nil
+			v.destroyFramebuffers()
 			v.context.destroy()
 			v.window.Destroy()
+			v.cursor.destroy()
 			v.onDestroy.Fire()
 			v.destroyed = true
 		}
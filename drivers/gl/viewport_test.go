@@ -0,0 +1,105 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/gxui"
+	"github.com/google/gxui/math"
+	test "github.com/google/gxui/testing"
+)
+
+func TestFramebuffersValid(t *testing.T) {
+	size := math.Size{W: 200, H: 100}
+	samples := gxui.DefaultRenderOptions.Samples
+	v := &viewport{}
+	test.AssertEquals(t, false, v.framebuffersValid(size, samples))
+
+	// With the default options, frames after the first only repaint the dirty
+	// region of the multisampled framebuffer.
+	v.framebuffer = &framebuffer{sizePixels: size}
+	v.multisample = &framebuffer{sizePixels: size, samples: samples}
+	test.AssertEquals(t, true, samples > 1)
+	test.AssertEquals(t, true, v.framebuffersValid(size, samples))
+
+	test.AssertEquals(t, false, v.framebuffersValid(math.Size{W: 201, H: 100}, samples))
+	test.AssertEquals(t, false, v.framebuffersValid(size, 1))
+	v.multisample.sizePixels = math.Size{W: 10, H: 10}
+	test.AssertEquals(t, false, v.framebuffersValid(size, samples))
+
+	v.multisample = nil
+	test.AssertEquals(t, true, v.framebuffersValid(size, 1))
+	test.AssertEquals(t, false, v.framebuffersValid(size, samples))
+}
+
+func TestViewportResizeKeepsScale(t *testing.T) {
+	defer func(f func() []monitor) { queryMonitors = f }(queryMonitors)
+	queryMonitors = func() []monitor {
+		return []monitor{{bounds: math.CreateRect(0, 0, 3840, 2160), scale: 2}}
+	}
+	resized, scaled := 0, 0
+	v := &viewport{
+		scaling:        1,
+		monitorScaling: 1,
+		userScaling:    1,
+		onResize:       gxui.CreateEvent(func() {}),
+		onScaleChanged: gxui.CreateEvent(func() {}),
+	}
+	v.onResize.Listen(func() { resized++ })
+	v.onScaleChanged.Listen(func() { scaled++ })
+
+	v.setSizes(math.Size{W: 800, H: 600}, math.Size{W: 800, H: 600})
+	test.AssertEquals(t, float32(2), v.Scale())
+	test.AssertEquals(t, 1, resized)
+	test.AssertEquals(t, 1, scaled)
+
+	// Resizing the window keeps the scale.
+	v.setSizes(math.Size{W: 1000, H: 700}, math.Size{W: 1000, H: 700})
+	test.AssertEquals(t, float32(2), v.Scale())
+	test.AssertEquals(t, math.Size{W: 500, H: 350}, v.SizeDips())
+	test.AssertEquals(t, 2, resized)
+	test.AssertEquals(t, 1, scaled)
+
+	// The second size callback of the resize changes nothing.
+	v.setSizes(math.Size{W: 1000, H: 700}, math.Size{W: 1000, H: 700})
+	test.AssertEquals(t, 2, resized)
+	test.AssertEquals(t, 1, scaled)
+}
+
+func TestRequestFrame(t *testing.T) {
+	d := &driver{pendingApp: make(chan func(), 8)}
+	v := &viewport{driver: d, framePending: true}
+	var times []time.Time
+	request := func(frameTime time.Time) {
+		times = append(times, frameTime)
+		if len(times) == 1 {
+			v.RequestFrame(func(time.Time) {})
+		}
+	}
+	v.RequestFrame(request)
+	v.RequestFrame(request)
+
+	// The requests are batched into one frame, and called with its time.
+	v.frame()
+	(<-d.pendingApp)()
+	test.AssertEquals(t, 2, len(times))
+	test.AssertEquals(t, times[0], times[1])
+
+	// Requests made by the frame wait for the next frame, which begins when
+	// the frame is presented.
+	test.AssertEquals(t, 1, len(d.pendingApp)) // Presents the frame.
+	<-d.pendingApp
+	test.AssertEquals(t, 1, len(v.frameRequests))
+	v.endFrame()
+	test.AssertEquals(t, true, v.framePending)
+	test.AssertEquals(t, 0, len(v.frameRequests))
+	(<-d.pendingApp)()
+	<-d.pendingApp
+	v.endFrame()
+	test.AssertEquals(t, false, v.framePending)
+	test.AssertEquals(t, 0, len(d.pendingApp))
+}
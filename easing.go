@@ -0,0 +1,113 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"github.com/google/gxui/math"
+)
+
+// Easing maps the linear progress of an animation, from 0 to 1, to the eased
+// progress. Eased progress may leave the range 0 to 1 to overshoot, but must
+// be 0 at 0 and 1 at 1.
+type Easing func(t float32) float32
+
+var (
+	EaseLinear Easing = func(t float32) float32 { return t }
+
+	EaseInQuad    Easing = func(t float32) float32 { return t * t }
+	EaseOutQuad   Easing = func(t float32) float32 { return 1 - (1-t)*(1-t) }
+	EaseInOutQuad Easing = easeInOut(EaseInQuad)
+
+	EaseInCubic    Easing = func(t float32) float32 { return t * t * t }
+	EaseOutCubic   Easing = func(t float32) float32 { return 1 - (1-t)*(1-t)*(1-t) }
+	EaseInOutCubic Easing = easeInOut(EaseInCubic)
+
+	EaseInSine    Easing = func(t float32) float32 { return 1 - math.Cosf(t*math.Pi/2) }
+	EaseOutSine   Easing = func(t float32) float32 { return math.Sinf(t * math.Pi / 2) }
+	EaseInOutSine Easing = easeInOut(EaseInSine)
+
+	// EaseOutBack overshoots the end by about 10% before settling.
+	EaseOutBack Easing = func(t float32) float32 {
+		const s = 1.70158
+		t--
+		return 1 + (s+1)*t*t*t + s*t*t
+	}
+
+	// EaseOutBounce bounces against the end like a dropped ball.
+	EaseOutBounce Easing = func(t float32) float32 {
+		const n, d = 7.5625, 2.75
+		switch {
+		case t < 1/d:
+			return n * t * t
+		case t < 2/d:
+			t -= 1.5 / d
+			return n*t*t + 0.75
+		case t < 2.5/d:
+			t -= 2.25 / d
+			return n*t*t + 0.9375
+		default:
+			t -= 2.625 / d
+			return n*t*t + 0.984375
+		}
+	}
+)
+
+// easeInOut returns an easing that applies in for the first half of the
+// progress, and in reversed for the second half.
+func easeInOut(in Easing) Easing {
+	return func(t float32) float32 {
+		if t < 0.5 {
+			return in(t*2) / 2
+		}
+		return 1 - in((1-t)*2)/2
+	}
+}
+
+// CreateCubicBezierEasing returns the easing described by the cubic bezier
+// curve from (0, 0) to (1, 1) with the control points (x1, y1) and (x2, y2),
+// as used by CSS transitions. x1 and x2 must be in the range 0 to 1.
+func CreateCubicBezierEasing(x1, y1, x2, y2 float32) Easing {
+	if x1 < 0 || x1 > 1 || x2 < 0 || x2 > 1 {
+		panic("Cubic bezier easing control points must have X coordinates between 0 and 1")
+	}
+	bezier := func(a, b, t float32) float32 {
+		u := 1 - t
+		return 3*u*u*t*a + 3*u*t*t*b + t*t*t
+	}
+	slope := func(a, b, t float32) float32 {
+		u := 1 - t
+		return 3*u*u*a + 6*u*t*(b-a) + 3*t*t*(1-b)
+	}
+	return func(x float32) float32 {
+		if x <= 0 || x >= 1 {
+			return x
+		}
+		// Solve bezier(x1, x2, t) = x for t with Newton's method, falling
+		// back to bisection where the curve is flat.
+		t := x
+		for i := 0; i < 8; i++ {
+			d := bezier(x1, x2, t) - x
+			if math.Absf(d) < 1e-5 {
+				return bezier(y1, y2, t)
+			}
+			s := slope(x1, x2, t)
+			if math.Absf(s) < 1e-6 {
+				break
+			}
+			t -= d / s
+		}
+		lo, hi := float32(0), float32(1)
+		t = x
+		for i := 0; i < 32; i++ {
+			if bezier(x1, x2, t) < x {
+				lo = t
+			} else {
+				hi = t
+			}
+			t = (lo + hi) / 2
+		}
+		return bezier(y1, y2, t)
+	}
+}
@@ -5,10 +5,11 @@
 package gxui
 
 type FocusController struct {
-	window             Window
-	focus              Focusable
-	setFocusCount      int
-	detachSubscription EventSubscription
+	window              Window
+	focus               Focusable
+	setFocusCount       int
+	detachSubscription  EventSubscription
+	enabledSubscription EventSubscription
 }
 
 func CreateFocusController(window Window) *FocusController {
@@ -26,6 +27,7 @@ func (c *FocusController) SetFocus(f Focusable) {
 		o := c.focus
 		c.focus = nil
 		c.detachSubscription.Unlisten()
+		c.enabledSubscription.Unlisten()
 		o.LostFocus()
 		if c.focus != nil {
 			return // Something in LostFocus() called SetFocus(). Respect their call.
@@ -34,6 +36,11 @@ func (c *FocusController) SetFocus(f Focusable) {
 	c.focus = f
 	if c.focus != nil {
 		c.detachSubscription = c.focus.OnDetach(func() { c.SetFocus(nil) })
+		c.enabledSubscription = c.focus.OnEnabledChanged(func() {
+			if !c.focus.IsEnabled() {
+				c.SetFocus(nil)
+			}
+		})
 		c.focus.GainedFocus()
 	}
 }
@@ -107,7 +114,7 @@ func (c *FocusController) NextChildFocusable(p Parent, after Control, forwards b
 			return focusable
 		}
 
-		if container, ok := f.Control.(Container); ok {
+		if container, ok := f.Control.(Container); ok && f.Control.IsEnabled() {
 			focusable := c.NextChildFocusable(container, nil, forwards)
 			if focusable != nil {
 				return focusable
@@ -117,9 +124,11 @@ func (c *FocusController) NextChildFocusable(p Parent, after Control, forwards b
 	return nil
 }
 
+// Focusable returns ctrl as a Focusable if it can currently acquire focus, or
+// nil if it is not focusable or is disabled.
 func (c *FocusController) Focusable(ctrl Control) Focusable {
 	focusable, _ := ctrl.(Focusable)
-	if focusable != nil && focusable.IsFocusable() {
+	if focusable != nil && focusable.IsFocusable() && focusable.IsEnabled() {
 		return focusable
 	}
 	return nil
@@ -0,0 +1,180 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"testing"
+
+	test "github.com/google/gxui/testing"
+)
+
+type testFocusableContainer interface {
+	Focusable
+	Container
+}
+
+// testFocusControl is a focusable container that logs its focus and keyboard
+// events. Unlike the mixins, disabling it does not disable its children.
+type testFocusControl struct {
+	testFocusableContainer // Only the methods below are implemented.
+	name                   string
+	parent                 Parent
+	children               Children
+	enabled                bool
+	focusable              bool
+	log                    *[]string
+	onEnabledChanged       Event
+	onDetach               Event
+}
+
+func (c *testFocusControl) Parent() Parent                 { return c.parent }
+func (c *testFocusControl) Children() Children             { return c.children }
+func (c *testFocusControl) IsEnabled() bool                { return c.enabled }
+func (c *testFocusControl) IsFocusable() bool              { return c.focusable }
+func (c *testFocusControl) GainedFocus()                   { c.record("gained") }
+func (c *testFocusControl) LostFocus()                     { c.record("lost") }
+func (c *testFocusControl) HandleRoutedEvent(*RoutedEvent) {}
+func (c *testFocusControl) KeyDown(KeyboardEvent)          { c.record("down") }
+func (c *testFocusControl) KeyUp(KeyboardEvent)            { c.record("up") }
+func (c *testFocusControl) KeyPress(KeyboardEvent) bool    { c.record("press"); return false }
+func (c *testFocusControl) KeyStroke(KeyStrokeEvent) bool  { c.record("stroke"); return false }
+
+func (c *testFocusControl) OnEnabledChanged(f func()) EventSubscription {
+	return c.onEnabledChanged.Listen(f)
+}
+
+func (c *testFocusControl) OnDetach(f func()) EventSubscription {
+	return c.onDetach.Listen(f)
+}
+
+func (c *testFocusControl) record(s string) {
+	*c.log = append(*c.log, c.name+" "+s)
+}
+
+func (c *testFocusControl) setEnabled(enabled bool) {
+	c.enabled = enabled
+	c.onEnabledChanged.Fire()
+}
+
+type testFocusWindow struct {
+	Window
+	children Children
+	focus    Focusable
+	log      *[]string
+	handlers map[string]interface{}
+}
+
+func (w *testFocusWindow) Children() Children             { return w.children }
+func (w *testFocusWindow) Focus() Focusable               { return w.focus }
+func (w *testFocusWindow) Keymap() *Keymap                { return nil }
+func (w *testFocusWindow) HandleRoutedEvent(*RoutedEvent) {}
+func (w *testFocusWindow) KeyPress(KeyboardEvent)         { *w.log = append(*w.log, "window press") }
+func (w *testFocusWindow) KeyStroke(KeyStrokeEvent)       { *w.log = append(*w.log, "window stroke") }
+
+func (w *testFocusWindow) OnKeyDown(f func(KeyboardEvent)) EventSubscription {
+	w.handlers["down"] = f
+	return nil
+}
+
+func (w *testFocusWindow) OnKeyUp(f func(KeyboardEvent)) EventSubscription {
+	w.handlers["up"] = f
+	return nil
+}
+
+func (w *testFocusWindow) OnKeyRepeat(f func(KeyboardEvent)) EventSubscription {
+	w.handlers["repeat"] = f
+	return nil
+}
+
+func (w *testFocusWindow) OnKeyStroke(f func(KeyStrokeEvent)) EventSubscription {
+	w.handlers["stroke"] = f
+	return nil
+}
+
+func createTestFocusWindow() (*testFocusWindow, *[]string) {
+	log := []string{}
+	return &testFocusWindow{log: &log, handlers: map[string]interface{}{}}, &log
+}
+
+// add appends a new enabled control named name to the children of p.
+func (w *testFocusWindow) add(p Parent, name string, focusable bool) *testFocusControl {
+	c := &testFocusControl{
+		name:             name,
+		parent:           p,
+		enabled:          true,
+		focusable:        focusable,
+		log:              w.log,
+		onEnabledChanged: CreateEvent(func() {}),
+		onDetach:         CreateEvent(func() {}),
+	}
+	child := &Child{Control: c}
+	switch p := p.(type) {
+	case *testFocusWindow:
+		p.children = append(p.children, child)
+	case *testFocusControl:
+		p.children = append(p.children, child)
+	}
+	return c
+}
+
+func TestNextFocusableSkipsDisabled(t *testing.T) {
+	w, _ := createTestFocusWindow()
+	a := w.add(w, "a", true)
+	b := w.add(w, "b", false)
+	b1 := w.add(b, "b1", true)
+	b2 := w.add(b, "b2", true)
+	c := w.add(w, "c", true)
+	f := CreateFocusController(w)
+	next := func(after Control, forwards bool) Control {
+		if n := f.NextFocusable(after, forwards); n != nil {
+			return n
+		}
+		return nil
+	}
+	// Controls hold events, so they are compared by identity.
+	is := func(want, got Control) {
+		t.Helper()
+		test.AssertEquals(t, true, want == got)
+	}
+
+	is(b1, next(a, true))
+	b1.setEnabled(false)
+	is(b2, next(a, true))
+
+	// The children of disabled containers are skipped.
+	b.setEnabled(false)
+	is(c, next(a, true))
+	is(a, next(c, false))
+
+	c.setEnabled(false)
+	is(a, next(a, true))
+	a.setEnabled(false)
+	is(nil, next(a, true))
+	test.AssertEquals(t, true, f.Focusable(a) == nil)
+}
+
+func TestFocusDroppedWhenDisabled(t *testing.T) {
+	w, log := createTestFocusWindow()
+	a := w.add(w, "a", true)
+	b := w.add(w, "b", true)
+	f := CreateFocusController(w)
+
+	f.SetFocus(a)
+	a.setEnabled(false)
+	test.AssertEquals(t, true, f.Focus() == nil)
+	test.AssertEquals(t, []string{"a gained", "a lost"}, *log)
+
+	// The subscription to the old focus is removed.
+	*log = (*log)[:0]
+	f.SetFocus(b)
+	a.setEnabled(true)
+	a.setEnabled(false)
+	test.AssertEquals(t, true, f.Focus() == b)
+	test.AssertEquals(t, []string{"b gained"}, *log)
+
+	// Changes that leave the focus enabled keep it.
+	b.setEnabled(true)
+	test.AssertEquals(t, true, f.Focus() == b)
+}
@@ -0,0 +1,117 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"fmt"
+
+	"github.com/google/gxui/math"
+)
+
+// MaxGradientStops is the maximum number of color stops in a Gradient.
+const MaxGradientStops = 8
+
+// GradientKind is an enumerator of gradient shapes.
+type GradientKind int
+
+const (
+	// LinearGradient varies the color along the line from Start to End.
+	LinearGradient GradientKind = iota
+	// RadialGradient varies the color outwards from Center to Radius.
+	RadialGradient
+)
+
+// SpreadMode controls how a Gradient is drawn outside of the [0, 1] range of
+// its stops.
+type SpreadMode int
+
+const (
+	// SpreadPad extends the colors of the first and last stops.
+	SpreadPad SpreadMode = iota
+	// SpreadRepeat repeats the gradient.
+	SpreadRepeat
+	// SpreadReflect repeats the gradient, mirroring every other repetition.
+	SpreadReflect
+)
+
+// GradientStop is a color at an offset along a Gradient, where 0 is the start
+// and 1 is the end of the gradient.
+type GradientStop struct {
+	Offset float32
+	Color  Color
+}
+
+// Gradient describes a smooth transition between colors.
+// All positions are fractions of the bounds of the shape being filled, where
+// (0, 0) is the top-left and (1, 1) is the bottom-right of the bounds.
+type Gradient struct {
+	Kind   GradientKind
+	Spread SpreadMode
+	Stops  []GradientStop // Sorted by offset, at most MaxGradientStops.
+
+	Start, End math.Vec2 // Used by LinearGradient
+	Center     math.Vec2 // Used by RadialGradient
+	Radius     math.Vec2 // Used by RadialGradient
+}
+
+func validateGradientStops(stops []GradientStop) {
+	if len(stops) == 0 {
+		panic("A gradient requires at least one stop")
+	}
+	if len(stops) > MaxGradientStops {
+		panic(fmt.Errorf("A gradient can have at most %d stops. Got: %d", MaxGradientStops, len(stops)))
+	}
+	for i := 1; i < len(stops); i++ {
+		if stops[i].Offset < stops[i-1].Offset {
+			panic(fmt.Errorf("Gradient stops must be sorted by offset. Stops: %v", stops))
+		}
+	}
+}
+
+// CreateLinearGradientBrush returns a Brush that fills with a linear gradient
+// from start to end, using the spread mode.
+func CreateLinearGradientBrush(start, end math.Vec2, spread SpreadMode, stops ...GradientStop) Brush {
+	validateGradientStops(stops)
+	return Brush{Gradient: &Gradient{
+		Kind:   LinearGradient,
+		Spread: spread,
+		Stops:  append([]GradientStop{}, stops...),
+		Start:  start,
+		End:    end,
+	}}
+}
+
+// CreateRadialGradientBrush returns a Brush that fills with an elliptical
+// gradient from center out to radius, using the spread mode.
+func CreateRadialGradientBrush(center, radius math.Vec2, spread SpreadMode, stops ...GradientStop) Brush {
+	validateGradientStops(stops)
+	return Brush{Gradient: &Gradient{
+		Kind:   RadialGradient,
+		Spread: spread,
+		Stops:  append([]GradientStop{}, stops...),
+		Center: center,
+		Radius: radius,
+	}}
+}
+
+// CreateVerticalGradientBrush is a convenience function that returns a Brush
+// filling with a linear gradient from top to bottom.
+func CreateVerticalGradientBrush(top, bottom Color) Brush {
+	return CreateLinearGradientBrush(math.Vec2{X: 0, Y: 0}, math.Vec2{X: 0, Y: 1}, SpreadPad,
+		GradientStop{Offset: 0, Color: top},
+		GradientStop{Offset: 1, Color: bottom},
+	)
+}
+
+// Transparent returns true if every color of the gradient is fully
+// transparent.
+func (g *Gradient) Transparent() bool {
+	for _, s := range g.Stops {
+		if s.Color.A > 0 {
+			return false
+		}
+	}
+	return true
+}
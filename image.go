@@ -30,6 +30,8 @@ type Image interface {
 	SetTexture(Texture)
 	Canvas() Canvas
 	SetCanvas(Canvas)
+	SVG() *SVG
+	SetSVG(*SVG)
 	BorderPen() Pen
 	SetBorderPen(Pen)
 	BackgroundBrush() Brush
@@ -0,0 +1,250 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Accelerator is a single key press with modifiers, such as Ctrl+S.
+type Accelerator struct {
+	Key      KeyboardKey
+	Modifier KeyboardModifier
+}
+
+// KeyChord is a sequence of one or more accelerators pressed in turn, such as
+// Ctrl+K followed by Ctrl+C.
+type KeyChord []Accelerator
+
+var keyNames = map[KeyboardKey]string{
+	KeySpace:        "Space",
+	KeyApostrophe:   "Apostrophe",
+	KeyComma:        "Comma",
+	KeyMinus:        "Minus",
+	KeyPeriod:       "Period",
+	KeySlash:        "Slash",
+	Key0:            "0",
+	Key1:            "1",
+	Key2:            "2",
+	Key3:            "3",
+	Key4:            "4",
+	Key5:            "5",
+	Key6:            "6",
+	Key7:            "7",
+	Key8:            "8",
+	Key9:            "9",
+	KeySemicolon:    "Semicolon",
+	KeyEqual:        "Equal",
+	KeyA:            "A",
+	KeyB:            "B",
+	KeyC:            "C",
+	KeyD:            "D",
+	KeyE:            "E",
+	KeyF:            "F",
+	KeyG:            "G",
+	KeyH:            "H",
+	KeyI:            "I",
+	KeyJ:            "J",
+	KeyK:            "K",
+	KeyL:            "L",
+	KeyM:            "M",
+	KeyN:            "N",
+	KeyO:            "O",
+	KeyP:            "P",
+	KeyQ:            "Q",
+	KeyR:            "R",
+	KeyS:            "S",
+	KeyT:            "T",
+	KeyU:            "U",
+	KeyV:            "V",
+	KeyW:            "W",
+	KeyX:            "X",
+	KeyY:            "Y",
+	KeyZ:            "Z",
+	KeyLeftBracket:  "LeftBracket",
+	KeyBackslash:    "Backslash",
+	KeyRightBracket: "RightBracket",
+	KeyGraveAccent:  "GraveAccent",
+	KeyWorld1:       "World1",
+	KeyWorld2:       "World2",
+	KeyEscape:       "Escape",
+	KeyEnter:        "Enter",
+	KeyTab:          "Tab",
+	KeyBackspace:    "Backspace",
+	KeyInsert:       "Insert",
+	KeyDelete:       "Delete",
+	KeyRight:        "Right",
+	KeyLeft:         "Left",
+	KeyDown:         "Down",
+	KeyUp:           "Up",
+	KeyPageUp:       "PageUp",
+	KeyPageDown:     "PageDown",
+	KeyHome:         "Home",
+	KeyEnd:          "End",
+	KeyCapsLock:     "CapsLock",
+	KeyScrollLock:   "ScrollLock",
+	KeyNumLock:      "NumLock",
+	KeyPrintScreen:  "PrintScreen",
+	KeyPause:        "Pause",
+	KeyF1:           "F1",
+	KeyF2:           "F2",
+	KeyF3:           "F3",
+	KeyF4:           "F4",
+	KeyF5:           "F5",
+	KeyF6:           "F6",
+	KeyF7:           "F7",
+	KeyF8:           "F8",
+	KeyF9:           "F9",
+	KeyF10:          "F10",
+	KeyF11:          "F11",
+	KeyF12:          "F12",
+	KeyKp0:          "Kp0",
+	KeyKp1:          "Kp1",
+	KeyKp2:          "Kp2",
+	KeyKp3:          "Kp3",
+	KeyKp4:          "Kp4",
+	KeyKp5:          "Kp5",
+	KeyKp6:          "Kp6",
+	KeyKp7:          "Kp7",
+	KeyKp8:          "Kp8",
+	KeyKp9:          "Kp9",
+	KeyKpDecimal:    "KpDecimal",
+	KeyKpDivide:     "KpDivide",
+	KeyKpMultiply:   "KpMultiply",
+	KeyKpSubtract:   "KpSubtract",
+	KeyKpAdd:        "KpAdd",
+	KeyKpEnter:      "KpEnter",
+	KeyKpEqual:      "KpEqual",
+	KeyLeftShift:    "LeftShift",
+	KeyLeftControl:  "LeftControl",
+	KeyLeftAlt:      "LeftAlt",
+	KeyLeftSuper:    "LeftSuper",
+	KeyRightShift:   "RightShift",
+	KeyRightControl: "RightControl",
+	KeyRightAlt:     "RightAlt",
+	KeyRightSuper:   "RightSuper",
+	KeyMenu:         "Menu",
+}
+
+var modifierNames = []struct {
+	modifier KeyboardModifier
+	name     string
+}{
+	{ModControl, "Ctrl"},
+	{ModAlt, "Alt"},
+	{ModShift, "Shift"},
+	{ModSuper, "Super"},
+}
+
+// modifierAliases are the alternative names of modifiers accepted by
+// ParseAccelerator.
+var modifierAliases = map[string]KeyboardModifier{
+	"control": ModControl,
+	"cmd":     ModSuper,
+	"meta":    ModSuper,
+}
+
+// ParseKeyChord parses a key chord written as space-separated accelerators,
+// such as "Ctrl+K Ctrl+C". See ParseAccelerator.
+func ParseKeyChord(s string) (KeyChord, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("Empty key chord")
+	}
+	chord := make(KeyChord, len(fields))
+	for i, f := range fields {
+		a, err := ParseAccelerator(f)
+		if err != nil {
+			return nil, err
+		}
+		chord[i] = a
+	}
+	return chord, nil
+}
+
+// ParseAccelerator parses an accelerator written as a key name preceded by
+// any modifiers, joined with '+', such as "Ctrl+Shift+S" or "F5". The key
+// names are those of the KeyboardKey constants without the Key prefix, and the
+// modifiers are Ctrl, Alt, Shift and Super. Names are case-insensitive.
+func ParseAccelerator(s string) (Accelerator, error) {
+	parts := strings.Split(s, "+")
+	a := Accelerator{}
+	for _, p := range parts[:len(parts)-1] {
+		m, ok := parseModifier(p)
+		if !ok {
+			return Accelerator{}, fmt.Errorf("Unknown modifier %q in %q", p, s)
+		}
+		a.Modifier |= m
+	}
+	name := parts[len(parts)-1]
+	for k, n := range keyNames {
+		if strings.EqualFold(n, name) {
+			a.Key = k
+			return a, nil
+		}
+	}
+	return Accelerator{}, fmt.Errorf("Unknown key %q in %q", name, s)
+}
+
+func parseModifier(s string) (KeyboardModifier, bool) {
+	for _, m := range modifierNames {
+		if strings.EqualFold(m.name, s) {
+			return m.modifier, true
+		}
+	}
+	m, ok := modifierAliases[strings.ToLower(s)]
+	return m, ok
+}
+
+// IsModifier returns true if k is one of the shift, control, alt or super
+// keys.
+func (k KeyboardKey) IsModifier() bool {
+	switch k {
+	case KeyLeftShift, KeyRightShift, KeyLeftControl, KeyRightControl,
+		KeyLeftAlt, KeyRightAlt, KeyLeftSuper, KeyRightSuper:
+		return true
+	}
+	return false
+}
+
+func (a Accelerator) String() string {
+	s := ""
+	for _, m := range modifierNames {
+		if a.Modifier&m.modifier != 0 {
+			s += m.name + "+"
+		}
+	}
+	if n, ok := keyNames[a.Key]; ok {
+		return s + n
+	}
+	return s + fmt.Sprintf("Key(%d)", a.Key)
+}
+
+func (c KeyChord) String() string {
+	s := make([]string, len(c))
+	for i, a := range c {
+		s[i] = a.String()
+	}
+	return strings.Join(s, " ")
+}
+
+// Equals returns true if c and o are the same sequence of accelerators.
+func (c KeyChord) Equals(o KeyChord) bool {
+	return len(c) == len(o) && c.HasPrefix(o)
+}
+
+// HasPrefix returns true if c begins with the accelerators of p.
+func (c KeyChord) HasPrefix(p KeyChord) bool {
+	if len(p) > len(c) {
+		return false
+	}
+	for i := range p {
+		if c[i] != p[i] {
+			return false
+		}
+	}
+	return true
+}
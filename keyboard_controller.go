@@ -4,8 +4,18 @@
 
 package gxui
 
+// KeyboardController delivers the keyboard events of a window to the control
+// with focus and its ancestors, skipping any that are disabled. Each event is
+// first dispatched as a RoutedEvent from the window to the control with focus
+// and back, and is only delivered if its default action is not prevented.
+// Key-presses are then matched against the window's Keymap.
 type KeyboardController struct {
 	window Window
+
+	// strokeConsumed is true if the last key-press executed or began a key
+	// chord of the keymap, or had its default action prevented, so the
+	// key-stroke that follows is not delivered.
+	strokeConsumed bool
 }
 
 func CreateKeyboardController(w Window) *KeyboardController {
@@ -19,27 +29,64 @@ func CreateKeyboardController(w Window) *KeyboardController {
 	return c
 }
 
+// dispatch dispatches ev along the route from the window through the enabled
+// ancestors of the control with focus to the control itself, returning true if
+// the default action should be performed.
+func (c *KeyboardController) dispatch(ev *RoutedEvent) bool {
+	route := Route{}
+	for f := Control(c.window.Focus()); f != nil; f, _ = f.Parent().(Control) {
+		if f.IsEnabled() {
+			route = append(route, RouteStep{Target: f})
+		}
+	}
+	route = append(route, RouteStep{Target: c.window})
+	for i, j := 0, len(route)-1; i < j; i, j = i+1, j-1 {
+		route[i], route[j] = route[j], route[i]
+	}
+	return route.Dispatch(ev)
+}
+
 func (c *KeyboardController) keyDown(ev KeyboardEvent) {
+	c.strokeConsumed = false
+	if !c.dispatch(&RoutedEvent{Kind: RoutedKeyDown, Keyboard: ev}) {
+		c.strokeConsumed = true
+		return
+	}
 	f := Control(c.window.Focus())
 	for f != nil {
-		f.KeyDown(ev)
+		if f.IsEnabled() {
+			f.KeyDown(ev)
+		}
 		f, _ = f.Parent().(Control)
 	}
 	c.keyPress(ev)
 }
 
 func (c *KeyboardController) keyUp(ev KeyboardEvent) {
+	if !c.dispatch(&RoutedEvent{Kind: RoutedKeyUp, Keyboard: ev}) {
+		return
+	}
 	f := Control(c.window.Focus())
 	for f != nil {
-		f.KeyUp(ev)
+		if f.IsEnabled() {
+			f.KeyUp(ev)
+		}
 		f, _ = f.Parent().(Control)
 	}
 }
 
 func (c *KeyboardController) keyPress(ev KeyboardEvent) {
+	if !c.dispatch(&RoutedEvent{Kind: RoutedKeyPress, Keyboard: ev}) {
+		c.strokeConsumed = true
+		return
+	}
 	f := Control(c.window.Focus())
+	if keymap := c.window.Keymap(); keymap != nil && keymap.KeyPress(ev, f) {
+		c.strokeConsumed = true
+		return
+	}
 	for f != nil {
-		if f.KeyPress(ev) {
+		if f.IsEnabled() && f.KeyPress(ev) {
 			return
 		}
 		f, _ = f.Parent().(Control)
@@ -48,9 +95,15 @@ func (c *KeyboardController) keyPress(ev KeyboardEvent) {
 }
 
 func (c *KeyboardController) keyStroke(ev KeyStrokeEvent) {
+	if c.strokeConsumed {
+		return
+	}
+	if !c.dispatch(&RoutedEvent{Kind: RoutedKeyStroke, KeyStroke: ev}) {
+		return
+	}
 	f := Control(c.window.Focus())
 	for f != nil {
-		if f.KeyStroke(ev) {
+		if f.IsEnabled() && f.KeyStroke(ev) {
 			return
 		}
 		f, _ = f.Parent().(Control)
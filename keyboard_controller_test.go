@@ -0,0 +1,47 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"testing"
+
+	test "github.com/google/gxui/testing"
+)
+
+func TestKeyboardControllerSkipsDisabled(t *testing.T) {
+	w, log := createTestFocusWindow()
+	outer := w.add(w, "outer", false)
+	inner := w.add(outer, "inner", false)
+	focus := w.add(inner, "focus", true)
+	w.focus = focus
+	CreateKeyboardController(w)
+	down := w.handlers["down"].(func(KeyboardEvent))
+	up := w.handlers["up"].(func(KeyboardEvent))
+	stroke := w.handlers["stroke"].(func(KeyStrokeEvent))
+
+	inner.setEnabled(false)
+	down(KeyboardEvent{Key: KeyA})
+	stroke(KeyStrokeEvent{Character: 'a'})
+	up(KeyboardEvent{Key: KeyA})
+	test.AssertEquals(t, []string{
+		"focus down",
+		"outer down",
+		"focus press",
+		"outer press",
+		"window press",
+		"focus stroke",
+		"outer stroke",
+		"window stroke",
+		"focus up",
+		"outer up",
+	}, *log)
+
+	// A disabled focus only delivers the events to the window.
+	*log = (*log)[:0]
+	focus.setEnabled(false)
+	outer.setEnabled(false)
+	down(KeyboardEvent{Key: KeyA})
+	test.AssertEquals(t, []string{"window press"}, *log)
+}
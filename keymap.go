@@ -0,0 +1,262 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// KeyConflictError is returned when binding a key chord that conflicts with a
+// binding of another command in the same scope. Chords conflict if they are
+// equal, or if one is a prefix of the other, as the longer chord could never
+// be completed.
+type KeyConflictError struct {
+	Chord   KeyChord // The chord being bound.
+	Command string   // The command the chord was being bound to.
+	Other   KeyChord // The chord it conflicts with.
+	With    string   // The command Other is bound to.
+}
+
+func (e *KeyConflictError) Error() string {
+	return fmt.Sprintf("Key chord %v of command %q conflicts with %v of command %q",
+		e.Chord, e.Command, e.Other, e.With)
+}
+
+type keymapEntry struct {
+	command *Command
+	scope   Control
+	chords  []KeyChord
+}
+
+// Keymap binds key chords to commands, and executes the commands when their
+// chords are pressed in a window. Each command has a scope: either the whole
+// window, or a control, in which case the command's bindings are only active
+// while the control or one of its descendants has focus. When chords of
+// different scopes match, the innermost scope takes precedence.
+//
+// Keymaps are consulted by the KeyboardController before the control with
+// focus receives the key-press.
+type Keymap struct {
+	entries map[string]*keymapEntry
+	pending KeyChord
+}
+
+// CreateKeymap returns a new keymap with no commands.
+func CreateKeymap() *Keymap {
+	return &Keymap{
+		entries: make(map[string]*keymapEntry),
+	}
+}
+
+// AddCommand adds the command to the keymap, in the scope of the control, or
+// of the whole window if scope is nil. The command is initially unbound.
+func (k *Keymap) AddCommand(c *Command, scope Control) {
+	if _, found := k.entries[c.ID()]; found {
+		panic(fmt.Errorf("Keymap already has a command with the identifier %q", c.ID()))
+	}
+	k.entries[c.ID()] = &keymapEntry{command: c, scope: scope}
+}
+
+// RemoveCommand removes the command and its bindings from the keymap.
+func (k *Keymap) RemoveCommand(id string) {
+	delete(k.entries, id)
+}
+
+// Command returns the command with the identifier, or nil if the keymap has
+// no such command.
+func (k *Keymap) Command(id string) *Command {
+	if e, found := k.entries[id]; found {
+		return e.command
+	}
+	return nil
+}
+
+// Commands returns the commands of the keymap, sorted by identifier.
+func (k *Keymap) Commands() []*Command {
+	ids := make([]string, 0, len(k.entries))
+	for id := range k.entries {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	commands := make([]*Command, len(ids))
+	for i, id := range ids {
+		commands[i] = k.entries[id].command
+	}
+	return commands
+}
+
+func (k *Keymap) entry(id string) *keymapEntry {
+	e, found := k.entries[id]
+	if !found {
+		panic(fmt.Errorf("Keymap has no command with the identifier %q", id))
+	}
+	return e
+}
+
+// Bindings returns the key chords bound to the command, in the order they
+// were bound. Menus display the first binding as the command's shortcut.
+func (k *Keymap) Bindings(id string) []KeyChord {
+	return append([]KeyChord{}, k.entry(id).chords...)
+}
+
+// Bind binds the key chord to the command, returning a *KeyConflictError if
+// the chord conflicts with a chord of another command in the same scope.
+func (k *Keymap) Bind(id string, chord KeyChord) error {
+	e := k.entry(id)
+	if len(chord) == 0 {
+		panic("Cannot bind an empty key chord")
+	}
+	if err := k.conflict(e, chord, nil); err != nil {
+		return err
+	}
+	for _, c := range e.chords {
+		if c.Equals(chord) {
+			return nil
+		}
+	}
+	e.chords = append(e.chords, chord)
+	return nil
+}
+
+// Unbind removes the key chord from the bindings of the command.
+func (k *Keymap) Unbind(id string, chord KeyChord) {
+	e := k.entry(id)
+	for i, c := range e.chords {
+		if c.Equals(chord) {
+			e.chords = append(e.chords[:i], e.chords[i+1:]...)
+			return
+		}
+	}
+}
+
+// conflict returns a *KeyConflictError if chord conflicts with a chord bound
+// to a command other than e's in the same scope. Commands in replaced use the
+// chords of replaced in place of their bindings.
+func (k *Keymap) conflict(e *keymapEntry, chord KeyChord, replaced map[string][]KeyChord) error {
+	for id, o := range k.entries {
+		if o == e || o.scope != e.scope {
+			continue
+		}
+		chords, found := replaced[id]
+		if !found {
+			chords = o.chords
+		}
+		for _, c := range chords {
+			if c.HasPrefix(chord) || chord.HasPrefix(c) {
+				return &KeyConflictError{Chord: chord, Command: e.command.ID(), Other: c, With: id}
+			}
+		}
+	}
+	return nil
+}
+
+// LoadBindings replaces the bindings of commands with those read from r, so
+// that users can rebind commands. The data is a JSON object mapping command
+// identifiers to lists of key chords, in the form parsed by ParseKeyChord:
+//
+//	{
+//		"file.save":      ["Ctrl+S"],
+//		"editor.comment": ["Ctrl+K Ctrl+C", "Ctrl+Slash"],
+//		"editor.format":  []
+//	}
+//
+// Commands not listed keep their bindings. If the data names an unknown
+// command, has a malformed chord or a conflict, then an error is returned and
+// no bindings are changed.
+func (k *Keymap) LoadBindings(r io.Reader) error {
+	data := map[string][]string{}
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return err
+	}
+
+	ids := make([]string, 0, len(data))
+	for id := range data {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	replaced := make(map[string][]KeyChord)
+	for _, id := range ids {
+		if _, found := k.entries[id]; !found {
+			return fmt.Errorf("Unknown command %q", id)
+		}
+		chords := []KeyChord{}
+		for _, s := range data[id] {
+			chord, err := ParseKeyChord(s)
+			if err != nil {
+				return fmt.Errorf("Command %q: %v", id, err)
+			}
+			chords = append(chords, chord)
+		}
+		replaced[id] = chords
+	}
+
+	for _, id := range ids {
+		for _, chord := range replaced[id] {
+			if err := k.conflict(k.entries[id], chord, replaced); err != nil {
+				return err
+			}
+		}
+	}
+
+	for id, chords := range replaced {
+		k.entries[id].chords = chords
+	}
+	return nil
+}
+
+// Pending returns the accelerators pressed so far of a key chord that has not
+// yet been completed.
+func (k *Keymap) Pending() KeyChord {
+	return append(KeyChord{}, k.pending...)
+}
+
+// KeyPress matches the key-press, following any pending accelerators, against
+// the bindings active while focus has focus, returning true if the key-press
+// was consumed. A key-press that completes a chord executes its command, and
+// one that begins or continues a longer chord is held as pending. A key-press
+// that cannot complete a pending chord is consumed, and the chord abandoned.
+// KeyPress is called by the KeyboardController.
+func (k *Keymap) KeyPress(ev KeyboardEvent, focus Control) (consume bool) {
+	if ev.Key.IsModifier() {
+		return false
+	}
+	chord := append(k.Pending(), Accelerator{Key: ev.Key, Modifier: ev.Modifier})
+	hadPending := len(k.pending) > 0
+	k.pending = nil
+
+	scopes := []Control{}
+	for c := focus; c != nil; c, _ = c.Parent().(Control) {
+		scopes = append(scopes, c)
+	}
+	scopes = append(scopes, nil)
+
+	for _, scope := range scopes {
+		prefix := false
+		for _, e := range k.entries {
+			if e.scope != scope {
+				continue
+			}
+			for _, c := range e.chords {
+				switch {
+				case c.Equals(chord):
+					if e.command.Execute() {
+						return true
+					}
+				case c.HasPrefix(chord):
+					prefix = e.command.IsEnabled() || prefix
+				}
+			}
+		}
+		if prefix {
+			k.pending = chord
+			return true
+		}
+	}
+	return hadPending
+}
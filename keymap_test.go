@@ -0,0 +1,124 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"strings"
+	"testing"
+
+	test "github.com/google/gxui/testing"
+)
+
+type testKeymapControl struct {
+	Control
+	parent Parent
+}
+
+func (c *testKeymapControl) Parent() Parent     { return c.parent }
+func (c *testKeymapControl) Children() Children { return nil }
+func (c *testKeymapControl) Relayout()          {}
+func (c *testKeymapControl) Redraw()            {}
+
+func chord(s string) KeyChord {
+	c, err := ParseKeyChord(s)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func press(k *Keymap, focus Control, s string) bool {
+	consumed := false
+	for _, a := range chord(s) {
+		consumed = k.KeyPress(KeyboardEvent{Key: a.Key, Modifier: a.Modifier}, focus)
+	}
+	return consumed
+}
+
+func TestParseKeyChord(t *testing.T) {
+	c, err := ParseKeyChord("ctrl+k Control+Shift+C")
+	test.AssertEquals(t, nil, err)
+	test.AssertEquals(t, KeyChord{{KeyK, ModControl}, {KeyC, ModControl | ModShift}}, c)
+	test.AssertEquals(t, "Ctrl+K Ctrl+Shift+C", c.String())
+
+	_, err = ParseKeyChord("Hyper+K")
+	test.AssertEquals(t, `Unknown modifier "Hyper" in "Hyper+K"`, err.Error())
+	_, err = ParseKeyChord("Ctrl+Banana")
+	test.AssertEquals(t, `Unknown key "Banana" in "Ctrl+Banana"`, err.Error())
+}
+
+func TestKeymapChords(t *testing.T) {
+	executed := []string{}
+	command := func(id string) *Command {
+		return CreateCommand(id, id, func() { executed = append(executed, id) })
+	}
+	k := CreateKeymap()
+	k.AddCommand(command("comment"), nil)
+	k.AddCommand(command("copy"), nil)
+	test.AssertEquals(t, nil, k.Bind("comment", chord("Ctrl+K Ctrl+C")))
+	test.AssertEquals(t, nil, k.Bind("copy", chord("Ctrl+C")))
+
+	test.AssertEquals(t, true, press(k, nil, "Ctrl+K"))
+	test.AssertEquals(t, KeyChord{{KeyK, ModControl}}, k.Pending())
+	test.AssertEquals(t, true, press(k, nil, "Ctrl+C"))
+	test.AssertEquals(t, true, press(k, nil, "Ctrl+C"))
+	test.AssertEquals(t, []string{"comment", "copy"}, executed)
+
+	// An unbound key abandons the pending chord.
+	test.AssertEquals(t, true, press(k, nil, "Ctrl+K A"))
+	test.AssertEquals(t, KeyChord{}, k.Pending())
+	test.AssertEquals(t, false, press(k, nil, "A"))
+
+	// Disabled commands are not executed.
+	k.Command("copy").SetEnabled(false)
+	test.AssertEquals(t, false, press(k, nil, "Ctrl+C"))
+	test.AssertEquals(t, []string{"comment", "copy"}, executed)
+}
+
+func TestKeymapScopes(t *testing.T) {
+	executed := ""
+	window := &testKeymapControl{}
+	editor := &testKeymapControl{parent: window}
+	other := &testKeymapControl{}
+
+	k := CreateKeymap()
+	k.AddCommand(CreateCommand("save", "Save", func() { executed = "save" }), nil)
+	k.AddCommand(CreateCommand("format", "Format", func() { executed = "format" }), editor)
+	k.Bind("save", chord("Ctrl+S"))
+	test.AssertEquals(t, nil, k.Bind("format", chord("Ctrl+S")))
+
+	press(k, editor, "Ctrl+S")
+	test.AssertEquals(t, "format", executed)
+	press(k, other, "Ctrl+S")
+	test.AssertEquals(t, "save", executed)
+}
+
+func TestKeymapConflicts(t *testing.T) {
+	k := CreateKeymap()
+	k.AddCommand(CreateCommand("comment", "Comment", func() {}), nil)
+	k.AddCommand(CreateCommand("kill", "Kill", func() {}), nil)
+	k.Bind("comment", chord("Ctrl+K Ctrl+C"))
+
+	err := k.Bind("kill", chord("Ctrl+K"))
+	test.AssertEquals(t, &KeyConflictError{
+		Chord:   chord("Ctrl+K"),
+		Command: "kill",
+		Other:   chord("Ctrl+K Ctrl+C"),
+		With:    "comment",
+	}, err)
+	test.AssertEquals(t, []KeyChord{}, k.Bindings("kill"))
+
+	// Rebinding both commands at once resolves the conflict.
+	err = k.LoadBindings(strings.NewReader(`{"comment": ["Ctrl+Slash"], "kill": ["Ctrl+K"]}`))
+	test.AssertEquals(t, nil, err)
+	test.AssertEquals(t, []KeyChord{chord("Ctrl+Slash")}, k.Bindings("comment"))
+	test.AssertEquals(t, []KeyChord{chord("Ctrl+K")}, k.Bindings("kill"))
+
+	err = k.LoadBindings(strings.NewReader(`{"comment": ["Ctrl+K Ctrl+C"]}`))
+	test.AssertEquals(t, `Key chord Ctrl+K Ctrl+C of command "comment" conflicts with Ctrl+K of command "kill"`, err.Error())
+	err = k.LoadBindings(strings.NewReader(`{"paste": ["Ctrl+V"]}`))
+	test.AssertEquals(t, `Unknown command "paste"`, err.Error())
+	test.AssertEquals(t, []KeyChord{chord("Ctrl+Slash")}, k.Bindings("comment"))
+}
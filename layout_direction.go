@@ -0,0 +1,56 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+// LayoutDirection is the horizontal direction in which a control and its
+// descendants lay out their content. A right-to-left layout is a mirror image
+// of the left-to-right layout.
+type LayoutDirection int
+
+const (
+	// InheritLayoutDirection uses the layout direction of the parent. Windows
+	// that inherit their layout direction are left-to-right.
+	InheritLayoutDirection LayoutDirection = iota
+	LayoutLeftToRight
+	LayoutRightToLeft
+)
+
+func (d LayoutDirection) Inherit() bool     { return d == InheritLayoutDirection }
+func (d LayoutDirection) LeftToRight() bool { return d == LayoutLeftToRight }
+func (d LayoutDirection) RightToLeft() bool { return d == LayoutRightToLeft }
+
+// LayoutDirectioner is the interface implemented by Controls and Windows that
+// have a layout direction.
+type LayoutDirectioner interface {
+	LayoutDirection() LayoutDirection
+}
+
+// ResolveLayoutDirection returns the layout direction of d, walking up the
+// parent hierarchy while the direction is inherited. ResolveLayoutDirection
+// never returns InheritLayoutDirection.
+func ResolveLayoutDirection(d LayoutDirectioner) LayoutDirection {
+	for {
+		if dir := d.LayoutDirection(); !dir.Inherit() {
+			return dir
+		}
+		c, ok := d.(Control)
+		if !ok {
+			return LayoutLeftToRight
+		}
+		if d, ok = c.Parent().(LayoutDirectioner); !ok {
+			return LayoutLeftToRight
+		}
+	}
+}
+
+// MirrorChildren horizontally mirrors the offsets of the children within a
+// parent of the specified width. Layouts call MirrorChildren after laying out
+// their children left-to-right when their resolved layout direction is
+// right-to-left.
+func MirrorChildren(children Children, width int) {
+	for _, c := range children {
+		c.Offset.X = width - c.Offset.X - c.Control.Size().W
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"testing"
+
+	"github.com/google/gxui/math"
+	test "github.com/google/gxui/testing"
+)
+
+type testDirectionParent struct {
+	Parent
+	direction LayoutDirection
+}
+
+func (p *testDirectionParent) LayoutDirection() LayoutDirection { return p.direction }
+
+type testDirectionControl struct {
+	Control
+	parent    Parent
+	direction LayoutDirection
+	size      math.Size
+}
+
+func (c *testDirectionControl) Parent() Parent                   { return c.parent }
+func (c *testDirectionControl) LayoutDirection() LayoutDirection { return c.direction }
+func (c *testDirectionControl) Size() math.Size                  { return c.size }
+
+type testDirectionContainer struct {
+	testDirectionControl
+}
+
+func (c *testDirectionContainer) Children() Children { return nil }
+func (c *testDirectionContainer) Relayout()          {}
+func (c *testDirectionContainer) Redraw()            {}
+
+func TestResolveLayoutDirection(t *testing.T) {
+	root := &testDirectionParent{}
+	mid := &testDirectionContainer{testDirectionControl{parent: root}}
+	leaf := &testDirectionControl{parent: mid}
+
+	// Everything inherits, so the default is left-to-right.
+	test.AssertEquals(t, LayoutLeftToRight, ResolveLayoutDirection(leaf))
+
+	root.direction = LayoutRightToLeft
+	test.AssertEquals(t, LayoutRightToLeft, ResolveLayoutDirection(leaf))
+	test.AssertEquals(t, LayoutRightToLeft, ResolveLayoutDirection(root))
+
+	mid.direction = LayoutLeftToRight
+	test.AssertEquals(t, LayoutLeftToRight, ResolveLayoutDirection(leaf))
+
+	leaf.direction = LayoutRightToLeft
+	test.AssertEquals(t, LayoutRightToLeft, ResolveLayoutDirection(leaf))
+}
+
+func TestResolveLayoutDirectionWithoutParent(t *testing.T) {
+	c := &testDirectionControl{}
+	test.AssertEquals(t, LayoutLeftToRight, ResolveLayoutDirection(c))
+
+	// Parents that have no layout direction end the walk.
+	c.parent = struct{ Parent }{}
+	test.AssertEquals(t, LayoutLeftToRight, ResolveLayoutDirection(c))
+}
+
+func TestMirrorChildren(t *testing.T) {
+	a := &testDirectionControl{size: math.Size{W: 20, H: 10}}
+	b := &testDirectionControl{size: math.Size{W: 30, H: 10}}
+	children := Children{
+		{Control: a, Offset: math.Point{X: 0, Y: 5}},
+		{Control: b, Offset: math.Point{X: 25, Y: 0}},
+	}
+
+	MirrorChildren(children, 100)
+	test.AssertEquals(t, math.CreateRect(80, 5, 100, 15), children[0].Bounds())
+	test.AssertEquals(t, math.CreateRect(45, 0, 75, 10), children[1].Bounds())
+
+	// Mirroring twice restores the original layout.
+	MirrorChildren(children, 100)
+	test.AssertEquals(t, math.CreateRect(0, 5, 20, 15), children[0].Bounds())
+	test.AssertEquals(t, math.CreateRect(25, 0, 55, 10), children[1].Bounds())
+}
@@ -4,7 +4,11 @@
 
 package gxui
 
-import "github.com/google/gxui/math"
+import (
+	"time"
+
+	"github.com/google/gxui/math"
+)
 
 type List interface {
 	Focusable
@@ -24,6 +28,29 @@ type List interface {
 	Select(AdapterItem) bool
 	OnSelectionChanged(func(AdapterItem)) EventSubscription
 	OnItemClicked(func(MouseEvent, AdapterItem)) EventSubscription
+
+	// SmoothScrolling returns true if mouse-wheel and trackpad scrolling is
+	// kinetic, and ScrollTo is animated.
+	SmoothScrolling() bool
+
+	// SetSmoothScrolling enables or disables kinetic scrolling and animated
+	// ScrollTo calls.
+	SetSmoothScrolling(bool)
+
+	// OverscrollBounce returns true if kinetic scrolling is permitted to travel
+	// past the scroll limits before springing back.
+	OverscrollBounce() bool
+
+	// SetOverscrollBounce enables or disables bouncing at the scroll limits.
+	SetOverscrollBounce(bool)
+
+	// ScrollBarAutoHideDelay returns the duration of inactivity after which the
+	// scroll bar is hidden. A duration of 0 means the scroll bar does not hide.
+	ScrollBarAutoHideDelay() time.Duration
+
+	// SetScrollBarAutoHideDelay sets the duration of inactivity after which the
+	// scroll bar is hidden. A duration of 0 means the scroll bar does not hide.
+	SetScrollBarAutoHideDelay(time.Duration)
 }
 
 // ListAdapter is an interface used to visualize a flat set of items.
@@ -131,3 +131,62 @@ func (m Mat3) DivS(s float32) Mat3 {
 		m.Row(2).DivS(s),
 	)
 }
+
+// Mul returns the matrix product M • O. Transforming a row vector by the
+// result is equivalent to transforming it by M and then by O.
+func (m Mat3) Mul(o Mat3) Mat3 {
+	c0, c1, c2 := o.Transpose().Rows()
+	r0, r1, r2 := m.Rows()
+	return CreateMat3(
+		r0.Dot(c0), r0.Dot(c1), r0.Dot(c2),
+		r1.Dot(c0), r1.Dot(c1), r1.Dot(c2),
+		r2.Dot(c0), r2.Dot(c1), r2.Dot(c2),
+	)
+}
+
+// IsTranslation returns true if the matrix is an affine transform that only
+// translates.
+func (m Mat3) IsTranslation() bool {
+	return m[0] == 1 && m[1] == 0 && m[2] == 0 &&
+		m[3] == 0 && m[4] == 1 && m[5] == 0 &&
+		m[8] == 1
+}
+
+// CreateMat3Translate returns an affine transform that translates by (x, y).
+func CreateMat3Translate(x, y float32) Mat3 {
+	return CreateMat3(
+		1, 0, 0,
+		0, 1, 0,
+		x, y, 1,
+	)
+}
+
+// CreateMat3Scale returns an affine transform that scales by (x, y).
+func CreateMat3Scale(x, y float32) Mat3 {
+	return CreateMat3(
+		x, 0, 0,
+		0, y, 0,
+		0, 0, 1,
+	)
+}
+
+// CreateMat3Rotate returns an affine transform that rotates by the angle in
+// radians. With the Y axis pointing down, positive angles rotate clockwise.
+func CreateMat3Rotate(radians float32) Mat3 {
+	s, c := Sinf(radians), Cosf(radians)
+	return CreateMat3(
+		+c, s, 0,
+		-s, c, 0,
+		0, 0, 1,
+	)
+}
+
+// CreateMat3Skew returns an affine transform that shears X by x for each unit
+// of Y, and Y by y for each unit of X.
+func CreateMat3Skew(x, y float32) Mat3 {
+	return CreateMat3(
+		1, y, 0,
+		x, 1, 0,
+		0, 0, 1,
+	)
+}
@@ -21,3 +21,14 @@ func TestCreateMat3PositionToBarycentric(t *testing.T) {
 	test.AssertEquals(t, Vec3{0.0, 1.0, 1.0}, b.Vec3(1).MulM(m))
 	test.AssertEquals(t, Vec3{0.0, 0.0, 1.0}, c.Vec3(1).MulM(m))
 }
+
+func TestMat3MulOrder(t *testing.T) {
+	m := CreateMat3Scale(2, 3).Mul(CreateMat3Translate(10, 20))
+	test.AssertEquals(t, Point{12, 23}, Point{1, 1}.Transform(m))
+}
+
+func TestMat3Rotate(t *testing.T) {
+	m := CreateMat3Rotate(Pi / 2)
+	test.AssertEquals(t, Point{0, 10}, Point{10, 0}.Transform(m))
+	test.AssertEquals(t, Point{10, 0}, Point{0, 10}.Transform(m.Invert()))
+}
@@ -32,6 +32,14 @@ func Round(v float32) int {
 	}
 }
 
+func Floorf(v float32) float32 {
+	return float32(math.Floor(float64(v)))
+}
+
+func Ceilf(v float32) float32 {
+	return float32(math.Ceil(float64(v)))
+}
+
 func Sinf(v float32) float32 {
 	return float32(math.Sin(float64(v)))
 }
@@ -56,6 +64,10 @@ func Atanf(v float32) float32 {
 	return float32(math.Atan(float64(v)))
 }
 
+func Atan2f(y, x float32) float32 {
+	return float32(math.Atan2(float64(y), float64(x)))
+}
+
 func Sqrtf(v float32) float32 {
 	return float32(math.Sqrt(float64(v)))
 }
@@ -161,3 +173,12 @@ func Mod(a, b int) int {
 		return x
 	}
 }
+
+func Modf(a, b float32) float32 {
+	x := float32(math.Mod(float64(a), float64(b)))
+	if x < 0 {
+		return x + b
+	} else {
+		return x
+	}
+}
@@ -56,6 +56,13 @@ func (p Point) Vec3(z float32) Vec3 {
 	return Vec3{float32(p.X), float32(p.Y), z}
 }
 
+// Transform returns the point transformed by the affine matrix m, rounded to
+// the nearest integer coordinates.
+func (p Point) Transform(m Mat3) Point {
+	v := p.Vec3(1).MulM(m)
+	return Point{Round(v.X), Round(v.Y)}
+}
+
 func (p Point) Scale(s Vec2) Point {
 	return Point{int(float32(p.X) * s.X), int(float32(p.Y) * s.Y)}
 }
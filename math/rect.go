@@ -150,6 +150,27 @@ func (r Rect) Constrain(o Rect) Rect {
 	}
 }
 
+// Transform returns the smallest rectangle that contains all four corners of r
+// transformed by the affine matrix m.
+func (r Rect) Transform(m Mat3) Rect {
+	if m.IsTranslation() {
+		return r.Offset(Point{Round(m[6]), Round(m[7])})
+	}
+	min := Vec2{X: float32(MaxInt), Y: float32(MaxInt)}
+	max := min.Neg()
+	for _, p := range []Point{r.TL(), r.TR(), r.BL(), r.BR()} {
+		v := p.Vec3(1).MulM(m)
+		min = Vec2{Minf(min.X, v.X), Minf(min.Y, v.Y)}
+		max = Vec2{Maxf(max.X, v.X), Maxf(max.Y, v.Y)}
+	}
+	// Allow for a small amount of floating-point error before rounding out.
+	const e = 0.001
+	return Rect{
+		Min: Point{int(Floorf(min.X + e)), int(Floorf(min.Y + e))},
+		Max: Point{int(Ceilf(max.X - e)), int(Ceilf(max.Y - e))},
+	}
+}
+
 func (r Rect) Canon() Rect {
 	return Rect{
 		r.Min.Min(r.Max),
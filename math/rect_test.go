@@ -24,3 +24,8 @@ func TestRectConstrainOutMax(t *testing.T) {
 	r2 := CreateRect(80, 80, 120, 120)
 	test.AssertEquals(t, CreateRect(60, 60, 100, 100), r2.Constrain(r1))
 }
+
+func TestRectTransformRotate(t *testing.T) {
+	r := CreateRect(0, 0, 20, 10)
+	test.AssertEquals(t, CreateRect(-10, 0, 0, 20), r.Transform(CreateMat3Rotate(Pi/2)))
+}
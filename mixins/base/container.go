@@ -25,26 +25,45 @@ type ContainerOuter interface {
 type Container struct {
 	parts.Attachable
 	parts.Container
+	parts.Cursorable
 	parts.DrawPaint
+	parts.Enableable
 	parts.InputEventHandler
 	parts.Layoutable
 	parts.Paddable
 	parts.PaintChildren
 	parts.Parentable
+	parts.RoutedEventHandler
+	parts.Styleable
+	parts.Themeable
+	parts.Transformable
 	parts.Visible
 }
 
 func (c *Container) Init(outer ContainerOuter, theme gxui.Theme) {
 	c.Attachable.Init(outer)
 	c.Container.Init(outer)
+	c.Cursorable.Init(outer)
 	c.DrawPaint.Init(outer, theme)
+	c.Enableable.Init(outer)
 	c.InputEventHandler.Init(outer)
 	c.Layoutable.Init(outer, theme)
 	c.Paddable.Init(outer)
 	c.PaintChildren.Init(outer)
 	c.Parentable.Init(outer)
+	c.Styleable.Init(outer)
+	c.Themeable.Init(outer, theme)
+	c.Transformable.Init(outer)
 	c.Visible.Init(outer)
 
 	// Interface compliance test
 	_ = gxui.Container(c)
 }
+
+// SetTheme sets the theme of the container and of its children.
+func (c *Container) SetTheme(theme gxui.Theme) {
+	c.Themeable.SetTheme(theme)
+	for _, child := range c.Children() {
+		child.Control.SetTheme(theme)
+	}
+}
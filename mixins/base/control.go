@@ -20,19 +20,30 @@ type ControlOuter interface {
 
 type Control struct {
 	parts.Attachable
+	parts.Cursorable
 	parts.DrawPaint
+	parts.Enableable
 	parts.InputEventHandler
 	parts.Layoutable
 	parts.Parentable
+	parts.RoutedEventHandler
+	parts.Styleable
+	parts.Themeable
+	parts.Transformable
 	parts.Visible
 }
 
 func (c *Control) Init(outer ControlOuter, theme gxui.Theme) {
 	c.Attachable.Init(outer)
+	c.Cursorable.Init(outer)
 	c.DrawPaint.Init(outer, theme)
+	c.Enableable.Init(outer)
 	c.Layoutable.Init(outer, theme)
 	c.InputEventHandler.Init(outer)
 	c.Parentable.Init(outer)
+	c.Styleable.Init(outer)
+	c.Themeable.Init(outer, theme)
+	c.Transformable.Init(outer)
 	c.Visible.Init(outer)
 
 	// Interface compliance test
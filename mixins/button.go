@@ -21,7 +21,6 @@ type Button struct {
 	parts.Focusable
 
 	outer      ButtonOuter
-	theme      gxui.Theme
 	label      gxui.Label
 	buttonType gxui.ButtonType
 	checked    bool
@@ -32,7 +31,6 @@ func (b *Button) Init(outer ButtonOuter, theme gxui.Theme) {
 	b.Focusable.Init(outer)
 
 	b.buttonType = gxui.PushButton
-	b.theme = theme
 	b.outer = outer
 
 	// Interface compliance test
@@ -62,7 +60,7 @@ func (b *Button) SetText(text string) {
 		}
 	} else {
 		if b.label == nil {
-			b.label = b.theme.CreateLabel()
+			b.label = b.Theme().CreateLabel()
 			b.label.SetMargin(math.ZeroSpacing)
 			b.AddChild(b.label)
 		}
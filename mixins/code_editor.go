@@ -24,7 +24,6 @@ type CodeEditor struct {
 	suggestionList     gxui.List
 	suggestionProvider gxui.CodeSuggestionProvider
 	tabWidth           int
-	theme              gxui.Theme
 }
 
 func (t *CodeEditor) updateSpans(edits []gxui.TextBoxEdit) {
@@ -37,25 +36,36 @@ func (t *CodeEditor) updateSpans(edits []gxui.TextBoxEdit) {
 func (t *CodeEditor) Init(outer CodeEditorOuter, driver gxui.Driver, theme gxui.Theme, font gxui.Font) {
 	t.outer = outer
 	t.tabWidth = 2
-	t.theme = theme
-
-	t.suggestionAdapter = &SuggestionAdapter{}
-	t.suggestionList = t.outer.CreateSuggestionList()
-	t.suggestionList.SetAdapter(t.suggestionAdapter)
 
 	t.TextBox.Init(outer, driver, theme, font)
 	t.controller.OnTextChanged(t.updateSpans)
 
+	t.suggestionAdapter = &SuggestionAdapter{}
+	t.createSuggestionList()
+	t.OnThemeChanged(func() {
+		showing := t.IsSuggestionListShowing()
+		t.HideSuggestionList()
+		t.createSuggestionList()
+		if showing {
+			t.ShowSuggestionList()
+		}
+	})
+
 	// Interface compliance test
 	_ = gxui.CodeEditor(t)
 }
 
+func (t *CodeEditor) createSuggestionList() {
+	t.suggestionList = t.outer.CreateSuggestionList()
+	t.suggestionList.SetAdapter(t.suggestionAdapter)
+}
+
 func (t *CodeEditor) ItemSize(theme gxui.Theme) math.Size {
 	return math.Size{W: math.MaxSize.W, H: t.font.GlyphMaxSize().H}
 }
 
 func (t *CodeEditor) CreateSuggestionList() gxui.List {
-	l := t.theme.CreateList()
+	l := t.Theme().CreateList()
 	l.SetBackgroundBrush(gxui.DefaultBrush)
 	l.SetBorderPen(gxui.DefaultPen)
 	return l
@@ -67,6 +67,17 @@ func (t *DefaultTextBoxLine) Paint(c gxui.Canvas) {
 	}
 }
 
+// textOffset returns the horizontal offset of the start of the line's text.
+// The text is right-aligned if the layout direction is right-to-left.
+func (t *DefaultTextBoxLine) textOffset() int {
+	if gxui.ResolveLayoutDirection(t.outer).RightToLeft() {
+		controller := t.textbox.controller
+		w := t.outer.MeasureRunes(controller.LineStart(t.lineIndex), controller.LineEnd(t.lineIndex)).W
+		return t.Size().W - t.caretWidth - w
+	}
+	return t.caretWidth
+}
+
 func (t *DefaultTextBoxLine) MeasureRunes(s, e int) math.Size {
 	controller := t.textbox.controller
 	return t.textbox.font.Measure(&gxui.TextBlock{
@@ -79,7 +90,7 @@ func (t *DefaultTextBoxLine) PaintText(c gxui.Canvas) {
 	f := t.textbox.font
 	offsets := f.Layout(&gxui.TextBlock{
 		Runes:     runes,
-		AlignRect: t.Size().Rect().OffsetX(t.caretWidth),
+		AlignRect: t.Size().Rect().OffsetX(t.textOffset()),
 		H:         gxui.AlignLeft,
 		V:         gxui.AlignBottom,
 	})
@@ -88,13 +99,14 @@ func (t *DefaultTextBoxLine) PaintText(c gxui.Canvas) {
 
 func (t *DefaultTextBoxLine) PaintCarets(c gxui.Canvas) {
 	controller := t.textbox.controller
+	offset := t.textOffset()
 	for i, cnt := 0, controller.SelectionCount(); i < cnt; i++ {
 		e := controller.Caret(i)
 		l := controller.LineIndex(e)
 		if l == t.lineIndex {
 			s := controller.LineStart(l)
 			m := t.outer.MeasureRunes(s, e)
-			top := math.Point{X: t.caretWidth + m.W, Y: 0}
+			top := math.Point{X: offset + m.W, Y: 0}
 			bottom := top.Add(math.Point{X: 0, Y: t.Size().H})
 			t.outer.PaintCaret(c, top, bottom)
 		}
@@ -105,6 +117,7 @@ func (t *DefaultTextBoxLine) PaintSelections(c gxui.Canvas) {
 	controller := t.textbox.controller
 
 	ls, le := controller.LineStart(t.lineIndex), controller.LineEnd(t.lineIndex)
+	offset := t.textOffset()
 
 	selections := controller.Selections()
 	if t.textbox.selectionDragging {
@@ -114,7 +127,7 @@ func (t *DefaultTextBoxLine) PaintSelections(c gxui.Canvas) {
 		if s < e {
 			x := t.outer.MeasureRunes(ls, int(s)).W
 			m := t.outer.MeasureRunes(int(s), int(e))
-			top := math.Point{X: t.caretWidth + x, Y: 0}
+			top := math.Point{X: offset + x, Y: 0}
 			bottom := top.Add(m.Point())
 			t.outer.PaintSelection(c, top, bottom)
 		}
@@ -136,7 +149,7 @@ func (t *DefaultTextBoxLine) RuneIndexAt(p math.Point) int {
 	font := t.textbox.font
 	controller := t.textbox.controller
 
-	x := p.X
+	x := p.X - (t.textOffset() - t.caretWidth)
 	line := controller.Line(t.lineIndex)
 	i := 0
 	for ; i < len(line) && x > font.Measure(&gxui.TextBlock{Runes: []rune(line[:i+1])}).W; i++ {
@@ -151,5 +164,5 @@ func (t *DefaultTextBoxLine) PositionAt(runeIndex int) math.Point {
 
 	x := runeIndex - controller.LineStart(t.lineIndex)
 	line := controller.Line(t.lineIndex)
-	return font.Measure(&gxui.TextBlock{Runes: []rune(line[:x])}).Point()
+	return font.Measure(&gxui.TextBlock{Runes: []rune(line[:x])}).Point().AddX(t.textOffset() - t.caretWidth)
 }
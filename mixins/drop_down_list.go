@@ -22,7 +22,6 @@ type DropDownList struct {
 
 	outer DropDownListOuter
 
-	theme       gxui.Theme
 	list        gxui.List
 	listShowing bool
 	itemSize    math.Size
@@ -38,13 +37,16 @@ func (l *DropDownList) Init(outer DropDownListOuter, theme gxui.Theme) {
 	l.BackgroundBorderPainter.Init(outer)
 	l.Focusable.Init(outer)
 
-	l.theme = theme
 	l.list = theme.CreateList()
+	l.OnThemeChanged(func() {
+		// The list is only a child while it is showing.
+		l.list.SetTheme(l.Theme())
+	})
 	l.list.OnSelectionChanged(func(item gxui.AdapterItem) {
 		l.outer.RemoveAll()
 		adapter := l.list.Adapter()
 		if item != nil && adapter != nil {
-			l.selected = l.AddChild(adapter.Create(l.theme, adapter.ItemIndex(item)))
+			l.selected = l.AddChild(adapter.Create(l.Theme(), adapter.ItemIndex(item)))
 		} else {
 			l.selected = nil
 		}
@@ -91,7 +93,7 @@ func (l *DropDownList) DesiredSize(min, max math.Size) math.Size {
 
 func (l *DropDownList) DataReplaced() {
 	adapter := l.list.Adapter()
-	itemSize := adapter.Size(l.theme)
+	itemSize := adapter.Size(l.Theme())
 	l.itemSize = itemSize
 	l.outer.Relayout()
 }
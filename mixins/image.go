@@ -22,14 +22,18 @@ type Image struct {
 	outer        ImageOuter
 	texture      gxui.Texture
 	canvas       gxui.Canvas
+	svg          *gxui.SVG
 	scalingMode  gxui.ScalingMode
 	aspectMode   gxui.AspectMode
 	explicitSize math.Size
 }
 
-func (i *Image) calculateDrawRect() math.Rect {
+func (i *Image) calculateDrawRect(size math.Size) math.Rect {
 	r := i.outer.Size().Rect()
-	texW, texH := i.texture.Size().WH()
+	texW, texH := size.WH()
+	if texW == 0 || texH == 0 {
+		return r
+	}
 	aspectSrc := float32(texH) / float32(texW)
 	aspectDst := float32(r.H()) / float32(r.W())
 	switch i.aspectMode {
@@ -64,6 +68,7 @@ func (i *Image) SetTexture(tex gxui.Texture) {
 	if i.texture != tex {
 		i.texture = tex
 		i.canvas = nil
+		i.svg = nil
 		i.outer.Relayout()
 	}
 }
@@ -80,6 +85,20 @@ func (i *Image) SetCanvas(canvas gxui.Canvas) {
 	if i.canvas != canvas {
 		i.canvas = canvas
 		i.texture = nil
+		i.svg = nil
+		i.outer.Relayout()
+	}
+}
+
+func (i *Image) SVG() *gxui.SVG {
+	return i.svg
+}
+
+func (i *Image) SetSVG(svg *gxui.SVG) {
+	if i.svg != svg {
+		i.svg = svg
+		i.texture = nil
+		i.canvas = nil
 		i.outer.Relayout()
 	}
 }
@@ -115,8 +134,8 @@ func (i *Image) SetExplicitSize(explicitSize math.Size) {
 }
 
 func (i *Image) PixelAt(p math.Point) (math.Point, bool) {
-	ir := i.calculateDrawRect()
 	if tex := i.Texture(); tex != nil {
+		ir := i.calculateDrawRect(tex.Size())
 		s := tex.SizePixels()
 		p = p.Sub(ir.Min).
 			ScaleX(float32(s.W) / float32(ir.W())).
@@ -139,6 +158,8 @@ func (i *Image) DesiredSize(min, max math.Size) math.Size {
 			s = i.texture.Size()
 		case i.canvas != nil:
 			s = i.canvas.Size()
+		case i.svg != nil:
+			s = i.svg.Size()
 		}
 	}
 	return s.Expand(math.CreateSpacing(int(i.BorderPen().Width))).Clamp(min, max)
@@ -149,9 +170,11 @@ func (i *Image) Paint(c gxui.Canvas) {
 	i.PaintBackground(c, r)
 	switch {
 	case i.texture != nil:
-		c.DrawTexture(i.texture, i.calculateDrawRect())
+		c.DrawTexture(i.texture, i.calculateDrawRect(i.texture.Size()))
 	case i.canvas != nil:
 		c.DrawCanvas(i.canvas, math.ZeroPoint)
+	case i.svg != nil:
+		i.svg.Draw(c, i.calculateDrawRect(i.svg.Size()))
 	}
 	i.PaintBorder(c, r)
 }
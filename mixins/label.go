@@ -117,6 +117,15 @@ func (l *Label) VerticalAlignment() gxui.VerticalAlignment {
 	return l.verticalAlignment
 }
 
+// alignment returns the horizontal alignment of the text, mirrored if the
+// layout direction is right-to-left.
+func (l *Label) alignment() gxui.HorizontalAlignment {
+	if gxui.ResolveLayoutDirection(l.outer).RightToLeft() {
+		return l.horizontalAlignment.Flip()
+	}
+	return l.horizontalAlignment
+}
+
 // parts.DrawPaint overrides
 func (l *Label) Paint(c gxui.Canvas) {
 	r := l.outer.Size().Rect()
@@ -129,7 +138,7 @@ func (l *Label) Paint(c gxui.Canvas) {
 	offsets := l.font.Layout(&gxui.TextBlock{
 		Runes:     runes,
 		AlignRect: r,
-		H:         l.horizontalAlignment,
+		H:         l.alignment(),
 		V:         l.verticalAlignment,
 	})
 	c.DrawRunes(l.font, runes, offsets, l.color)
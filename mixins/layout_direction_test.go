@@ -0,0 +1,195 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mixins
+
+import (
+	"testing"
+
+	"github.com/google/gxui"
+	"github.com/google/gxui/math"
+	gxtest "github.com/google/gxui/testing"
+)
+
+type testDriver struct{ gxui.Driver }
+
+func (testDriver) AssertUIGoroutine() {}
+
+type testTheme struct{ gxui.Theme }
+
+func (testTheme) Driver() gxui.Driver { return testDriver{} }
+
+func createTestLinearLayout(theme gxui.Theme) *LinearLayout {
+	l := &LinearLayout{}
+	l.Init(l, theme)
+	return l
+}
+
+func (t testTheme) CreateLinearLayout() gxui.LinearLayout {
+	l := &LinearLayout{}
+	l.Init(l, t)
+	return l
+}
+
+func (t testTheme) CreateScrollBar() gxui.ScrollBar {
+	s := &ScrollBar{}
+	s.Init(s, t)
+	return s
+}
+
+type testPanelTab struct{ Image }
+
+func (*testPanelTab) SetText(string) {}
+func (*testPanelTab) SetActive(bool) {}
+
+type testPanelHolder struct{ PanelHolder }
+
+func (h *testPanelHolder) CreatePanelTab() PanelTab {
+	t := &testPanelTab{}
+	t.Init(t, h.Theme())
+	t.SetExplicitSize(math.Size{W: 20, H: 5})
+	return t
+}
+
+// createTestBox returns a control with a fixed desired size.
+func createTestBox(w, h int) *Image {
+	i := &Image{}
+	i.Init(i, testTheme{})
+	i.SetExplicitSize(math.Size{W: w, H: h})
+	return i
+}
+
+func bounds(c gxui.Control) math.Rect {
+	return c.Parent().Children().Find(c).Bounds()
+}
+
+func TestWindowLayoutRightToLeft(t *testing.T) {
+	w, _ := createTestWindow(100, 100)
+	w.SetPadding(math.Spacing{L: 5, T: 1})
+	a := createTestBox(20, 10)
+	w.AddChild(a)
+
+	w.LayoutChildren()
+	gxtest.AssertEquals(t, math.CreateRect(5, 1, 25, 11), bounds(a))
+
+	w.SetLayoutDirection(gxui.LayoutRightToLeft)
+	w.LayoutChildren()
+	gxtest.AssertEquals(t, math.CreateRect(75, 1, 95, 11), bounds(a))
+}
+
+func TestLinearLayoutRightToLeft(t *testing.T) {
+	l := createTestLinearLayout(testTheme{})
+	l.SetDirection(gxui.LeftToRight)
+	a, b := createTestBox(20, 10), createTestBox(30, 10)
+	l.AddChild(a)
+	l.AddChild(b)
+
+	l.SetSize(math.Size{W: 100, H: 10})
+	gxtest.AssertEquals(t, math.CreateRect(0, 0, 20, 10), bounds(a))
+	gxtest.AssertEquals(t, math.CreateRect(20, 0, 50, 10), bounds(b))
+
+	l.SetLayoutDirection(gxui.LayoutRightToLeft)
+	l.LayoutChildren()
+	gxtest.AssertEquals(t, math.CreateRect(80, 0, 100, 10), bounds(a))
+	gxtest.AssertEquals(t, math.CreateRect(50, 0, 80, 10), bounds(b))
+}
+
+func TestLinearLayoutInheritsRightToLeft(t *testing.T) {
+	parent := createTestLinearLayout(testTheme{})
+	child := createTestLinearLayout(testTheme{})
+	child.SetDirection(gxui.LeftToRight)
+	a := createTestBox(20, 10)
+	child.AddChild(a)
+	parent.AddChild(child)
+	parent.SetLayoutDirection(gxui.LayoutRightToLeft)
+
+	child.SetSize(math.Size{W: 100, H: 10})
+	gxtest.AssertEquals(t, math.CreateRect(80, 0, 100, 10), bounds(a))
+
+	child.SetLayoutDirection(gxui.LayoutLeftToRight)
+	child.LayoutChildren()
+	gxtest.AssertEquals(t, math.CreateRect(0, 0, 20, 10), bounds(a))
+}
+
+func TestTableLayoutRightToLeft(t *testing.T) {
+	l := &TableLayout{}
+	l.Init(l, testTheme{})
+	l.SetGrid(4, 1)
+	a, b := createTestBox(10, 10), createTestBox(10, 10)
+	l.SetChildAt(0, 0, 1, 1, a)
+	l.SetChildAt(1, 0, 3, 1, b)
+
+	l.SetSize(math.Size{W: 100, H: 10})
+	gxtest.AssertEquals(t, math.CreateRect(0, 0, 25, 10), bounds(a))
+	gxtest.AssertEquals(t, math.CreateRect(25, 0, 100, 10), bounds(b))
+
+	l.SetLayoutDirection(gxui.LayoutRightToLeft)
+	l.LayoutChildren()
+	gxtest.AssertEquals(t, math.CreateRect(75, 0, 100, 10), bounds(a))
+	gxtest.AssertEquals(t, math.CreateRect(0, 0, 75, 10), bounds(b))
+}
+
+func TestSplitterLayoutRightToLeft(t *testing.T) {
+	l := &SplitterLayout{}
+	l.Init(l, testTheme{})
+	l.SetOrientation(gxui.Horizontal)
+	a, b := createTestBox(10, 10), createTestBox(10, 10)
+	l.AddChild(a)
+	l.AddChild(b)
+	bar := l.Children()[1].Control
+
+	l.SetSize(math.Size{W: 104, H: 10})
+	gxtest.AssertEquals(t, math.CreateRect(0, 0, 50, 10), bounds(a))
+	gxtest.AssertEquals(t, math.CreateRect(50, 0, 54, 10), bounds(bar))
+	gxtest.AssertEquals(t, math.CreateRect(54, 0, 104, 10), bounds(b))
+
+	l.SetLayoutDirection(gxui.LayoutRightToLeft)
+	l.LayoutChildren()
+	gxtest.AssertEquals(t, math.CreateRect(54, 0, 104, 10), bounds(a))
+	gxtest.AssertEquals(t, math.CreateRect(50, 0, 54, 10), bounds(bar))
+	gxtest.AssertEquals(t, math.CreateRect(0, 0, 50, 10), bounds(b))
+}
+
+func TestScrollLayoutRightToLeft(t *testing.T) {
+	l := &ScrollLayout{}
+	l.Init(l, testTheme{})
+	child := createTestBox(200, 50)
+	l.SetChild(child)
+	barX, barY := l.scrollBarX.Control, l.scrollBarY.Control
+
+	l.SetSize(math.Size{W: 100, H: 100})
+	gxtest.AssertEquals(t, math.CreateRect(0, 0, 200, 50), bounds(child))
+	gxtest.AssertEquals(t, math.CreateRect(0, 90, 90, 100), bounds(barX))
+	gxtest.AssertEquals(t, math.CreateRect(90, 0, 100, 90), bounds(barY))
+
+	// The vertical scroll bar moves to the left, and the child is anchored to
+	// the right edge.
+	l.SetLayoutDirection(gxui.LayoutRightToLeft)
+	l.LayoutChildren()
+	gxtest.AssertEquals(t, math.CreateRect(-100, 0, 100, 50), bounds(child))
+	gxtest.AssertEquals(t, math.CreateRect(10, 90, 100, 100), bounds(barX))
+	gxtest.AssertEquals(t, math.CreateRect(0, 0, 10, 90), bounds(barY))
+}
+
+func TestPanelHolderRightToLeft(t *testing.T) {
+	h := &testPanelHolder{}
+	h.Init(h, testTheme{})
+	h.SetPadding(math.Spacing{L: 5, R: 1})
+	panel := createTestBox(10, 10)
+	h.AddPanel(panel, "panel")
+	tabs := h.tabLayout
+	tab := h.Tab(0)
+
+	h.SetSize(math.Size{W: 100, H: 50})
+	gxtest.AssertEquals(t, math.CreateRect(0, 0, 100, 5), bounds(tabs))
+	gxtest.AssertEquals(t, math.CreateRect(0, 0, 20, 5), bounds(tab))
+	gxtest.AssertEquals(t, math.CreateRect(5, 5, 99, 50), bounds(panel))
+
+	// The tab layout inherits the direction, so the tabs start on the right.
+	h.SetLayoutDirection(gxui.LayoutRightToLeft)
+	h.LayoutChildren()
+	gxtest.AssertEquals(t, math.CreateRect(0, 0, 100, 5), bounds(tabs))
+	gxtest.AssertEquals(t, math.CreateRect(80, 0, 100, 5), bounds(tab))
+	gxtest.AssertEquals(t, math.CreateRect(1, 5, 95, 50), bounds(panel))
+}
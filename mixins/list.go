@@ -6,6 +6,7 @@ package mixins
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/google/gxui"
 	"github.com/google/gxui/math"
@@ -36,7 +37,6 @@ type List struct {
 
 	outer ListOuter
 
-	theme                    gxui.Theme
 	adapter                  gxui.ListAdapter
 	scrollBar                gxui.ScrollBar
 	scrollBarChild           *gxui.Child
@@ -54,6 +54,8 @@ type List struct {
 	onItemClicked            gxui.Event
 	dataChangedSubscription  gxui.EventSubscription
 	dataReplacedSubscription gxui.EventSubscription
+	scroller                 scroller
+	smoothScrolling          bool
 }
 
 func (l *List) Init(outer ListOuter, theme gxui.Theme) {
@@ -62,11 +64,18 @@ func (l *List) Init(outer ListOuter, theme gxui.Theme) {
 	l.BackgroundBorderPainter.Init(outer)
 	l.Focusable.Init(outer)
 
-	l.theme = theme
 	l.scrollBar = theme.CreateScrollBar()
 	l.scrollBarChild = l.AddChild(l.scrollBar)
 	l.scrollBarEnabled = true
-	l.scrollBar.OnScroll(func(from, to int) { l.SetScrollOffset(from) })
+	l.scrollBar.OnScroll(func(from, to int) {
+		if from != l.clampScrollOffset(l.scrollOffset) {
+			l.SetScrollOffset(from)
+		}
+	})
+	l.scroller.init(theme.Driver(), l.maxScrollOffset, func(p math.Point) {
+		l.setScrollOffset(p.X)
+	})
+	outer.OnDetach(l.scroller.stop)
 
 	l.SetOrientation(gxui.Vertical)
 	l.SetBackgroundBrush(gxui.TransparentBrush)
@@ -74,6 +83,14 @@ func (l *List) Init(outer ListOuter, theme gxui.Theme) {
 
 	l.details = make(map[gxui.AdapterItem]itemDetails)
 
+	l.OnThemeChanged(func() {
+		// Recreate the items, as the adapter may size and create them
+		// differently with the new theme.
+		if l.adapter != nil {
+			l.DataChanged(true)
+		}
+	})
+
 	// Interface compliance test
 	_ = gxui.List(l)
 }
@@ -137,7 +154,7 @@ func (l *List) LayoutChildren() {
 					gxui.Path(l.outer), item, details.index, idx))
 			}
 		} else {
-			control := l.adapter.Create(l.theme, idx)
+			control := l.adapter.Create(l.Theme(), idx)
 			details.onClickSubscription = control.OnClick(func(ev gxui.MouseEvent) {
 				l.ItemClicked(ev, item)
 			})
@@ -186,7 +203,9 @@ func (l *List) LayoutChildren() {
 func (l *List) SetSize(size math.Size) {
 	l.Layoutable.SetSize(size)
 	// Ensure scroll offset is still valid
-	l.SetScrollOffset(l.scrollOffset)
+	if !l.scroller.active() {
+		l.SetScrollOffset(l.scrollOffset)
+	}
 }
 
 func (l *List) DesiredSize(min, max math.Size) math.Size {
@@ -221,26 +240,77 @@ func (l *List) SetScrollBarEnabled(enabled bool) {
 	}
 }
 
-func (l *List) SetScrollOffset(scrollOffset int) {
+// maxScrollOffset returns the maximum scroll offset in the X component of the
+// returned vector, as used by the list's scroller.
+func (l *List) maxScrollOffset() math.Vec2 {
+	s := l.outer.Size().Contract(l.outer.Padding())
+	major := l.orientation.Major(s.WH())
+	return math.Vec2{X: float32(math.Max(l.MajorAxisItemSize()*l.itemCount-major, 0))}
+}
+
+func (l *List) clampScrollOffset(scrollOffset int) int {
+	return math.Clamp(scrollOffset, 0, int(l.maxScrollOffset().X))
+}
+
+// setScrollOffset sets the scroll offset without clamping or cancelling any
+// scroll animation.
+func (l *List) setScrollOffset(scrollOffset int) {
 	if l.adapter == nil {
 		return
 	}
 	s := l.outer.Size().Contract(l.outer.Padding())
-	if l.orientation.Horizontal() {
-		maxScroll := math.Max(l.itemSize.W*l.itemCount-s.W, 0)
-		scrollOffset = math.Clamp(scrollOffset, 0, maxScroll)
-		l.scrollBar.SetScrollPosition(scrollOffset, scrollOffset+s.W)
-	} else {
-		maxScroll := math.Max(l.itemSize.H*l.itemCount-s.H, 0)
-		scrollOffset = math.Clamp(scrollOffset, 0, maxScroll)
-		l.scrollBar.SetScrollPosition(scrollOffset, scrollOffset+s.H)
-	}
-	if l.scrollOffset != scrollOffset {
-		l.scrollOffset = scrollOffset
+	changed := l.scrollOffset != scrollOffset
+	l.scrollOffset = scrollOffset
+	from := l.clampScrollOffset(scrollOffset)
+	l.scrollBar.SetScrollPosition(from, from+l.orientation.Major(s.WH()))
+	if changed {
 		l.LayoutChildren()
 	}
 }
 
+func (l *List) SetScrollOffset(scrollOffset int) {
+	if l.adapter == nil {
+		return
+	}
+	scrollOffset = l.clampScrollOffset(scrollOffset)
+	l.scroller.setPosition(math.Point{X: scrollOffset})
+	l.setScrollOffset(scrollOffset)
+}
+
+// scrollToOffset scrolls to the specified offset, animating if smooth
+// scrolling is enabled.
+func (l *List) scrollToOffset(scrollOffset int) {
+	if l.smoothScrolling {
+		l.scroller.animateTo(math.Point{X: l.clampScrollOffset(scrollOffset)})
+	} else {
+		l.SetScrollOffset(scrollOffset)
+	}
+}
+
+func (l *List) SmoothScrolling() bool {
+	return l.smoothScrolling
+}
+
+func (l *List) SetSmoothScrolling(enabled bool) {
+	l.smoothScrolling = enabled
+}
+
+func (l *List) OverscrollBounce() bool {
+	return l.scroller.bounce
+}
+
+func (l *List) SetOverscrollBounce(enabled bool) {
+	l.scroller.bounce = enabled
+}
+
+func (l *List) ScrollBarAutoHideDelay() time.Duration {
+	return l.scrollBar.AutoHideDelay()
+}
+
+func (l *List) SetScrollBarAutoHideDelay(delay time.Duration) {
+	l.scrollBar.SetAutoHideDelay(delay)
+}
+
 func (l *List) MajorAxisItemSize() int {
 	return l.orientation.Major(l.itemSize.WH())
 }
@@ -274,8 +344,9 @@ func (l *List) VisibleItemRange(includePartiallyVisible bool) (startIndex, endIn
 }
 
 func (l *List) SizeChanged() {
-	l.itemSize = l.adapter.Size(l.theme)
+	l.itemSize = l.adapter.Size(l.Theme())
 	l.scrollBar.SetScrollLimit(l.itemCount * l.MajorAxisItemSize())
+	l.scroller.stop()
 	l.SetScrollOffset(l.scrollOffset)
 	l.outer.Relayout()
 }
@@ -370,6 +441,18 @@ func (l *List) MouseExit(ev gxui.MouseEvent) {
 }
 
 func (l *List) MouseScroll(ev gxui.MouseEvent) (consume bool) {
+	if l.smoothScrolling {
+		delta := ev.ScrollDeltaY
+		if l.orientation.Horizontal() {
+			delta += ev.ScrollDeltaX
+		}
+		if delta == 0 {
+			return l.InputEventHandler.MouseScroll(ev)
+		}
+		itemSize := float32(l.MajorAxisItemSize())
+		l.scroller.fling(math.Vec2{X: -delta * itemSize / 8})
+		return true
+	}
 	if ev.ScrollY == 0 {
 		return l.InputEventHandler.MouseScroll(ev)
 	}
@@ -395,10 +478,10 @@ func (l *List) KeyPress(ev gxui.KeyboardEvent) (consume bool) {
 				l.SelectNext()
 				return true
 			case gxui.KeyPageUp:
-				l.SetScrollOffset(l.scrollOffset - l.Size().W)
+				l.scrollToOffset(l.scrollOffset - l.Size().W)
 				return true
 			case gxui.KeyPageDown:
-				l.SetScrollOffset(l.scrollOffset + l.Size().W)
+				l.scrollToOffset(l.scrollOffset + l.Size().W)
 				return true
 			}
 		} else {
@@ -410,10 +493,10 @@ func (l *List) KeyPress(ev gxui.KeyboardEvent) (consume bool) {
 				l.SelectNext()
 				return true
 			case gxui.KeyPageUp:
-				l.SetScrollOffset(l.scrollOffset - l.Size().H)
+				l.scrollToOffset(l.scrollOffset - l.Size().H)
 				return true
 			case gxui.KeyPageDown:
-				l.SetScrollOffset(l.scrollOffset + l.Size().H)
+				l.scrollToOffset(l.scrollOffset + l.Size().H)
 				return true
 			}
 		}
@@ -458,16 +541,16 @@ func (l *List) ScrollTo(item gxui.AdapterItem) {
 	startIndex, endIndex := l.VisibleItemRange(false)
 	if idx < startIndex {
 		if l.Orientation().Horizontal() {
-			l.SetScrollOffset(l.itemSize.W * idx)
+			l.scrollToOffset(l.itemSize.W * idx)
 		} else {
-			l.SetScrollOffset(l.itemSize.H * idx)
+			l.scrollToOffset(l.itemSize.H * idx)
 		}
 	} else if idx >= endIndex {
 		count := endIndex - startIndex
 		if l.Orientation().Horizontal() {
-			l.SetScrollOffset(l.itemSize.W * (idx - count + 1))
+			l.scrollToOffset(l.itemSize.W * (idx - count + 1))
 		} else {
-			l.SetScrollOffset(l.itemSize.H * (idx - count + 1))
+			l.scrollToOffset(l.itemSize.H * (idx - count + 1))
 		}
 	}
 }
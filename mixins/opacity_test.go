@@ -0,0 +1,62 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mixins
+
+import (
+	"testing"
+
+	"github.com/google/gxui"
+	gxtest "github.com/google/gxui/testing"
+)
+
+func TestSetOpacityClamps(t *testing.T) {
+	c := createTestBox(10, 10)
+	gxtest.AssertEquals(t, float32(1), c.Opacity())
+	for _, test := range []struct {
+		opacity, expected float32
+	}{
+		{0.25, 0.25},
+		{-1, 0},
+		{2, 1},
+	} {
+		c.SetOpacity(test.opacity)
+		gxtest.AssertEquals(t, test.expected, c.Opacity())
+	}
+}
+
+// layers returns the opacities of the layers pushed by the canvas of c.
+func layers(t *testing.T, c gxui.Control) []float32 {
+	l, _ := gxui.DisplayListOf(c.Draw())
+	opacities := []float32{}
+	for _, cmd := range l.Commands {
+		if cmd.Op == gxui.DisplayPushLayer {
+			gxtest.AssertEquals(t, gxui.BlendNormal, cmd.BlendMode)
+			opacities = append(opacities, cmd.Opacity)
+		}
+	}
+	return opacities
+}
+
+func TestPaintChildrenLayers(t *testing.T) {
+	w, _ := createTestWindow(20, 20)
+	l := createTestLinearLayout(testTheme{})
+	a, b := createTestBox(10, 10), createTestBox(10, 10)
+	l.AddChild(a)
+	l.AddChild(b)
+	w.AddChild(l)
+	w.LayoutChildren()
+
+	// Opaque children are painted without layers.
+	gxtest.AssertEquals(t, []float32{}, layers(t, l))
+
+	// Translucent children are painted into a layer.
+	b.SetOpacity(0.5)
+	gxtest.AssertEquals(t, []float32{0.5}, layers(t, l))
+
+	// Transparent children are not painted.
+	a.SetOpacity(0)
+	b.SetOpacity(1)
+	gxtest.AssertEquals(t, []float32{}, layers(t, l))
+}
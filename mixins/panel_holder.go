@@ -39,7 +39,6 @@ type PanelHolder struct {
 
 	outer PanelHolderOuter
 
-	theme     gxui.Theme
 	tabLayout gxui.LinearLayout
 	entries   []PanelEntry
 	selected  PanelEntry
@@ -95,7 +94,6 @@ func (p *PanelHolder) Init(outer PanelHolderOuter, theme gxui.Theme) {
 	p.Container.Init(outer, theme)
 
 	p.outer = outer
-	p.theme = theme
 
 	p.tabLayout = theme.CreateLinearLayout()
 	p.tabLayout.SetDirection(gxui.LeftToRight)
@@ -123,6 +121,10 @@ func (p *PanelHolder) LayoutChildren() {
 			child.Offset = rect.Min
 		}
 	}
+
+	if gxui.ResolveLayoutDirection(p.outer).RightToLeft() {
+		gxui.MirrorChildren(p.Children(), s.W)
+	}
 }
 
 func (p *PanelHolder) DesiredSize(min, max math.Size) math.Size {
@@ -27,7 +27,7 @@ func (b *BackgroundBorderPainter) Init(outer BackgroundBorderPainterOuter) {
 }
 
 func (b *BackgroundBorderPainter) PaintBackground(c gxui.Canvas, r math.Rect) {
-	if b.brush.Color.A != 0 {
+	if !b.brush.Transparent() {
 		w := b.pen.Width
 		c.DrawRoundedRect(r, w, w, w, w, gxui.TransparentPen, b.brush)
 	}
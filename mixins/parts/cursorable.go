@@ -0,0 +1,51 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package parts
+
+import (
+	"github.com/google/gxui"
+	"github.com/google/gxui/mixins/outer"
+)
+
+type CursorableOuter interface {
+	outer.Attachable
+	outer.Parenter
+}
+
+type Cursorable struct {
+	outer  CursorableOuter
+	cursor gxui.Cursor
+}
+
+func (c *Cursorable) Init(outer CursorableOuter) {
+	c.outer = outer
+}
+
+func (c *Cursorable) Cursor() gxui.Cursor {
+	return c.cursor
+}
+
+func (c *Cursorable) SetCursor(cursor gxui.Cursor) {
+	if c.cursor == cursor {
+		return
+	}
+	c.cursor = cursor
+	if !c.outer.Attached() {
+		return
+	}
+	// The mouse pointer may be over the control, so have the window show the
+	// new cursor.
+	for p := c.outer.Parent(); p != nil; {
+		if w, ok := p.(gxui.Window); ok {
+			w.UpdateCursor()
+			return
+		}
+		control, ok := p.(gxui.Control)
+		if !ok {
+			return
+		}
+		p = control.Parent()
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"runtime"
 
 	"github.com/google/gxui"
+	"github.com/google/gxui/math"
 	"github.com/google/gxui/mixins/outer"
 )
 
@@ -45,10 +46,20 @@ func (d *DrawPaint) Init(outer DrawPaintOuter, theme gxui.Theme) {
 }
 
 func (d *DrawPaint) Redraw() {
+	d.RedrawRegion(d.outer.Size().Rect())
+}
+
+// RedrawRegion requests a redraw of the control, where only the rectangle r,
+// in the control's local coordinates, is considered to have changed. The
+// control's canvas is always repainted in full, but the containing window only
+// needs to repaint r.
+func (d *DrawPaint) RedrawRegion(r math.Rect) {
 	d.driver.AssertUIGoroutine()
-	if !d.redrawRequested {
-		if p := d.outer.Parent(); p != nil {
-			d.redrawRequested = true
+	if p := d.outer.Parent(); p != nil {
+		d.redrawRequested = true
+		if c, ok := d.outer.(gxui.Control); ok {
+			gxui.RedrawChildRegion(p, c, r)
+		} else {
 			p.Redraw()
 		}
 	}
@@ -0,0 +1,86 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package parts
+
+import (
+	"github.com/google/gxui"
+	"github.com/google/gxui/mixins/outer"
+)
+
+type EnableableOuter interface {
+	outer.Attachable
+	outer.Parenter
+	outer.Redrawer
+}
+
+type Enableable struct {
+	outer            EnableableOuter
+	enabled          bool
+	wasEnabled       bool
+	onEnabledChanged gxui.Event
+	parentChanged    gxui.EventSubscription
+}
+
+func (e *Enableable) Init(outer EnableableOuter) {
+	e.outer = outer
+	e.enabled = true
+	e.wasEnabled = true
+	outer.OnAttach(e.attach)
+	outer.OnDetach(e.detach)
+}
+
+// attach subscribes to the enabled changes of the new parent, as a disabled
+// ancestor disables all of its descendants.
+func (e *Enableable) attach() {
+	if p, ok := e.outer.Parent().(gxui.Control); ok {
+		e.parentChanged = p.OnEnabledChanged(e.update)
+	}
+	e.update()
+}
+
+func (e *Enableable) detach() {
+	if e.parentChanged != nil {
+		e.parentChanged.Unlisten()
+		e.parentChanged = nil
+	}
+}
+
+// update fires OnEnabledChanged and issues a redraw if IsEnabled has changed
+// since it was last called.
+func (e *Enableable) update() {
+	enabled := e.IsEnabled()
+	if e.wasEnabled == enabled {
+		return
+	}
+	e.wasEnabled = enabled
+	if e.onEnabledChanged != nil {
+		e.onEnabledChanged.Fire()
+	}
+	e.outer.Redraw()
+}
+
+func (e *Enableable) IsEnabled() bool {
+	if !e.enabled {
+		return false
+	}
+	if p, ok := e.outer.Parent().(gxui.Control); ok {
+		return p.IsEnabled()
+	}
+	return true
+}
+
+func (e *Enableable) SetEnabled(enabled bool) {
+	if e.enabled != enabled {
+		e.enabled = enabled
+		e.update()
+	}
+}
+
+func (e *Enableable) OnEnabledChanged(f func()) gxui.EventSubscription {
+	if e.onEnabledChanged == nil {
+		e.onEnabledChanged = gxui.CreateEvent(func() {})
+	}
+	return e.onEnabledChanged.Listen(f)
+}
@@ -0,0 +1,76 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package parts
+
+import (
+	"testing"
+
+	"github.com/google/gxui"
+	test "github.com/google/gxui/testing"
+)
+
+type testParent struct {
+	gxui.Control     // Only the methods below are implemented.
+	enabled          bool
+	onEnabledChanged gxui.Event
+}
+
+func (p *testParent) Children() gxui.Children { return nil }
+func (p *testParent) Relayout()               {}
+func (p *testParent) Redraw()                 {}
+func (p *testParent) IsEnabled() bool         { return p.enabled }
+
+func (p *testParent) SetEnabled(enabled bool) {
+	p.enabled = enabled
+	p.onEnabledChanged.Fire()
+}
+
+func (p *testParent) OnEnabledChanged(f func()) gxui.EventSubscription {
+	return p.onEnabledChanged.Listen(f)
+}
+
+type testEnableable struct {
+	Attachable
+	Enableable
+	parent  gxui.Parent
+	redraws int
+}
+
+func (e *testEnableable) Parent() gxui.Parent { return e.parent }
+func (e *testEnableable) Redraw()             { e.redraws++ }
+func (e *testEnableable) Relayout()           {}
+
+func TestEnableableCascades(t *testing.T) {
+	parent := &testParent{enabled: true, onEnabledChanged: gxui.CreateEvent(func() {})}
+	e := &testEnableable{parent: parent}
+	e.Attachable.Init(e)
+	e.Enableable.Init(e)
+	e.Attach()
+
+	changed := 0
+	e.OnEnabledChanged(func() { changed++ })
+
+	parent.SetEnabled(false)
+	test.AssertEquals(t, false, e.IsEnabled())
+	test.AssertEquals(t, 1, changed)
+	test.AssertEquals(t, 1, e.redraws)
+
+	// Enabling the parent does not enable a control that was disabled itself.
+	e.SetEnabled(false)
+	parent.SetEnabled(true)
+	test.AssertEquals(t, false, e.IsEnabled())
+	test.AssertEquals(t, 1, changed)
+
+	e.SetEnabled(true)
+	test.AssertEquals(t, true, e.IsEnabled())
+	test.AssertEquals(t, 2, changed)
+
+	// Detached controls no longer follow their old parent.
+	e.Detach()
+	e.parent = nil
+	parent.SetEnabled(false)
+	test.AssertEquals(t, true, e.IsEnabled())
+	test.AssertEquals(t, 2, changed)
+}
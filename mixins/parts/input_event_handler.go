@@ -27,6 +27,8 @@ type InputEventHandler struct {
 	onKeyDown     gxui.Event
 	onKeyUp       gxui.Event
 	onKeyRepeat   gxui.Event
+
+	onMouseCaptureLost gxui.Event
 }
 
 func (m *InputEventHandler) getOnClick() gxui.Event {
@@ -120,6 +122,13 @@ func (m *InputEventHandler) getOnKeyRepeat() gxui.Event {
 	return m.onKeyRepeat
 }
 
+func (m *InputEventHandler) getOnMouseCaptureLost() gxui.Event {
+	if m.onMouseCaptureLost == nil {
+		m.onMouseCaptureLost = gxui.CreateEvent(m.MouseCaptureLost)
+	}
+	return m.onMouseCaptureLost
+}
+
 func (m *InputEventHandler) Init(outer InputEventHandlerOuter) {
 	m.outer = outer
 	m.isMouseDown = make(map[gxui.MouseButton]bool)
@@ -186,6 +195,10 @@ func (m *InputEventHandler) KeyRepeat(ev gxui.KeyboardEvent) {
 	m.getOnKeyRepeat().Fire(ev)
 }
 
+func (m *InputEventHandler) MouseCaptureLost() {
+	m.getOnMouseCaptureLost().Fire()
+}
+
 func (m *InputEventHandler) OnClick(f func(gxui.MouseEvent)) gxui.EventSubscription {
 	return m.getOnClick().Listen(f)
 }
@@ -238,6 +251,10 @@ func (m *InputEventHandler) OnKeyRepeat(f func(gxui.KeyboardEvent)) gxui.EventSu
 	return m.getOnKeyRepeat().Listen(f)
 }
 
+func (m *InputEventHandler) OnMouseCaptureLost(f func()) gxui.EventSubscription {
+	return m.getOnMouseCaptureLost().Listen(f)
+}
+
 func (m *InputEventHandler) IsMouseOver() bool {
 	return m.isMouseOver
 }
@@ -22,6 +22,10 @@ type Layoutable struct {
 	driver            gxui.Driver
 	margin            math.Spacing
 	size              math.Size
+	minSize           math.Size
+	maxSize           math.Size
+	preferredSize     math.Size
+	layoutDirection   gxui.LayoutDirection
 	relayoutRequested bool
 	inLayoutChildren  bool // True when calling LayoutChildren
 }
@@ -29,6 +33,7 @@ type Layoutable struct {
 func (l *Layoutable) Init(outer LayoutableOuter, theme gxui.Theme) {
 	l.outer = outer
 	l.driver = theme.Driver()
+	l.maxSize = math.MaxSize
 }
 
 func (l *Layoutable) SetMargin(m math.Spacing) {
@@ -42,17 +47,59 @@ func (l *Layoutable) Margin() math.Spacing {
 	return l.margin
 }
 
+func (l *Layoutable) MinSize() math.Size {
+	return l.minSize
+}
+
+func (l *Layoutable) SetMinSize(size math.Size) {
+	assertNonNegativeSize("SetMinSize", size)
+	l.setSizeConstraint(&l.minSize, size)
+}
+
+func (l *Layoutable) MaxSize() math.Size {
+	return l.maxSize
+}
+
+func (l *Layoutable) SetMaxSize(size math.Size) {
+	assertNonNegativeSize("SetMaxSize", size)
+	l.setSizeConstraint(&l.maxSize, size)
+}
+
+func (l *Layoutable) PreferredSize() math.Size {
+	return l.preferredSize
+}
+
+func (l *Layoutable) SetPreferredSize(size math.Size) {
+	assertNonNegativeSize("SetPreferredSize", size)
+	l.setSizeConstraint(&l.preferredSize, size)
+}
+
+func (l *Layoutable) setSizeConstraint(field *math.Size, size math.Size) {
+	if *field != size {
+		*field = size
+		if p := l.outer.Parent(); p != nil {
+			p.Relayout()
+		}
+	}
+}
+
+func (l *Layoutable) LayoutDirection() gxui.LayoutDirection {
+	return l.layoutDirection
+}
+
+func (l *Layoutable) SetLayoutDirection(d gxui.LayoutDirection) {
+	if l.layoutDirection != d {
+		l.layoutDirection = d
+		RelayoutTree(l.outer)
+	}
+}
+
 func (l *Layoutable) Size() math.Size {
 	return l.size
 }
 
 func (l *Layoutable) SetSize(size math.Size) {
-	if size.W < 0 {
-		panic(fmt.Errorf("SetSize() called with a negative width. Size: %v", size))
-	}
-	if size.H < 0 {
-		panic(fmt.Errorf("SetSize() called with a negative height. Size: %v", size))
-	}
+	assertNonNegativeSize("SetSize", size)
 
 	sizeChanged := l.size != size
 	l.size = size
@@ -77,3 +124,26 @@ func (l *Layoutable) Relayout() {
 		}
 	}
 }
+
+func assertNonNegativeSize(method string, size math.Size) {
+	if size.W < 0 {
+		panic(fmt.Errorf("%s() called with a negative width. Size: %v", method, size))
+	}
+	if size.H < 0 {
+		panic(fmt.Errorf("%s() called with a negative height. Size: %v", method, size))
+	}
+}
+
+// RelayoutTree requests a relayout of c and all of its descendants. It is used
+// when a change affects the layout of an entire sub-tree, such as a change of
+// layout direction.
+func RelayoutTree(c interface{}) {
+	if r, ok := c.(outer.Relayouter); ok {
+		r.Relayout()
+	}
+	if p, ok := c.(gxui.Parent); ok {
+		for _, child := range p.Children() {
+			RelayoutTree(child.Control)
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 type LinearLayoutOuter interface {
 	gxui.Container
+	gxui.LayoutDirectioner
 	outer.Sized
 }
 
@@ -41,7 +42,7 @@ func (l *LinearLayout) LayoutChildren() {
 	}
 	for _, c := range children {
 		cm := c.Control.Margin()
-		cs := c.Control.DesiredSize(math.ZeroSize, s.Contract(cm).Max(math.ZeroSize))
+		cs := gxui.ConstrainedDesiredSize(c.Control, math.ZeroSize, s.Contract(cm).Max(math.ZeroSize))
 		c.Control.SetSize(cs)
 
 		// Calculate minor-axis alignment
@@ -95,6 +96,10 @@ func (l *LinearLayout) LayoutChildren() {
 			s.H -= cs.H + cm.H()
 		}
 	}
+
+	if gxui.ResolveLayoutDirection(l.outer).RightToLeft() {
+		gxui.MirrorChildren(children, l.outer.Size().W)
+	}
 }
 
 func (l *LinearLayout) DesiredSize(min, max math.Size) math.Size {
@@ -108,7 +113,7 @@ func (l *LinearLayout) DesiredSize(min, max math.Size) math.Size {
 	horizontal := l.direction.Orientation().Horizontal()
 	offset := math.Point{X: 0, Y: 0}
 	for _, c := range children {
-		cs := c.Control.DesiredSize(math.ZeroSize, max)
+		cs := gxui.ConstrainedDesiredSize(c.Control, math.ZeroSize, max)
 		cm := c.Control.Margin()
 		cb := cs.Expand(cm).Rect().Offset(offset)
 		if horizontal {
@@ -6,6 +6,7 @@ package parts
 
 import (
 	"github.com/google/gxui"
+	"github.com/google/gxui/math"
 	"github.com/google/gxui/mixins/outer"
 )
 
@@ -25,10 +26,22 @@ func (p *PaintChildren) Init(outer PaintChildrenOuter) {
 
 func (p *PaintChildren) Paint(c gxui.Canvas) {
 	for i, v := range p.outer.Children() {
-		if v.Control.IsVisible() {
+		if v.Control.IsVisible() && v.Control.Opacity() > 0 {
 			c.Push()
+			if m := v.Transform(); m != math.Mat3Ident {
+				// Apply the transform about the child's origin, so that
+				// PaintChild can continue to draw at the child's offset.
+				o := v.Offset.Vec2()
+				c.Transform(math.CreateMat3Translate(-o.X, -o.Y).Mul(m).Mul(math.CreateMat3Translate(o.X, o.Y)))
+			}
 			c.AddClip(v.Control.Size().Rect().Offset(v.Offset))
-			p.outer.PaintChild(c, v, i)
+			if o := v.Control.Opacity(); o < 1 {
+				c.PushLayer(o, gxui.BlendNormal)
+				p.outer.PaintChild(c, v, i)
+				c.PopLayer()
+			} else {
+				p.outer.PaintChild(c, v, i)
+			}
 			c.Pop()
 		}
 	}
@@ -0,0 +1,37 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package parts
+
+import (
+	"github.com/google/gxui"
+)
+
+type routedEventKey struct {
+	kind  gxui.RoutedEventKind
+	phase gxui.RoutePhase
+}
+
+type RoutedEventHandler struct {
+	events map[routedEventKey]gxui.Event
+}
+
+func (h *RoutedEventHandler) OnRoutedEvent(kind gxui.RoutedEventKind, phase gxui.RoutePhase, f func(*gxui.RoutedEvent)) gxui.EventSubscription {
+	if h.events == nil {
+		h.events = make(map[routedEventKey]gxui.Event)
+	}
+	key := routedEventKey{kind, phase}
+	e, found := h.events[key]
+	if !found {
+		e = gxui.CreateEvent(func(*gxui.RoutedEvent) {})
+		h.events[key] = e
+	}
+	return e.Listen(f)
+}
+
+func (h *RoutedEventHandler) HandleRoutedEvent(ev *gxui.RoutedEvent) {
+	if e, found := h.events[routedEventKey{ev.Kind, ev.Phase}]; found {
+		e.Fire(ev)
+	}
+}
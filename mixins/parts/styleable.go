@@ -0,0 +1,86 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package parts
+
+import (
+	"fmt"
+
+	"github.com/google/gxui"
+	"github.com/google/gxui/mixins/outer"
+)
+
+type StyleableOuter interface {
+	outer.Redrawer
+}
+
+type Styleable struct {
+	outer          StyleableOuter
+	classes        []string
+	overrides      map[gxui.StyleState]gxui.StyleOverride
+	onStyleChanged gxui.Event
+}
+
+func (s *Styleable) Init(outer StyleableOuter) {
+	s.outer = outer
+}
+
+func (s *Styleable) changed() {
+	if s.onStyleChanged != nil {
+		s.onStyleChanged.Fire()
+	}
+	s.outer.Redraw()
+}
+
+func (s *Styleable) StyleClasses() []string {
+	return append([]string{}, s.classes...)
+}
+
+func (s *Styleable) HasStyleClass(class string) bool {
+	for _, c := range s.classes {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Styleable) AddStyleClass(class string) {
+	if !s.HasStyleClass(class) {
+		s.classes = append(s.classes, class)
+		s.changed()
+	}
+}
+
+func (s *Styleable) RemoveStyleClass(class string) {
+	for i, c := range s.classes {
+		if c == class {
+			s.classes = append(s.classes[:i], s.classes[i+1:]...)
+			s.changed()
+			return
+		}
+	}
+}
+
+func (s *Styleable) StyleOverride(state gxui.StyleState) gxui.StyleOverride {
+	return s.overrides[state]
+}
+
+func (s *Styleable) SetStyleOverride(state gxui.StyleState, override gxui.StyleOverride) {
+	if !state.IsSingle() {
+		panic(fmt.Errorf("Style overrides must be for a single state, got %v", state))
+	}
+	if s.overrides == nil {
+		s.overrides = make(map[gxui.StyleState]gxui.StyleOverride)
+	}
+	s.overrides[state] = override
+	s.changed()
+}
+
+func (s *Styleable) OnStyleChanged(f func()) gxui.EventSubscription {
+	if s.onStyleChanged == nil {
+		s.onStyleChanged = gxui.CreateEvent(func() {})
+	}
+	return s.onStyleChanged.Listen(f)
+}
@@ -0,0 +1,52 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package parts
+
+import (
+	"github.com/google/gxui"
+	"github.com/google/gxui/mixins/outer"
+)
+
+type ThemeableOuter interface {
+	outer.Redrawer
+	outer.Relayouter
+}
+
+type Themeable struct {
+	outer          ThemeableOuter
+	theme          gxui.Theme
+	onThemeChanged gxui.Event
+}
+
+func (t *Themeable) Init(outer ThemeableOuter, theme gxui.Theme) {
+	t.outer = outer
+	t.theme = theme
+}
+
+func (t *Themeable) Theme() gxui.Theme {
+	return t.theme
+}
+
+// SetTheme sets the theme, firing OnThemeChanged and issuing a relayout and
+// redraw if the theme has changed. SetTheme does not change the theme of any
+// children.
+func (t *Themeable) SetTheme(theme gxui.Theme) {
+	if t.theme == theme {
+		return
+	}
+	t.theme = theme
+	if t.onThemeChanged != nil {
+		t.onThemeChanged.Fire()
+	}
+	t.outer.Relayout()
+	t.outer.Redraw()
+}
+
+func (t *Themeable) OnThemeChanged(f func()) gxui.EventSubscription {
+	if t.onThemeChanged == nil {
+		t.onThemeChanged = gxui.CreateEvent(func() {})
+	}
+	return t.onThemeChanged.Listen(f)
+}
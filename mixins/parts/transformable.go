@@ -0,0 +1,41 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package parts
+
+import (
+	"github.com/google/gxui/math"
+	"github.com/google/gxui/mixins/outer"
+)
+
+type TransformableOuter interface {
+	outer.Parenter
+}
+
+// Transformable implements gxui.Transformer. The transform only affects how
+// the control is painted and hit-tested by its parent, not its layout.
+type Transformable struct {
+	outer     TransformableOuter
+	transform math.Mat3
+}
+
+func (t *Transformable) Init(outer TransformableOuter) {
+	t.outer = outer
+	t.transform = math.Mat3Ident
+}
+
+func (t *Transformable) Transform() math.Mat3 {
+	return t.transform
+}
+
+// SetTransform sets the affine transform, in DIPs, applied to the control
+// when it is painted by its parent.
+func (t *Transformable) SetTransform(m math.Mat3) {
+	if t.transform != m {
+		t.transform = m
+		if p := t.outer.Parent(); p != nil {
+			p.Redraw()
+		}
+	}
+}
@@ -5,6 +5,8 @@
 package mixins
 
 import (
+	"time"
+
 	"github.com/google/gxui"
 	"github.com/google/gxui/math"
 	"github.com/google/gxui/mixins/base"
@@ -16,7 +18,8 @@ type ScrollBarOuter interface {
 
 type ScrollBar struct {
 	base.Control
-	outer ScrollBarOuter
+	outer  ScrollBarOuter
+	driver gxui.Driver
 
 	orientation         gxui.Orientation
 	thickness           int
@@ -29,6 +32,26 @@ type ScrollBar struct {
 	barRect             math.Rect
 	onScroll            gxui.Event
 	autoHide            bool
+	autoHideDelay       time.Duration
+	autoHideTimer       *time.Timer
+	inactive            bool
+	dragging            bool
+	dragOffset          math.Point // Offset of the mouse from the bar's origin while dragging
+}
+
+// mirrored returns true if the scroll bar is horizontal and laid out
+// right-to-left, in which case the scroll position starts from the right.
+func (s *ScrollBar) mirrored() bool {
+	return s.orientation.Horizontal() && gxui.ResolveLayoutDirection(s.outer).RightToLeft()
+}
+
+// logicalPoint transforms the local point p to the left-to-right coordinate
+// space used for the scroll bar's position calculations.
+func (s *ScrollBar) logicalPoint(p math.Point) math.Point {
+	if s.mirrored() {
+		p.X = s.Size().W - p.X
+	}
+	return p
 }
 
 func (s *ScrollBar) positionAt(p math.Point) int {
@@ -70,16 +93,53 @@ func (s *ScrollBar) updateBarRect() {
 	s.barRect = b
 }
 
+func (s *ScrollBar) stopAutoHideTimer() {
+	if s.autoHideTimer != nil {
+		s.autoHideTimer.Stop()
+		s.autoHideTimer = nil
+	}
+}
+
+func (s *ScrollBar) setInactive(inactive bool) {
+	if s.inactive != inactive {
+		s.inactive = inactive
+		if p := s.Parent(); p != nil {
+			p.Redraw()
+		}
+	}
+}
+
+// wake reveals an auto-hidden scroll bar and restarts the inactivity timer.
+func (s *ScrollBar) wake() {
+	s.stopAutoHideTimer()
+	s.setInactive(false)
+	if s.autoHideDelay <= 0 || !s.Attached() {
+		return
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(s.autoHideDelay, func() {
+		s.driver.Call(func() {
+			if s.autoHideTimer == timer && !s.IsMouseOver() && !s.dragging {
+				s.autoHideTimer = nil
+				s.setInactive(true)
+			}
+		})
+	})
+	s.autoHideTimer = timer
+}
+
 func (s *ScrollBar) Init(outer ScrollBarOuter, theme gxui.Theme) {
 	s.Control.Init(outer, theme)
 
 	s.outer = outer
+	s.driver = theme.Driver()
 	s.thickness = 10
 	s.minBarLength = 10
 	s.scrollPositionFrom = 0
 	s.scrollPositionTo = 100
 	s.scrollLimit = 100
 	s.onScroll = gxui.CreateEvent(s.SetScrollPosition)
+	outer.OnDetach(s.stopAutoHideTimer)
 
 	// Interface compliance test
 	_ = gxui.ScrollBar(s)
@@ -105,7 +165,12 @@ func (s *ScrollBar) DesiredSize(min, max math.Size) math.Size {
 
 func (s *ScrollBar) Paint(c gxui.Canvas) {
 	c.DrawRoundedRect(s.outer.Size().Rect(), 3, 3, 3, 3, s.railPen, s.railBrush)
-	c.DrawRoundedRect(s.barRect, 3, 3, 3, 3, s.barPen, s.barBrush)
+	b := s.barRect
+	if s.mirrored() {
+		w := s.Size().W
+		b.Min.X, b.Max.X = w-b.Max.X, w-b.Min.X
+	}
+	c.DrawRoundedRect(b, 3, 3, 3, 3, s.barPen, s.barBrush)
 }
 
 func (s *ScrollBar) RailBrush() gxui.Brush {
@@ -161,6 +226,7 @@ func (s *ScrollBar) SetScrollPosition(from, to int) {
 		s.scrollPositionFrom, s.scrollPositionTo = from, to
 		s.updateBarRect()
 		s.Redraw()
+		s.wake()
 		s.onScroll.Fire(from, to)
 	}
 }
@@ -188,10 +254,24 @@ func (s *ScrollBar) SetAutoHide(autoHide bool) {
 	}
 }
 
+func (s *ScrollBar) AutoHideDelay() time.Duration {
+	return s.autoHideDelay
+}
+
+func (s *ScrollBar) SetAutoHideDelay(delay time.Duration) {
+	if s.autoHideDelay != delay {
+		s.autoHideDelay = delay
+		s.wake()
+	}
+}
+
 func (s *ScrollBar) IsVisible() bool {
 	if s.autoHide && s.scrollPositionFrom == 0 && s.scrollPositionTo == s.scrollLimit {
 		return false
 	}
+	if s.autoHideDelay > 0 && s.inactive {
+		return false
+	}
 	return s.Control.IsVisible()
 }
 
@@ -208,8 +288,8 @@ func (s *ScrollBar) SetOrientation(o gxui.Orientation) {
 
 // InputEventHandler overrides
 func (s *ScrollBar) Click(ev gxui.MouseEvent) (consume bool) {
-	if !s.barRect.Contains(ev.Point) {
-		p := s.positionAt(ev.Point)
+	if lp := s.logicalPoint(ev.Point); !s.barRect.Contains(lp) {
+		p := s.positionAt(lp)
 		from, to := s.scrollPositionFrom, s.scrollPositionTo
 		switch {
 		case p < from:
@@ -226,17 +306,41 @@ func (s *ScrollBar) Click(ev gxui.MouseEvent) (consume bool) {
 }
 
 func (s *ScrollBar) MouseDown(ev gxui.MouseEvent) {
-	if s.barRect.Contains(ev.Point) {
-		initialOffset := ev.Point.Sub(s.barRect.Min)
-		var mms, mus gxui.EventSubscription
-		mms = ev.Window.OnMouseMove(func(we gxui.MouseEvent) {
-			p := gxui.WindowToChild(we.WindowPoint, s.outer)
-			s.SetScrollPosition(s.rangeAt(p.Sub(initialOffset)))
-		})
-		mus = ev.Window.OnMouseUp(func(we gxui.MouseEvent) {
-			mms.Unlisten()
-			mus.Unlisten()
-		})
+	if lp := s.logicalPoint(ev.Point); s.barRect.Contains(lp) {
+		s.dragging = true
+		s.dragOffset = lp.Sub(s.barRect.Min)
+		ev.Window.CaptureMouse(s.outer)
 	}
 	s.InputEventHandler.MouseDown(ev)
 }
+
+func (s *ScrollBar) MouseMove(ev gxui.MouseEvent) {
+	if s.dragging {
+		p := s.logicalPoint(ev.Point)
+		s.SetScrollPosition(s.rangeAt(p.Sub(s.dragOffset)))
+	}
+	s.InputEventHandler.MouseMove(ev)
+}
+
+func (s *ScrollBar) MouseUp(ev gxui.MouseEvent) {
+	if s.dragging {
+		ev.Window.ReleaseMouse()
+	}
+	s.InputEventHandler.MouseUp(ev)
+}
+
+func (s *ScrollBar) MouseCaptureLost() {
+	s.dragging = false
+	s.wake()
+	s.InputEventHandler.MouseCaptureLost()
+}
+
+func (s *ScrollBar) MouseEnter(ev gxui.MouseEvent) {
+	s.wake()
+	s.InputEventHandler.MouseEnter(ev)
+}
+
+func (s *ScrollBar) MouseExit(ev gxui.MouseEvent) {
+	s.InputEventHandler.MouseExit(ev)
+	s.wake()
+}
@@ -5,6 +5,8 @@
 package mixins
 
 import (
+	"time"
+
 	"github.com/google/gxui"
 	"github.com/google/gxui/math"
 	"github.com/google/gxui/mixins/base"
@@ -20,12 +22,13 @@ type ScrollLayout struct {
 	parts.BackgroundBorderPainter
 
 	outer                  ScrollLayoutOuter
-	theme                  gxui.Theme
 	scrollOffset           math.Point
 	canScrollX, canScrollY bool
 	scrollBarX, scrollBarY *gxui.Child
 	child                  *gxui.Child
 	innerSize              math.Size
+	scroller               scroller
+	smoothScrolling        bool
 }
 
 func (l *ScrollLayout) Init(outer ScrollLayoutOuter, theme gxui.Theme) {
@@ -33,19 +36,29 @@ func (l *ScrollLayout) Init(outer ScrollLayoutOuter, theme gxui.Theme) {
 	l.BackgroundBorderPainter.Init(outer)
 
 	l.outer = outer
-	l.theme = theme
 	l.canScrollX = true
 	l.canScrollY = true
 	scrollBarX := theme.CreateScrollBar()
 	scrollBarX.SetOrientation(gxui.Horizontal)
-	scrollBarX.OnScroll(func(from, to int) { l.SetScrollOffset(math.Point{X: from, Y: l.scrollOffset.Y}) })
+	scrollBarX.OnScroll(func(from, to int) {
+		if from != l.clampScrollOffset(l.scrollOffset).X {
+			l.SetScrollOffset(math.Point{X: from, Y: l.scrollOffset.Y})
+		}
+	})
 	scrollBarY := theme.CreateScrollBar()
 	scrollBarY.SetOrientation(gxui.Vertical)
-	scrollBarY.OnScroll(func(from, to int) { l.SetScrollOffset(math.Point{X: l.scrollOffset.X, Y: from}) })
+	scrollBarY.OnScroll(func(from, to int) {
+		if from != l.clampScrollOffset(l.scrollOffset).Y {
+			l.SetScrollOffset(math.Point{X: l.scrollOffset.X, Y: from})
+		}
+	})
 	l.scrollBarX = l.AddChild(scrollBarX)
 	l.scrollBarY = l.AddChild(scrollBarY)
 	l.SetMouseEventTarget(true)
 
+	l.scroller.init(theme.Driver(), l.maxScrollOffset, l.setScrollOffset)
+	outer.OnDetach(l.scroller.stop)
+
 	// Interface compliance test
 	_ = gxui.ScrollLayout(l)
 }
@@ -75,44 +88,108 @@ func (l *ScrollLayout) LayoutChildren() {
 		if l.canScrollY {
 			max.H = math.MaxSize.H
 		}
-		cs := l.child.Control.DesiredSize(math.ZeroSize, max)
+		cs := gxui.ConstrainedDesiredSize(l.child.Control, math.ZeroSize, max)
 		l.child.Layout(cs.Rect().Offset(l.scrollOffset.Neg()).Offset(o))
 		l.scrollBarX.Control.(gxui.ScrollBar).SetScrollLimit(cs.W)
 		l.scrollBarY.Control.(gxui.ScrollBar).SetScrollLimit(cs.H)
 	}
 
-	l.SetScrollOffset(l.scrollOffset)
+	if gxui.ResolveLayoutDirection(l.outer).RightToLeft() {
+		// The vertical scroll bar is placed on the left, and the child is
+		// anchored to the right edge, scrolling leftwards.
+		gxui.MirrorChildren(l.outer.Children(), l.outer.Size().W)
+	}
+
+	if l.scroller.active() {
+		// Let the scroller own the offset (which may be overscrolled).
+		l.updateScrollBars(l.scrollOffset)
+	} else {
+		l.setScrollOffset(l.clampScrollOffset(l.scrollOffset))
+	}
 }
 
 func (l *ScrollLayout) DesiredSize(min, max math.Size) math.Size {
 	return max
 }
 
-func (l *ScrollLayout) SetScrollOffset(scrollOffset math.Point) bool {
+func (l *ScrollLayout) maxScrollOffset() math.Vec2 {
+	var cs math.Size
+	if l.child != nil {
+		cs = l.child.Control.Size()
+	}
+	return cs.Sub(l.innerSize).Point().Max(math.Point{}).Vec2()
+}
+
+func (l *ScrollLayout) clampScrollOffset(scrollOffset math.Point) math.Point {
+	return scrollOffset.Min(l.maxScrollOffset().Point()).Max(math.Point{})
+}
+
+func (l *ScrollLayout) updateScrollBars(scrollOffset math.Point) {
 	var cs math.Size
 	if l.child != nil {
 		cs = l.child.Control.Size()
 	}
 
 	s := l.innerSize
-	scrollOffset = scrollOffset.Min(cs.Sub(s).Point()).Max(math.Point{})
+	scrollOffset = l.clampScrollOffset(scrollOffset)
 
 	l.scrollBarX.Control.SetVisible(l.canScrollX && cs.W > s.W)
 	l.scrollBarY.Control.SetVisible(l.canScrollY && cs.H > s.H)
-	l.scrollBarX.Control.(gxui.ScrollBar).SetScrollPosition(l.scrollOffset.X, l.scrollOffset.X+s.W)
-	l.scrollBarY.Control.(gxui.ScrollBar).SetScrollPosition(l.scrollOffset.Y, l.scrollOffset.Y+s.H)
+	l.scrollBarX.Control.(gxui.ScrollBar).SetScrollPosition(scrollOffset.X, scrollOffset.X+s.W)
+	l.scrollBarY.Control.(gxui.ScrollBar).SetScrollPosition(scrollOffset.Y, scrollOffset.Y+s.H)
+}
 
-	if l.scrollOffset != scrollOffset {
-		l.scrollOffset = scrollOffset
+// setScrollOffset sets the scroll offset without clamping or cancelling any
+// scroll animation.
+func (l *ScrollLayout) setScrollOffset(scrollOffset math.Point) {
+	changed := l.scrollOffset != scrollOffset
+	l.scrollOffset = scrollOffset
+	l.updateScrollBars(scrollOffset)
+	if changed {
 		l.Relayout()
-		return true
 	}
+}
 
-	return false
+func (l *ScrollLayout) scrollIntoViewOffset(r math.Rect) math.Point {
+	view := l.innerSize.Rect().Offset(l.Padding().LT())
+	offset := l.scrollOffset
+	if gxui.ResolveLayoutDirection(l.outer).RightToLeft() {
+		// Horizontal offsets are measured from the right edge.
+		w := l.outer.Size().W
+		r.Min.X, r.Max.X = w-r.Max.X, w-r.Min.X
+	}
+	switch {
+	case r.Min.X < view.Min.X:
+		offset.X -= view.Min.X - r.Min.X
+	case r.Max.X > view.Max.X:
+		offset.X += math.Min(r.Max.X-view.Max.X, r.Min.X-view.Min.X)
+	}
+	switch {
+	case r.Min.Y < view.Min.Y:
+		offset.Y -= view.Min.Y - r.Min.Y
+	case r.Max.Y > view.Max.Y:
+		offset.Y += math.Min(r.Max.Y-view.Max.Y, r.Min.Y-view.Min.Y)
+	}
+	return offset
 }
 
 // InputEventHandler override
 func (l *ScrollLayout) MouseScroll(ev gxui.MouseEvent) (consume bool) {
+	if l.smoothScrolling {
+		delta := math.Vec2{X: -ev.ScrollDeltaX, Y: -ev.ScrollDeltaY}
+		if !l.canScrollY {
+			// Vertical scrolls move layouts that only scroll horizontally.
+			delta = math.Vec2{X: delta.X + delta.Y}
+		}
+		if !l.canScrollX {
+			delta.X = 0
+		}
+		if delta == (math.Vec2{}) {
+			return l.InputEventHandler.MouseScroll(ev)
+		}
+		l.scroller.fling(delta)
+		return true
+	}
 	if ev.ScrollY == 0 {
 		return l.InputEventHandler.MouseScroll(ev)
 	}
@@ -152,3 +229,54 @@ func (l *ScrollLayout) SetScrollAxis(horizontal, vertical bool) {
 func (l *ScrollLayout) ScrollAxis() (horizontal, vertical bool) {
 	return l.canScrollX, l.canScrollY
 }
+
+func (l *ScrollLayout) ScrollOffset() math.Point {
+	return l.scrollOffset
+}
+
+func (l *ScrollLayout) SetScrollOffset(scrollOffset math.Point) bool {
+	scrollOffset = l.clampScrollOffset(scrollOffset)
+	l.scroller.setPosition(scrollOffset)
+	changed := l.scrollOffset != scrollOffset
+	l.setScrollOffset(scrollOffset)
+	return changed
+}
+
+func (l *ScrollLayout) ScrollTo(scrollOffset math.Point) {
+	l.scroller.animateTo(l.clampScrollOffset(scrollOffset))
+}
+
+func (l *ScrollLayout) ScrollIntoView(control gxui.Control) {
+	r := control.Size().Rect().Offset(gxui.ChildToParent(math.ZeroPoint, control, l.outer))
+	offset := l.scrollIntoViewOffset(r)
+	if l.smoothScrolling {
+		l.ScrollTo(offset)
+	} else {
+		l.SetScrollOffset(offset)
+	}
+}
+
+func (l *ScrollLayout) SmoothScrolling() bool {
+	return l.smoothScrolling
+}
+
+func (l *ScrollLayout) SetSmoothScrolling(enabled bool) {
+	l.smoothScrolling = enabled
+}
+
+func (l *ScrollLayout) OverscrollBounce() bool {
+	return l.scroller.bounce
+}
+
+func (l *ScrollLayout) SetOverscrollBounce(enabled bool) {
+	l.scroller.bounce = enabled
+}
+
+func (l *ScrollLayout) ScrollBarAutoHideDelay() time.Duration {
+	return l.scrollBarY.Control.(gxui.ScrollBar).AutoHideDelay()
+}
+
+func (l *ScrollLayout) SetScrollBarAutoHideDelay(delay time.Duration) {
+	l.scrollBarX.Control.(gxui.ScrollBar).SetAutoHideDelay(delay)
+	l.scrollBarY.Control.(gxui.ScrollBar).SetAutoHideDelay(delay)
+}
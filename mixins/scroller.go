@@ -0,0 +1,172 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mixins
+
+import (
+	"time"
+
+	"github.com/google/gxui"
+	"github.com/google/gxui/math"
+)
+
+const (
+	scrollerFrameInterval   = time.Second / 60
+	scrollerAnimationTime   = time.Millisecond * 250
+	scrollerFriction        = 0.92 // Fraction of velocity retained per 60Hz frame
+	scrollerStopSpeed       = 20   // Speed in dips per second considered at rest
+	scrollerMaxOverscroll   = 80   // Maximum distance in dips scrolled past a limit
+	scrollerBounceStiffness = 180
+	scrollerBounceDamping   = 24
+)
+
+type scrollerMode int
+
+const (
+	scrollerIdle scrollerMode = iota
+	scrollerAnimating
+	scrollerKinetic
+)
+
+// scroller drives animated and kinetic scrolling for ScrollLayout and List.
+// All methods must be called on the UI go-routine.
+type scroller struct {
+	driver    gxui.Driver
+	apply     func(offset math.Point)
+	limit     func() math.Vec2
+	mode      scrollerMode
+	bounce    bool
+	position  math.Vec2
+	velocity  math.Vec2
+	from, to  math.Vec2
+	started   time.Time
+	lastFrame time.Time
+	timer     *time.Timer
+}
+
+func (s *scroller) init(driver gxui.Driver, limit func() math.Vec2, apply func(math.Point)) {
+	s.driver = driver
+	s.limit = limit
+	s.apply = apply
+}
+
+func (s *scroller) active() bool {
+	return s.mode != scrollerIdle
+}
+
+// setPosition cancels any active animation, and sets the scroll position
+// without calling apply.
+func (s *scroller) setPosition(p math.Point) {
+	s.stop()
+	s.position = p.Vec2()
+}
+
+// animateTo smoothly scrolls from the current position to p.
+func (s *scroller) animateTo(p math.Point) {
+	to := p.Vec2()
+	if s.mode == scrollerIdle && to == s.position {
+		return
+	}
+	s.mode = scrollerAnimating
+	s.from, s.to = s.position, to
+	s.velocity = math.Vec2{}
+	s.started = time.Now()
+	s.schedule()
+}
+
+// fling adds momentum to the scroll so that, without interference, the
+// position travels approximately delta dips before coming to rest.
+func (s *scroller) fling(delta math.Vec2) {
+	if s.mode != scrollerKinetic {
+		s.mode = scrollerKinetic
+		s.velocity = math.Vec2{}
+	}
+	s.velocity = s.velocity.Add(delta.MulS(60 * (1 - scrollerFriction)))
+	s.schedule()
+}
+
+func (s *scroller) stop() {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	s.mode = scrollerIdle
+	s.velocity = math.Vec2{}
+}
+
+func (s *scroller) schedule() {
+	if s.timer != nil {
+		return
+	}
+	if s.lastFrame.IsZero() || time.Since(s.lastFrame) > scrollerFrameInterval*4 {
+		s.lastFrame = time.Now()
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(scrollerFrameInterval, func() {
+		s.driver.Call(func() {
+			// The timer may have been stopped, or replaced, after this call
+			// was queued.
+			if s.timer != timer {
+				return
+			}
+			s.timer = nil
+			s.step()
+		})
+	})
+	s.timer = timer
+}
+
+func (s *scroller) step() {
+	now := time.Now()
+	dt := float32(now.Sub(s.lastFrame).Seconds())
+	s.lastFrame = now
+
+	done := true
+	switch s.mode {
+	case scrollerIdle:
+		return
+	case scrollerAnimating:
+		t := math.Saturate(float32(now.Sub(s.started)) / float32(scrollerAnimationTime))
+		e := 1 - math.Powf(1-t, 3) // Cubic ease-out
+		s.position = s.from.Add(s.to.Sub(s.from).MulS(e))
+		done = t >= 1
+	case scrollerKinetic:
+		limit := s.limit()
+		s.position = s.position.Add(s.velocity.MulS(dt))
+		s.velocity = s.velocity.MulS(math.Powf(scrollerFriction, dt*60))
+		restX := s.integrateAxis(&s.position.X, &s.velocity.X, limit.X, dt)
+		restY := s.integrateAxis(&s.position.Y, &s.velocity.Y, limit.Y, dt)
+		done = restX && restY
+	}
+
+	if done {
+		s.mode = scrollerIdle
+		s.velocity = math.Vec2{}
+	} else {
+		s.schedule()
+	}
+	s.apply(s.position.Point())
+}
+
+// integrateAxis applies the scroll limits to a single axis of a kinetic
+// scroll, returning true if the axis has come to rest.
+func (s *scroller) integrateAxis(pos, vel *float32, limit float32, dt float32) bool {
+	limit = math.Maxf(limit, 0)
+	edge := math.Clampf(*pos, 0, limit)
+	overscroll := *pos - edge
+	if overscroll == 0 {
+		return math.Absf(*vel) < scrollerStopSpeed
+	}
+	if !s.bounce {
+		*pos, *vel = edge, 0
+		return true
+	}
+	*vel += (-overscroll*scrollerBounceStiffness - *vel*scrollerBounceDamping) * dt
+	*pos = math.Clampf(*pos, -scrollerMaxOverscroll, limit+scrollerMaxOverscroll)
+	if math.Absf(overscroll) < 0.5 && math.Absf(*vel) < scrollerStopSpeed {
+		*pos, *vel = edge, 0
+		return true
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mixins
+
+import (
+	"testing"
+
+	"github.com/google/gxui"
+	"github.com/google/gxui/math"
+	gxtest "github.com/google/gxui/testing"
+)
+
+func TestScrollerIntegrateAxisClampsWithoutBounce(t *testing.T) {
+	s := &scroller{}
+	pos, vel := float32(-10), float32(-300)
+	rest := s.integrateAxis(&pos, &vel, 100, 1.0/60)
+	gxtest.AssertEquals(t, true, rest)
+	gxtest.AssertEquals(t, float32(0), pos)
+	gxtest.AssertEquals(t, float32(0), vel)
+
+	pos, vel = 120, 300
+	s.integrateAxis(&pos, &vel, 100, 1.0/60)
+	gxtest.AssertEquals(t, float32(100), pos)
+}
+
+func TestScrollerIntegrateAxisBounceSpringsBack(t *testing.T) {
+	s := &scroller{bounce: true}
+	pos, vel := float32(-20), float32(0)
+	for i := 0; i < 600; i++ {
+		pos += vel / 60
+		if s.integrateAxis(&pos, &vel, 100, 1.0/60) {
+			break
+		}
+	}
+	gxtest.AssertEquals(t, float32(0), pos)
+	gxtest.AssertEquals(t, float32(0), vel)
+}
+
+func TestScrollerIntegrateAxisLimitsOverscroll(t *testing.T) {
+	s := &scroller{bounce: true}
+	pos, vel := float32(500), float32(0)
+	s.integrateAxis(&pos, &vel, 100, 1.0/60)
+	gxtest.AssertEquals(t, float32(100+scrollerMaxOverscroll), pos)
+}
+
+func TestScrollerIntegrateAxisInRange(t *testing.T) {
+	s := &scroller{}
+	pos, vel := float32(50), float32(1000)
+	gxtest.AssertEquals(t, false, s.integrateAxis(&pos, &vel, 100, 1.0/60))
+	vel = 1
+	gxtest.AssertEquals(t, true, s.integrateAxis(&pos, &vel, 100, 1.0/60))
+}
+
+// testQueueDriver queues the functions passed to Call, to be run by the test.
+type testQueueDriver struct {
+	gxui.Driver
+	calls chan func()
+}
+
+func (d testQueueDriver) Call(f func()) bool {
+	d.calls <- f
+	return true
+}
+
+func TestScrollerIgnoresStaleFrames(t *testing.T) {
+	d := testQueueDriver{calls: make(chan func(), 4)}
+	applied := 0
+	s := &scroller{}
+	s.init(d, func() math.Vec2 { return math.Vec2{Y: 1000} }, func(math.Point) { applied++ })
+
+	s.fling(math.Vec2{Y: 100})
+	stale := <-d.calls
+	s.stop()
+	s.fling(math.Vec2{Y: 100})
+	timer := s.timer
+
+	// The frame queued before stop must not step, or replace the new timer.
+	stale()
+	gxtest.AssertEquals(t, true, s.timer == timer)
+	gxtest.AssertEquals(t, 0, applied)
+
+	(<-d.calls)()
+	gxtest.AssertEquals(t, 1, applied)
+	s.stop()
+}
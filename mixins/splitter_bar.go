@@ -19,7 +19,6 @@ type SplitterBar struct {
 
 	onDrag          func(wndPnt math.Point)
 	outer           SplitterBarOuter
-	theme           gxui.Theme
 	onDragStart     gxui.Event
 	onDragEnd       gxui.Event
 	backgroundColor gxui.Color
@@ -31,7 +30,6 @@ func (b *SplitterBar) Init(outer SplitterBarOuter, theme gxui.Theme) {
 	b.Control.Init(outer, theme)
 
 	b.outer = outer
-	b.theme = theme
 	b.onDragStart = gxui.CreateEvent(func(gxui.MouseEvent) {})
 	b.onDragEnd = gxui.CreateEvent(func(gxui.MouseEvent) {})
 	b.backgroundColor = gxui.Red
@@ -75,18 +73,33 @@ func (b *SplitterBar) Paint(c gxui.Canvas) {
 func (b *SplitterBar) MouseDown(e gxui.MouseEvent) {
 	b.isDragging = true
 	b.onDragStart.Fire(e)
-	var mms, mus gxui.EventSubscription
-	mms = e.Window.OnMouseMove(func(we gxui.MouseEvent) {
-		if b.onDrag != nil {
-			b.onDrag(we.WindowPoint)
-		}
-	})
-	mus = e.Window.OnMouseUp(func(we gxui.MouseEvent) {
-		mms.Unlisten()
-		mus.Unlisten()
-		b.isDragging = false
-		b.onDragEnd.Fire(we)
-	})
-
+	e.Window.CaptureMouse(b.outer)
 	b.InputEventHandler.MouseDown(e)
 }
+
+func (b *SplitterBar) MouseMove(e gxui.MouseEvent) {
+	if b.isDragging && b.onDrag != nil {
+		b.onDrag(e.WindowPoint)
+	}
+	b.InputEventHandler.MouseMove(e)
+}
+
+func (b *SplitterBar) MouseUp(e gxui.MouseEvent) {
+	if b.isDragging {
+		b.endDrag(e)
+		e.Window.ReleaseMouse()
+	}
+	b.InputEventHandler.MouseUp(e)
+}
+
+func (b *SplitterBar) MouseCaptureLost() {
+	if b.isDragging {
+		b.endDrag(gxui.MouseEvent{})
+	}
+	b.InputEventHandler.MouseCaptureLost()
+}
+
+func (b *SplitterBar) endDrag(e gxui.MouseEvent) {
+	b.isDragging = false
+	b.onDragEnd.Fire(e)
+}
@@ -0,0 +1,41 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mixins
+
+import (
+	"testing"
+
+	"github.com/google/gxui"
+	"github.com/google/gxui/math"
+	gxtest "github.com/google/gxui/testing"
+)
+
+func TestSplitterBarDragEndsWhenMouseUpPrevented(t *testing.T) {
+	w, v := createTestWindow(100, 100)
+	b := &SplitterBar{}
+	b.Init(b, testTheme{})
+	w.AddChild(b)
+	w.LayoutChildren()
+
+	dragged := []math.Point{}
+	b.OnSplitterDragged(func(p math.Point) { dragged = append(dragged, p) })
+	w.OnRoutedEvent(gxui.RoutedMouseUp, gxui.TunnelPhase, func(ev *gxui.RoutedEvent) {
+		ev.PreventDefault()
+	})
+
+	v.mouse(w, "mouse-move", math.Point{X: 10, Y: 10})
+	v.mouse(w, "mouse-down", math.Point{X: 10, Y: 10})
+	gxtest.AssertEquals(t, true, b.IsDragging())
+	gxtest.AssertEquals(t, true, w.MouseCapture() == gxui.Control(b))
+
+	// The capture keeps the drag going outside of the bar.
+	v.mouse(w, "mouse-move", math.Point{X: 150, Y: 20})
+	gxtest.AssertEquals(t, []math.Point{{X: 150, Y: 20}}, dragged)
+
+	v.mouse(w, "mouse-up", math.Point{X: 150, Y: 20})
+	gxtest.AssertEquals(t, false, b.IsDragging())
+	gxtest.AssertEquals(t, false, b.IsMouseDown(gxui.MouseButtonLeft))
+	gxtest.AssertEquals(t, nil, w.MouseCapture())
+}
@@ -19,7 +19,6 @@ type SplitterLayout struct {
 	base.Container
 
 	outer         SplitterLayoutOuter
-	theme         gxui.Theme
 	orientation   gxui.Orientation
 	splitterWidth int
 	weights       map[gxui.Control]float32
@@ -28,7 +27,6 @@ type SplitterLayout struct {
 func (l *SplitterLayout) Init(outer SplitterLayoutOuter, theme gxui.Theme) {
 	l.Container.Init(outer, theme)
 	l.outer = outer
-	l.theme = theme
 	l.weights = make(map[gxui.Control]float32)
 	l.splitterWidth = 4
 	l.SetMouseEventTarget(true)
@@ -52,28 +50,42 @@ func (l *SplitterLayout) LayoutChildren() {
 		s.H -= splitterWidth * splitterCount
 	}
 
-	netWeight := float32(0.0)
+	// Gather the weights and size limits of the panes (non-splitter children)
+	// along the major axis.
+	var weights []float32
+	var mins, maxs []int
 	for i, c := range children {
 		if isSplitter := (i & 1) == 1; !isSplitter {
-			netWeight += l.weights[c.Control]
+			cm := c.Control.Margin()
+			lo, hi := gxui.SizeLimits(c.Control, math.ZeroSize, math.MaxSize)
+			if l.orientation.Horizontal() {
+				mins, maxs = append(mins, lo.W+cm.W()), append(maxs, hi.W+cm.W())
+			} else {
+				mins, maxs = append(mins, lo.H+cm.H()), append(maxs, hi.H+cm.H())
+			}
+			weights = append(weights, l.weights[c.Control])
 		}
 	}
+	total := s.W
+	if !l.orientation.Horizontal() {
+		total = s.H
+	}
+	sizes := splitSizes(total, weights, mins, maxs)
 
 	d := 0
 	for i, c := range children {
 		var cr math.Rect
 		if isSplitter := (i & 1) == 1; !isSplitter {
 			cm := c.Control.Margin()
-			frac := l.weights[c.Control] / netWeight
+			size := sizes[i/2]
 			if l.orientation.Horizontal() {
-				cw := int(float32(s.W) * frac)
-				cr = math.CreateRect(d+cm.L, cm.T, d+cw-cm.R, s.H-cm.B)
-				d += cw
+				cr = math.CreateRect(d+cm.L, cm.T, d+size-cm.R, s.H-cm.B)
 			} else {
-				ch := int(float32(s.H) * frac)
-				cr = math.CreateRect(cm.L, d+cm.T, s.W-cm.R, d+ch-cm.B)
-				d += ch
+				cr = math.CreateRect(cm.L, d+cm.T, s.W-cm.R, d+size-cm.B)
 			}
+			cr = cr.Canon()
+			cr = gxui.ConstrainSize(c.Control, cr.Size()).Rect().Offset(cr.Min)
+			d += size
 		} else {
 			if l.orientation.Horizontal() {
 				cr = math.CreateRect(d, 0, d+splitterWidth, s.H)
@@ -84,6 +96,53 @@ func (l *SplitterLayout) LayoutChildren() {
 		}
 		c.Layout(cr.Offset(o).Canon())
 	}
+
+	if gxui.ResolveLayoutDirection(l.outer).RightToLeft() {
+		gxui.MirrorChildren(children, l.outer.Size().W)
+	}
+}
+
+// splitSizes distributes total between the panes in proportion to their
+// weights, while keeping each pane's size within the corresponding min and max
+// limits. Panes that would violate a limit are fixed at that limit, and the
+// remaining space is redistributed between the other panes.
+func splitSizes(total int, weights []float32, mins, maxs []int) []int {
+	sizes := make([]int, len(weights))
+	fixed := make([]bool, len(weights))
+	for {
+		remaining, netWeight := total, float32(0)
+		for i, w := range weights {
+			if fixed[i] {
+				remaining -= sizes[i]
+			} else {
+				netWeight += w
+			}
+		}
+		for i, w := range weights {
+			if !fixed[i] {
+				if netWeight > 0 {
+					sizes[i] = int(float32(remaining) * w / netWeight)
+				} else {
+					sizes[i] = 0
+				}
+			}
+		}
+		changed := false
+		for i := range weights {
+			if fixed[i] {
+				continue
+			}
+			switch {
+			case sizes[i] < mins[i]:
+				sizes[i], fixed[i], changed = mins[i], true, true
+			case sizes[i] > maxs[i] && maxs[i] >= mins[i]:
+				sizes[i], fixed[i], changed = maxs[i], true, true
+			}
+		}
+		if !changed {
+			return sizes
+		}
+	}
 }
 
 func (l *SplitterLayout) ChildWeight(child gxui.Control) float32 {
@@ -108,13 +167,26 @@ func (l *SplitterLayout) Orientation() gxui.Orientation {
 func (l *SplitterLayout) SetOrientation(o gxui.Orientation) {
 	if l.orientation != o {
 		l.orientation = o
+		children := l.Container.Children()
+		for i := 1; i < len(children); i += 2 {
+			children[i].Control.SetCursor(l.splitterCursor())
+		}
 		l.LayoutChildren()
 	}
 }
 
+// splitterCursor returns the cursor shown over the splitter bars, which
+// resize along the major axis of the layout.
+func (l *SplitterLayout) splitterCursor() gxui.Cursor {
+	if l.orientation.Horizontal() {
+		return gxui.CreateCursor(gxui.HResizeCursor)
+	}
+	return gxui.CreateCursor(gxui.VResizeCursor)
+}
+
 func (l *SplitterLayout) CreateSplitterBar() gxui.Control {
 	b := &SplitterBar{}
-	b.Init(b, l.theme)
+	b.Init(b, l.Theme())
 	b.OnSplitterDragged(func(wndPnt math.Point) { l.SplitterDragged(b, wndPnt) })
 	return b
 }
@@ -128,7 +200,15 @@ func (l *SplitterLayout) SplitterDragged(splitter gxui.Control, wndPnt math.Poin
 	boundsA, boundsB := childA.Bounds(), childB.Bounds()
 
 	min, max := o.Major(boundsA.Min.XY()), o.Major(boundsB.Max.XY())
+	mirrored := o.Horizontal() && gxui.ResolveLayoutDirection(l.outer).RightToLeft()
+	if mirrored {
+		// childA is to the right of childB.
+		min, max = boundsB.Min.X, boundsA.Max.X
+	}
 	frac := math.RampSat(float32(o.Major(p.XY())), float32(min), float32(max))
+	if mirrored {
+		frac = 1 - frac
+	}
 
 	netWeight := l.weights[childA.Control] + l.weights[childB.Control]
 	l.weights[childA.Control] = netWeight * frac
@@ -140,7 +220,9 @@ func (l *SplitterLayout) SplitterDragged(splitter gxui.Control, wndPnt math.Poin
 func (l *SplitterLayout) AddChildAt(index int, control gxui.Control) *gxui.Child {
 	l.weights[control] = 1.0
 	if len(l.Container.Children()) > 0 {
-		l.Container.AddChildAt(index, l.outer.CreateSplitterBar())
+		bar := l.outer.CreateSplitterBar()
+		bar.SetCursor(l.splitterCursor())
+		l.Container.AddChildAt(index, bar)
 		index++
 	}
 	return l.Container.AddChildAt(index, control)
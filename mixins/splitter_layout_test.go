@@ -0,0 +1,32 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mixins
+
+import (
+	"testing"
+
+	"github.com/google/gxui/math"
+	gxtest "github.com/google/gxui/testing"
+)
+
+func TestSplitSizesUnconstrained(t *testing.T) {
+	sizes := splitSizes(300, []float32{1, 2}, []int{0, 0}, []int{math.MaxInt, math.MaxInt})
+	gxtest.AssertEquals(t, []int{100, 200}, sizes)
+}
+
+func TestSplitSizesMinimum(t *testing.T) {
+	sizes := splitSizes(300, []float32{1, 1, 1}, []int{150, 0, 0}, []int{math.MaxInt, math.MaxInt, math.MaxInt})
+	gxtest.AssertEquals(t, []int{150, 75, 75}, sizes)
+}
+
+func TestSplitSizesMaximum(t *testing.T) {
+	sizes := splitSizes(300, []float32{1, 1}, []int{0, 0}, []int{50, math.MaxInt})
+	gxtest.AssertEquals(t, []int{50, 250}, sizes)
+}
+
+func TestSplitSizesMinimumBeatsMaximum(t *testing.T) {
+	sizes := splitSizes(300, []float32{1, 1}, []int{200, 0}, []int{100, math.MaxInt})
+	gxtest.AssertEquals(t, []int{200, 100}, sizes)
+}
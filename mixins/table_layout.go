@@ -56,9 +56,18 @@ func (l *TableLayout) LayoutChildren() {
 		x, y := cell.x*cw, cell.y*ch
 		w, h := x+cell.w*cw, y+cell.h*ch
 
-		cr = math.CreateRect(x+cm.L, y+cm.T, w-cm.R, h-cm.B)
+		cr = math.CreateRect(x+cm.L, y+cm.T, w-cm.R, h-cm.B).Canon()
 
-		c.Layout(cr.Offset(o).Canon())
+		// Center the child within the cell if its constraints prevent it
+		// from filling the cell.
+		cs := gxui.ConstrainSize(c.Control, cr.Size())
+		cr = cs.Rect().Offset(cr.Mid().Sub(cs.Rect().Mid()))
+
+		c.Layout(cr.Offset(o))
+	}
+
+	if gxui.ResolveLayoutDirection(l.outer).RightToLeft() {
+		gxui.MirrorChildren(l.outer.Children(), l.outer.Size().W)
 	}
 }
 
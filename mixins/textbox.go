@@ -73,6 +73,7 @@ func (t *TextBox) Init(outer TextBoxOuter, driver gxui.Driver, theme gxui.Theme,
 	t.controller = gxui.CreateTextBoxController()
 	t.adapter = &TextBoxAdapter{TextBox: t}
 	t.desiredWidth = 100
+	t.SetCursor(gxui.CreateCursor(gxui.IBeamCursor))
 	t.SetScrollBarEnabled(false) // Defaults to single line
 	t.OnGainedFocus(func() { t.onRedrawLines.Fire() })
 	t.OnLostFocus(func() { t.onRedrawLines.Fire() })
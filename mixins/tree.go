@@ -104,7 +104,17 @@ func (t *Tree) PaintChild(c gxui.Canvas, child *gxui.Child, idx int) {
 
 // InputEventHandler override
 func (t *Tree) KeyPress(ev gxui.KeyboardEvent) (consume bool) {
-	switch ev.Key {
+	key := ev.Key
+	if gxui.ResolveLayoutDirection(t.outer).RightToLeft() {
+		// Expanders are on the right, so the arrow keys are swapped.
+		switch key {
+		case gxui.KeyLeft:
+			key = gxui.KeyRight
+		case gxui.KeyRight:
+			key = gxui.KeyLeft
+		}
+	}
+	switch key {
 	case gxui.KeyLeft:
 		if item := t.Selected(); item != nil {
 			node := t.listAdapter.DeepestNode(item)
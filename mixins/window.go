@@ -5,6 +5,8 @@
 package mixins
 
 import (
+	"time"
+
 	"github.com/google/gxui"
 	"github.com/google/gxui/math"
 	"github.com/google/gxui/mixins/outer"
@@ -28,19 +30,28 @@ type Window struct {
 	parts.Container
 	parts.Paddable
 	parts.PaintChildren
+	parts.RoutedEventHandler
+	parts.Themeable
 
 	driver             gxui.Driver
 	outer              WindowOuter
 	viewport           gxui.Viewport
 	windowedSize       math.Size
+	layoutDirection    gxui.LayoutDirection
+	cursor             gxui.Cursor
+	keymap             *gxui.Keymap
 	mouseController    *gxui.MouseController
 	keyboardController *gxui.KeyboardController
 	focusController    *gxui.FocusController
+	animator           *gxui.Animator
 	layoutPending      bool
 	drawPending        bool
 	updatePending      bool
+	dirty              math.Rect  // Union of the regions to repaint
+	dirtyAll           bool       // If true, dirty is ignored and the entire window is repainted
 	onClose            gxui.Event // Raised by viewport
 	onResize           gxui.Event // Raised by viewport
+	onScaleChanged     gxui.Event // Raised by viewport
 	onMouseMove        gxui.Event // Raised by viewport
 	onMouseEnter       gxui.Event // Raised by viewport
 	onMouseExit        gxui.Event // Raised by viewport
@@ -77,6 +88,7 @@ func (w *Window) update() {
 	if w.layoutPending {
 		w.layoutPending = false
 		w.drawPending = true
+		w.dirtyAll = true
 		w.outer.LayoutChildren()
 	}
 	if w.drawPending {
@@ -91,11 +103,13 @@ func (w *Window) Init(outer WindowOuter, driver gxui.Driver, width, height int,
 	w.Container.Init(outer)
 	w.Paddable.Init(outer)
 	w.PaintChildren.Init(outer)
+	w.Themeable.Init(outer, nil)
 	w.outer = outer
 	w.driver = driver
 
 	w.onClose = gxui.CreateEvent(func() {})
 	w.onResize = gxui.CreateEvent(func() {})
+	w.onScaleChanged = gxui.CreateEvent(func() {})
 	w.onMouseMove = gxui.CreateEvent(func(gxui.MouseEvent) {})
 	w.onMouseEnter = gxui.CreateEvent(func(gxui.MouseEvent) {})
 	w.onMouseExit = gxui.CreateEvent(func(gxui.MouseEvent) {})
@@ -112,9 +126,12 @@ func (w *Window) Init(outer WindowOuter, driver gxui.Driver, width, height int,
 
 	w.focusController = gxui.CreateFocusController(outer)
 	w.mouseController = gxui.CreateMouseController(outer, w.focusController)
+	w.keymap = gxui.CreateKeymap()
 	w.keyboardController = gxui.CreateKeyboardController(outer)
+	w.animator = gxui.CreateAnimator(outer)
 
 	w.onResize.Listen(func() {
+		w.dirtyAll = true
 		w.outer.LayoutChildren()
 		w.Draw()
 	})
@@ -135,7 +152,12 @@ func (w *Window) Draw() gxui.Canvas {
 		c := w.driver.CreateCanvas(s)
 		w.outer.Paint(c)
 		c.Complete()
-		w.viewport.SetCanvas(c)
+		if w.dirtyAll || w.dirty.Size().Area() == 0 {
+			w.viewport.SetCanvas(c)
+		} else {
+			w.viewport.SetCanvasRegion(c, w.dirty)
+		}
+		w.dirty, w.dirtyAll = math.Rect{}, false
 		return c
 	} else {
 		return nil
@@ -151,8 +173,12 @@ func (w *Window) Paint(c gxui.Canvas) {
 func (w *Window) LayoutChildren() {
 	s := w.Size().Contract(w.Padding()).Max(math.ZeroSize)
 	o := w.Padding().LT()
-	for _, c := range w.outer.Children() {
-		c.Layout(c.Control.DesiredSize(math.ZeroSize, s).Rect().Offset(o))
+	children := w.outer.Children()
+	for _, c := range children {
+		c.Layout(gxui.ConstrainedDesiredSize(c.Control, math.ZeroSize, s).Rect().Offset(o))
+	}
+	if w.layoutDirection.RightToLeft() {
+		gxui.MirrorChildren(children, w.Size().W)
 	}
 }
 
@@ -188,6 +214,48 @@ func (w *Window) SetScale(scale float32) {
 	w.viewport.SetScale(scale)
 }
 
+func (w *Window) LayoutDirection() gxui.LayoutDirection {
+	return w.layoutDirection
+}
+
+func (w *Window) SetLayoutDirection(d gxui.LayoutDirection) {
+	if w.layoutDirection != d {
+		w.layoutDirection = d
+		parts.RelayoutTree(w.outer)
+	}
+}
+
+func (w *Window) Keymap() *gxui.Keymap {
+	return w.keymap
+}
+
+func (w *Window) CaptureMouse(c gxui.Control) {
+	w.mouseController.CaptureMouse(c)
+}
+
+func (w *Window) ReleaseMouse() {
+	w.mouseController.ReleaseMouse()
+}
+
+func (w *Window) MouseCapture() gxui.Control {
+	return w.mouseController.MouseCapture()
+}
+
+func (w *Window) Cursor() gxui.Cursor {
+	return w.cursor
+}
+
+func (w *Window) SetCursor(cursor gxui.Cursor) {
+	if w.cursor != cursor {
+		w.cursor = cursor
+		w.UpdateCursor()
+	}
+}
+
+func (w *Window) UpdateCursor() {
+	w.mouseController.UpdateCursor()
+}
+
 func (w *Window) Position() math.Point {
 	return w.viewport.Position()
 }
@@ -230,6 +298,14 @@ func (w *Window) Close() {
 	w.viewport.Close()
 }
 
+func (w *Window) RequestFrame(f func(time.Time)) {
+	w.viewport.RequestFrame(f)
+}
+
+func (w *Window) Animator() *gxui.Animator {
+	return w.animator
+}
+
 func (w *Window) Focus() gxui.Focusable {
 	return w.focusController.Focus()
 }
@@ -259,6 +335,10 @@ func (w *Window) OnResize(f func()) gxui.EventSubscription {
 	return w.onResize.Listen(f)
 }
 
+func (w *Window) OnScaleChanged(f func()) gxui.EventSubscription {
+	return w.onScaleChanged.Listen(f)
+}
+
 func (w *Window) OnClick(f func(gxui.MouseEvent)) gxui.EventSubscription {
 	return w.onClick.Listen(f)
 }
@@ -336,7 +416,34 @@ func (w *Window) Relayout() {
 	w.requestUpdate()
 }
 
+// SetTheme sets the theme of the window and of every control in the window.
+func (w *Window) SetTheme(theme gxui.Theme) {
+	w.Themeable.SetTheme(theme)
+	for _, c := range w.Children() {
+		c.Control.SetTheme(theme)
+	}
+}
+
 func (w *Window) Redraw() {
+	w.dirtyAll = true
+	w.drawPending = true
+	w.requestUpdate()
+}
+
+// RedrawRegion requests a redraw of the window, where only the rectangle r is
+// repainted on the viewport. Multiple calls to RedrawRegion before the window
+// is drawn are combined into a single region.
+func (w *Window) RedrawRegion(r math.Rect) {
+	s := w.Size()
+	r = math.Rect{Min: r.Min.Max(math.ZeroPoint), Max: r.Max.Min(s.Point())}
+	if r.W() <= 0 || r.H() <= 0 {
+		return
+	}
+	if w.dirty.Size().Area() == 0 {
+		w.dirty = r
+	} else {
+		w.dirty = w.dirty.Union(r)
+	}
 	w.drawPending = true
 	w.requestUpdate()
 }
@@ -368,6 +475,7 @@ func (w *Window) setViewport(v gxui.Viewport) {
 	w.viewportSubscriptions = []gxui.EventSubscription{
 		v.OnClose(func() { w.onClose.Fire() }),
 		v.OnResize(func() { w.onResize.Fire() }),
+		v.OnScaleChanged(func() { w.onScaleChanged.Fire() }),
 		v.OnMouseMove(func(ev gxui.MouseEvent) { w.onMouseMove.Fire(ev) }),
 		v.OnMouseEnter(func(ev gxui.MouseEvent) { w.onMouseEnter.Fire(ev) }),
 		v.OnMouseExit(func(ev gxui.MouseEvent) { w.onMouseExit.Fire(ev) }),
@@ -0,0 +1,182 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mixins
+
+import (
+	"testing"
+
+	"github.com/google/gxui"
+	"github.com/google/gxui/math"
+	gxtest "github.com/google/gxui/testing"
+)
+
+// testViewport is a viewport that is never displayed. Its input events are
+// fired by the tests.
+type testViewport struct {
+	gxui.Viewport
+	size   math.Size
+	title  string
+	events map[string]gxui.Event
+	canvas gxui.Canvas // The canvas last set with SetCanvas or SetCanvasRegion
+	cursor gxui.Cursor // The cursor last set with SetCursor
+	dirty  math.Rect   // The region passed to SetCanvasRegion, or the entire viewport
+}
+
+func createTestViewport(size math.Size, title string) *testViewport {
+	mouse := func() gxui.Event { return gxui.CreateEvent(func(gxui.MouseEvent) {}) }
+	key := func() gxui.Event { return gxui.CreateEvent(func(gxui.KeyboardEvent) {}) }
+	return &testViewport{
+		size:  size,
+		title: title,
+		events: map[string]gxui.Event{
+			"close":       gxui.CreateEvent(func() {}),
+			"resize":      gxui.CreateEvent(func() {}),
+			"scale":       gxui.CreateEvent(func() {}),
+			"mouse-move":  mouse(),
+			"mouse-enter": mouse(),
+			"mouse-exit":  mouse(),
+			"mouse-down":  mouse(),
+			"mouse-up":    mouse(),
+			"scroll":      mouse(),
+			"key-down":    key(),
+			"key-up":      key(),
+			"key-repeat":  key(),
+			"key-stroke":  gxui.CreateEvent(func(gxui.KeyStrokeEvent) {}),
+		},
+	}
+}
+
+func (v *testViewport) SizeDips() math.Size     { return v.size }
+func (v *testViewport) SetSizeDips(s math.Size) { v.size = s }
+func (v *testViewport) Title() string           { return v.title }
+func (v *testViewport) SetTitle(t string)       { v.title = t }
+func (v *testViewport) Scale() float32          { return 1 }
+func (v *testViewport) SetCursor(c gxui.Cursor) { v.cursor = c }
+func (v *testViewport) SetCanvas(c gxui.Canvas) {
+	v.canvas, v.dirty = c, v.size.Rect()
+}
+func (v *testViewport) SetCanvasRegion(c gxui.Canvas, dirty math.Rect) {
+	v.canvas, v.dirty = c, dirty
+}
+func (v *testViewport) listen(name string, f interface{}) gxui.EventSubscription {
+	return v.events[name].Listen(f)
+}
+
+func (v *testViewport) OnClose(f func()) gxui.EventSubscription        { return v.listen("close", f) }
+func (v *testViewport) OnResize(f func()) gxui.EventSubscription       { return v.listen("resize", f) }
+func (v *testViewport) OnScaleChanged(f func()) gxui.EventSubscription { return v.listen("scale", f) }
+func (v *testViewport) OnMouseMove(f func(gxui.MouseEvent)) gxui.EventSubscription {
+	return v.listen("mouse-move", f)
+}
+func (v *testViewport) OnMouseEnter(f func(gxui.MouseEvent)) gxui.EventSubscription {
+	return v.listen("mouse-enter", f)
+}
+func (v *testViewport) OnMouseExit(f func(gxui.MouseEvent)) gxui.EventSubscription {
+	return v.listen("mouse-exit", f)
+}
+func (v *testViewport) OnMouseDown(f func(gxui.MouseEvent)) gxui.EventSubscription {
+	return v.listen("mouse-down", f)
+}
+func (v *testViewport) OnMouseUp(f func(gxui.MouseEvent)) gxui.EventSubscription {
+	return v.listen("mouse-up", f)
+}
+func (v *testViewport) OnMouseScroll(f func(gxui.MouseEvent)) gxui.EventSubscription {
+	return v.listen("scroll", f)
+}
+func (v *testViewport) OnKeyDown(f func(gxui.KeyboardEvent)) gxui.EventSubscription {
+	return v.listen("key-down", f)
+}
+func (v *testViewport) OnKeyUp(f func(gxui.KeyboardEvent)) gxui.EventSubscription {
+	return v.listen("key-up", f)
+}
+func (v *testViewport) OnKeyRepeat(f func(gxui.KeyboardEvent)) gxui.EventSubscription {
+	return v.listen("key-repeat", f)
+}
+func (v *testViewport) OnKeyStroke(f func(gxui.KeyStrokeEvent)) gxui.EventSubscription {
+	return v.listen("key-stroke", f)
+}
+
+// CreateWindowedViewport returns a testViewport.
+func (testDriver) CreateWindowedViewport(width, height int, name string) gxui.Viewport {
+	return createTestViewport(math.Size{W: width, H: height}, name)
+}
+
+// CreateCanvas returns a display list, so tests can inspect what was painted.
+func (testDriver) CreateCanvas(s math.Size) gxui.Canvas {
+	return gxui.CreateDisplayList(s)
+}
+
+// Call drops f. Windows queue their layout and drawing with Call, so tests
+// lay out windows by calling LayoutChildren.
+func (testDriver) Call(f func()) bool { return false }
+
+func createTestWindow(width, height int) (*Window, *testViewport) {
+	w := &Window{}
+	w.Init(w, testDriver{}, width, height, "test")
+	return w, w.Viewport().(*testViewport)
+}
+
+// mouse fires the viewport's mouse event of the kind at the window point p.
+func (v *testViewport) mouse(w *Window, kind string, p math.Point) {
+	v.events[kind].Fire(gxui.MouseEvent{
+		Button:      gxui.MouseButtonLeft,
+		Point:       p,
+		WindowPoint: p,
+		Window:      w,
+	})
+}
+
+func TestWindowRedrawRegion(t *testing.T) {
+	w, v := createTestWindow(100, 100)
+	w.Draw()
+
+	w.RedrawRegion(math.CreateRect(10, 10, 20, 20))
+	w.RedrawRegion(math.CreateRect(50, 90, 120, 130))
+	w.RedrawRegion(math.CreateRect(-10, -10, 0, 0))
+	w.Draw()
+	gxtest.AssertEquals(t, math.CreateRect(10, 10, 100, 100), v.dirty)
+
+	// Drawing resets the region, and a full redraw takes precedence.
+	w.RedrawRegion(math.CreateRect(10, 10, 20, 20))
+	w.Redraw()
+	w.Draw()
+	gxtest.AssertEquals(t, math.CreateRect(0, 0, 100, 100), v.dirty)
+}
+
+func TestControlRedrawRegion(t *testing.T) {
+	w, v := createTestWindow(100, 100)
+	w.SetPadding(math.Spacing{L: 10, T: 20})
+	b := &SplitterBar{}
+	b.Init(b, testTheme{})
+	w.AddChild(b)
+	w.LayoutChildren()
+	w.Draw()
+
+	b.RedrawRegion(math.CreateRect(0, 0, 5, 5))
+	w.Draw()
+	gxtest.AssertEquals(t, math.CreateRect(10, 20, 15, 25), v.dirty)
+}
+
+func TestControlCursorChangeUpdatesViewport(t *testing.T) {
+	w, v := createTestWindow(100, 100)
+	b := &SplitterBar{}
+	b.Init(b, testTheme{})
+	w.AddChild(b)
+	w.LayoutChildren()
+	v.mouse(w, "mouse-move", math.Point{X: 10, Y: 10})
+
+	hand := gxui.CreateCursor(gxui.HandCursor)
+	b.SetCursor(hand)
+	gxtest.AssertEquals(t, hand, v.cursor)
+
+	arrow := gxui.CreateCursor(gxui.ArrowCursor)
+	b.SetCursor(gxui.CreateCursor(gxui.DefaultCursor))
+	gxtest.AssertEquals(t, arrow, v.cursor)
+
+	// Detached controls do not change the cursor.
+	w.RemoveChild(b)
+	b.SetCursor(hand)
+	gxtest.AssertEquals(t, arrow, v.cursor)
+}
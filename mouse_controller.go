@@ -10,12 +10,25 @@ import (
 
 var doubleClickTime = time.Millisecond * 300
 
+// MouseController delivers the mouse events of a window to the enabled
+// controls under the mouse pointer. Each event is first dispatched as a
+// RoutedEvent from the window to the top-most control and back, and is only
+// delivered to the controls if its default action is not prevented.
+//
+// While a control has captured the mouse with CaptureMouse, the events are
+// delivered to the capturing control and its ancestors wherever the mouse
+// pointer is.
 type MouseController struct {
-	window          Window
-	focusController *FocusController
-	lastOver        ControlPointList
-	lastDown        map[MouseButton]ControlPointList
-	lastUpTime      map[MouseButton]time.Time
+	window               Window
+	focusController      *FocusController
+	lastOver             ControlPointList
+	lastDown             map[MouseButton]ControlPointList
+	lastUpTime           map[MouseButton]time.Time
+	lastPosition         *MouseEvent
+	cursor               Cursor
+	cursorViewport       Viewport
+	capture              Control
+	captureSubscriptions []EventSubscription
 }
 
 func CreateMouseController(w Window, focusController *FocusController) *MouseController {
@@ -34,10 +47,86 @@ func CreateMouseController(w Window, focusController *FocusController) *MouseCon
 	return c
 }
 
+// CaptureMouse routes all mouse events of the window to c and its ancestors,
+// as if the mouse pointer were over c, until the capture is released. If
+// another control has captured the mouse then it loses the capture.
+// The capture is released when c is detached or disabled.
+func (m *MouseController) CaptureMouse(c Control) {
+	if c == nil {
+		panic("Cannot capture the mouse with a nil control")
+	}
+	if m.capture == c {
+		return
+	}
+	m.ReleaseMouse()
+	m.capture = c
+	m.captureSubscriptions = []EventSubscription{
+		c.OnDetach(m.ReleaseMouse),
+		c.OnEnabledChanged(func() {
+			if !c.IsEnabled() {
+				m.ReleaseMouse()
+			}
+		}),
+	}
+	if m.lastPosition != nil {
+		m.updatePosition(*m.lastPosition)
+	}
+}
+
+// ReleaseMouse releases the capture of the mouse, calling MouseCaptureLost on
+// the control that had captured it. Mouse events are once again delivered to
+// the controls under the mouse pointer.
+func (m *MouseController) ReleaseMouse() {
+	c := m.capture
+	if c == nil {
+		return
+	}
+	for _, s := range m.captureSubscriptions {
+		s.Unlisten()
+	}
+	m.capture, m.captureSubscriptions = nil, nil
+	if m.lastPosition != nil {
+		m.updatePosition(*m.lastPosition)
+	}
+	c.MouseCaptureLost()
+}
+
+// MouseCapture returns the control that has captured the mouse, or nil if the
+// mouse is not captured.
+func (m *MouseController) MouseCapture() Control {
+	return m.capture
+}
+
+// captured returns the control that has captured the mouse and its ancestors,
+// with the position of the mouse pointer local to each.
+func (m *MouseController) captured(ev MouseEvent) ControlPointList {
+	l := ControlPointList{}
+	for c := m.capture; c != nil; c, _ = c.Parent().(Control) {
+		l = append(ControlPointList{{c, WindowToChild(ev.WindowPoint, c)}}, l...)
+	}
+	return l
+}
+
 func (m *MouseController) updatePosition(ev MouseEvent) {
 	ValidateHierarchy(m.window)
+	m.lastPosition = &ev
 
-	nowOver := TopControlsUnder(ev.Point, m.window)
+	var nowOver ControlPointList
+	if m.capture != nil {
+		nowOver = m.captured(ev)
+	} else {
+		nowOver = TopControlsUnder(ev.Point, m.window)
+	}
+
+	// Disabled controls do not receive mouse events. As disabling a control
+	// disables its descendants, the disabled controls are at the end of the
+	// list.
+	for i, cp := range nowOver {
+		if !cp.C.IsEnabled() {
+			nowOver = nowOver[:i]
+			break
+		}
+	}
 
 	for _, cp := range m.lastOver {
 		if !nowOver.Contains(cp.C) {
@@ -56,10 +145,40 @@ func (m *MouseController) updatePosition(ev MouseEvent) {
 	}
 
 	m.lastOver = nowOver
+	m.UpdateCursor()
+}
+
+// UpdateCursor sets the cursor of the window's viewport to the cursor of the
+// top-most control under the mouse pointer that sets a cursor, or to the
+// window's cursor if there is none.
+// UpdateCursor is called whenever the mouse moves, and by Window.UpdateCursor
+// when the cursor of the window or of an attached control changes.
+func (m *MouseController) UpdateCursor() {
+	cursor := m.lastOver.Cursor(m.window.Cursor())
+	viewport := m.window.Viewport()
+	if viewport != nil && (m.cursor != cursor || m.cursorViewport != viewport) {
+		m.cursor, m.cursorViewport = cursor, viewport
+		viewport.SetCursor(cursor)
+	}
+}
+
+// dispatch dispatches a routed event of the kind along the route from the
+// window through the controls of l, returning true if the default action
+// should be performed.
+func (m *MouseController) dispatch(kind RoutedEventKind, ev MouseEvent, l ControlPointList) bool {
+	route := make(Route, 0, len(l)+1)
+	route = append(route, RouteStep{m.window, ev.WindowPoint})
+	for _, cp := range l {
+		route = append(route, RouteStep{cp.C, cp.P})
+	}
+	return route.Dispatch(&RoutedEvent{Kind: kind, Mouse: ev})
 }
 
 func (m *MouseController) mouseMove(ev MouseEvent) {
 	m.updatePosition(ev)
+	if !m.dispatch(RoutedMouseMove, ev, m.lastOver) {
+		return
+	}
 	for _, cp := range m.lastOver {
 		e := ev
 		e.Point = cp.P
@@ -69,6 +188,9 @@ func (m *MouseController) mouseMove(ev MouseEvent) {
 
 func (m *MouseController) mouseDown(ev MouseEvent) {
 	m.updatePosition(ev)
+	if !m.dispatch(RoutedMouseDown, ev, m.lastOver) {
+		return
+	}
 
 	for _, cp := range m.lastOver {
 		e := ev
@@ -81,18 +203,40 @@ func (m *MouseController) mouseDown(ev MouseEvent) {
 
 func (m *MouseController) mouseUp(ev MouseEvent) {
 	m.updatePosition(ev)
+	doDefault := m.dispatch(RoutedMouseUp, ev, m.lastOver)
 
+	// The controls that received the mouse-down always receive the mouse-up,
+	// so they can end any press or drag. Preventing the default only skips
+	// the click and focus handling.
 	for _, cp := range m.lastDown[ev.Button] {
 		e := ev
 		e.Point = cp.P
 		cp.C.MouseUp(e)
 	}
 
+	if !doDefault {
+		delete(m.lastDown, ev.Button)
+		return
+	}
+
 	setFocusCount := m.focusController.SetFocusCount()
 
 	dblClick := time.Since(m.lastUpTime[ev.Button]) < doubleClickTime
-	clickConsumed := false
-	for i := len(m.lastDown[ev.Button]) - 1; i >= 0; i-- {
+
+	// The click is routed through the controls that were both pressed and
+	// released on.
+	clicked := ControlPointList{}
+	for _, cp := range m.lastOver {
+		if m.lastDown[ev.Button].Contains(cp.C) {
+			clicked = append(clicked, cp)
+		}
+	}
+	kind := RoutedClick
+	if dblClick {
+		kind = RoutedDoubleClick
+	}
+	clickConsumed := !m.dispatch(kind, ev, clicked)
+	for i := len(m.lastDown[ev.Button]) - 1; i >= 0 && !clickConsumed; i-- {
 		cp := m.lastDown[ev.Button][i]
 		if p, found := m.lastOver.Find(cp.C); found {
 			ev.Point = p
@@ -133,6 +277,9 @@ func (m *MouseController) mouseUp(ev MouseEvent) {
 
 func (m *MouseController) mouseScroll(ev MouseEvent) {
 	m.updatePosition(ev)
+	if !m.dispatch(RoutedMouseScroll, ev, m.lastOver) {
+		return
+	}
 
 	for i := len(m.lastOver) - 1; i >= 0; i-- {
 		cp := m.lastOver[i]
@@ -0,0 +1,157 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/gxui/math"
+	test "github.com/google/gxui/testing"
+)
+
+type testMouseWindow struct {
+	Window
+	children Children
+	handlers map[string]func(MouseEvent)
+}
+
+func (w *testMouseWindow) listen(name string, f func(MouseEvent)) EventSubscription {
+	w.handlers[name] = f
+	return nil
+}
+
+func (w *testMouseWindow) Children() Children             { return w.children }
+func (w *testMouseWindow) Cursor() Cursor                 { return Cursor{} }
+func (w *testMouseWindow) Viewport() Viewport             { return nil }
+func (w *testMouseWindow) HandleRoutedEvent(*RoutedEvent) {}
+func (w *testMouseWindow) OnMouseMove(f func(MouseEvent)) EventSubscription {
+	return w.listen("move", f)
+}
+func (w *testMouseWindow) OnMouseEnter(f func(MouseEvent)) EventSubscription {
+	return w.listen("enter", f)
+}
+func (w *testMouseWindow) OnMouseExit(f func(MouseEvent)) EventSubscription {
+	return w.listen("exit", f)
+}
+func (w *testMouseWindow) OnMouseDown(f func(MouseEvent)) EventSubscription {
+	return w.listen("down", f)
+}
+func (w *testMouseWindow) OnMouseUp(f func(MouseEvent)) EventSubscription {
+	return w.listen("up", f)
+}
+func (w *testMouseWindow) OnMouseScroll(f func(MouseEvent)) EventSubscription {
+	return w.listen("scroll", f)
+}
+
+type testMouseControl struct {
+	Control
+	name     string
+	parent   Parent
+	size     math.Size
+	log      *[]string
+	disabled bool
+	onDetach Event
+}
+
+func (c *testMouseControl) record(s string, p math.Point) {
+	*c.log = append(*c.log, fmt.Sprintf("%s %s %d,%d", c.name, s, p.X, p.Y))
+}
+
+func (c *testMouseControl) Parent() Parent                      { return c.parent }
+func (c *testMouseControl) IsEnabled() bool                     { return !c.disabled }
+func (c *testMouseControl) Cursor() Cursor                      { return Cursor{} }
+func (c *testMouseControl) ContainsPoint(p math.Point) bool     { return c.size.Rect().Contains(p) }
+func (c *testMouseControl) HandleRoutedEvent(*RoutedEvent)      {}
+func (c *testMouseControl) MouseEnter(ev MouseEvent)            { c.record("enter", ev.Point) }
+func (c *testMouseControl) MouseExit(ev MouseEvent)             { c.record("exit", ev.Point) }
+func (c *testMouseControl) MouseMove(ev MouseEvent)             { c.record("move", ev.Point) }
+func (c *testMouseControl) MouseDown(ev MouseEvent)             { c.record("down", ev.Point) }
+func (c *testMouseControl) MouseCaptureLost()                   { c.record("lost", math.Point{}) }
+func (c *testMouseControl) OnDetach(f func()) EventSubscription { return c.onDetach.Listen(f) }
+func (c *testMouseControl) OnEnabledChanged(f func()) EventSubscription {
+	return CreateEvent(func() {}).Listen(f)
+}
+
+func createTestMouseWindow() *testMouseWindow {
+	return &testMouseWindow{handlers: map[string]func(MouseEvent){}}
+}
+
+// add appends a new 10x10 control at x to the children of the window.
+func (w *testMouseWindow) add(name string, x int, log *[]string) *testMouseControl {
+	c := &testMouseControl{
+		name:     name,
+		parent:   w,
+		size:     math.Size{W: 10, H: 10},
+		log:      log,
+		onDetach: CreateEvent(func() {}),
+	}
+	w.children = append(w.children, &Child{Control: c, Offset: math.Point{X: x}})
+	return c
+}
+
+func (w *testMouseWindow) at(x int) MouseEvent {
+	p := math.Point{X: x, Y: 5}
+	return MouseEvent{Point: p, WindowPoint: p, Window: w}
+}
+
+func TestMouseCapture(t *testing.T) {
+	log := []string{}
+	window := createTestMouseWindow()
+	a, b := window.add("a", 0, &log), window.add("b", 20, &log)
+	m := CreateMouseController(window, nil)
+	at := window.at
+
+	window.handlers["move"](at(5))
+	m.CaptureMouse(a)
+	window.handlers["move"](at(25))
+	test.AssertEquals(t, []string{
+		"a enter 5,5",
+		"a move 5,5",
+		"a move 25,5",
+	}, log)
+	test.AssertEquals(t, true, m.MouseCapture() == a)
+
+	// Releasing the capture restores delivery to the controls under the
+	// mouse pointer.
+	log = log[:0]
+	m.ReleaseMouse()
+	window.handlers["move"](at(26))
+	test.AssertEquals(t, []string{
+		"a exit 25,5",
+		"b enter 5,5",
+		"a lost 0,0",
+		"b move 6,5",
+	}, log)
+
+	// Detaching the capturing control releases the capture.
+	log = log[:0]
+	m.CaptureMouse(b)
+	b.onDetach.Fire()
+	test.AssertEquals(t, []string{"b lost 0,0"}, log)
+	test.AssertEquals(t, nil, m.MouseCapture())
+}
+
+func TestMouseSkipsDisabled(t *testing.T) {
+	log := []string{}
+	window := createTestMouseWindow()
+	a := window.add("a", 0, &log)
+	CreateMouseController(window, nil)
+	move := window.handlers["move"]
+
+	a.disabled = true
+	move(window.at(5))
+	test.AssertEquals(t, []string{}, log)
+
+	a.disabled = false
+	move(window.at(6))
+	test.AssertEquals(t, []string{"a enter 6,5", "a move 6,5"}, log)
+
+	// Disabled controls are exited as if the mouse pointer had left them.
+	log = log[:0]
+	a.disabled = true
+	move(window.at(7))
+	test.AssertEquals(t, []string{"a exit 6,5"}, log)
+}
@@ -15,5 +15,9 @@ type MouseEvent struct {
 	WindowPoint      math.Point
 	Window           Window
 	ScrollX, ScrollY int
-	Modifier         KeyboardModifier
+	// ScrollDeltaX and ScrollDeltaY hold the unquantized scroll amounts. High
+	// precision devices such as trackpads may report fractional deltas that
+	// are not reflected in ScrollX and ScrollY.
+	ScrollDeltaX, ScrollDeltaY float32
+	Modifier                   KeyboardModifier
 }
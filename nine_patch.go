@@ -0,0 +1,85 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"github.com/google/gxui/math"
+)
+
+// PatchMode describes how the edges and center of a NinePatch fill the space
+// between the corners.
+type PatchMode int
+
+const (
+	// PatchStretch stretches the texture to fill the space.
+	PatchStretch PatchMode = iota
+	// PatchTile repeats the texture at its natural size to fill the space.
+	PatchTile
+)
+
+// NinePatch draws a texture into a rectangle of any size by splitting it into
+// a 3x3 grid. The four corners are drawn at their natural size, the edges are
+// stretched or tiled along one axis and the center is stretched or tiled along
+// both.
+type NinePatch struct {
+	Texture Texture
+
+	// Insets are the sizes of the corners and edges, in the texture's DIPs.
+	Insets math.Spacing
+
+	Edges  PatchMode
+	Center PatchMode
+}
+
+func CreateNinePatch(texture Texture, insets math.Spacing) NinePatch {
+	return NinePatch{
+		Texture: texture,
+		Insets:  insets,
+	}
+}
+
+// Patches returns the source rectangles in the texture, in DIPs, and the
+// destination rectangles when drawing into r, for each of the nine patches
+// in row-major order. If r is smaller than the corners then the corners are
+// scaled down proportionally.
+func (n NinePatch) Patches(r math.Rect) (src, dst [9]math.Rect) {
+	s := n.Texture.Size()
+	i := n.Insets
+	xs := [4]int{0, i.L, s.W - i.R, s.W}
+	ys := [4]int{0, i.T, s.H - i.B, s.H}
+
+	shrink := func(a, b, size int) (int, int) {
+		if a+b > size && a+b > 0 {
+			a = a * size / (a + b)
+			b = size - a
+		}
+		return a, b
+	}
+	l, rr := shrink(i.L, i.R, r.W())
+	t, b := shrink(i.T, i.B, r.H())
+	xd := [4]int{r.Min.X, r.Min.X + l, r.Max.X - rr, r.Max.X}
+	yd := [4]int{r.Min.Y, r.Min.Y + t, r.Max.Y - b, r.Max.Y}
+
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 3; x++ {
+			src[y*3+x] = math.CreateRect(xs[x], ys[y], xs[x+1], ys[y+1])
+			dst[y*3+x] = math.CreateRect(xd[x], yd[y], xd[x+1], yd[y+1])
+		}
+	}
+	return src, dst
+}
+
+// PatchMode returns the mode for the patch at index i in the order returned
+// by Patches.
+func (n NinePatch) PatchMode(i int) PatchMode {
+	switch i {
+	case 0, 2, 6, 8:
+		return PatchStretch // Corners are drawn at (or scaled to) their natural size
+	case 4:
+		return n.Center
+	default:
+		return n.Edges
+	}
+}
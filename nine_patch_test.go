@@ -0,0 +1,39 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"image"
+	"testing"
+
+	"github.com/google/gxui/math"
+	test "github.com/google/gxui/testing"
+)
+
+type testTexture struct{ size math.Size }
+
+func (t testTexture) Image() image.Image    { return nil }
+func (t testTexture) Size() math.Size       { return t.size }
+func (t testTexture) SizePixels() math.Size { return t.size }
+func (t testTexture) FlipY() bool           { return false }
+func (t testTexture) SetFlipY(bool)         {}
+
+func TestNinePatchPatches(t *testing.T) {
+	n := CreateNinePatch(testTexture{math.Size{W: 30, H: 30}}, math.CreateSpacing(10))
+	src, dst := n.Patches(math.CreateRect(100, 100, 200, 150))
+	test.AssertEquals(t, math.CreateRect(0, 0, 10, 10), src[0])
+	test.AssertEquals(t, math.CreateRect(10, 10, 20, 20), src[4])
+	test.AssertEquals(t, math.CreateRect(100, 100, 110, 110), dst[0])
+	test.AssertEquals(t, math.CreateRect(110, 110, 190, 140), dst[4])
+	test.AssertEquals(t, math.CreateRect(190, 140, 200, 150), dst[8])
+}
+
+func TestNinePatchPatchesShrink(t *testing.T) {
+	n := CreateNinePatch(testTexture{math.Size{W: 30, H: 30}}, math.CreateSpacing(10))
+	_, dst := n.Patches(math.CreateRect(0, 0, 10, 40))
+	test.AssertEquals(t, math.CreateRect(0, 0, 5, 10), dst[0])
+	test.AssertEquals(t, math.CreateRect(5, 0, 5, 10), dst[1])
+	test.AssertEquals(t, math.CreateRect(5, 30, 10, 40), dst[8])
+}
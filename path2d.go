@@ -0,0 +1,101 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"github.com/google/gxui/math"
+)
+
+// FillRule determines which regions of a Path2D are filled when its contours
+// overlap or intersect themselves.
+type FillRule int
+
+const (
+	// NonZeroFill fills regions with a non-zero winding number. Holes are
+	// made by contours that wind in the opposite direction.
+	NonZeroFill FillRule = iota
+	// EvenOddFill fills regions that are enclosed by an odd number of
+	// contours. Holes are made by any nested contour.
+	EvenOddFill
+)
+
+// PathCommand is the type of a PathSegment.
+type PathCommand int
+
+const (
+	PathMoveTo PathCommand = iota
+	PathLineTo
+	PathQuadTo
+	PathCubicTo
+	PathArc
+	PathClose
+)
+
+// PathSegment is a single drawing command of a Path2D.
+type PathSegment struct {
+	Command PathCommand
+	// Points holds the command's points:
+	//   PathMoveTo, PathLineTo: [0] is the end point.
+	//   PathQuadTo:             [0] is the control point, [1] the end point.
+	//   PathCubicTo:            [0] and [1] are the control points, [2] the end point.
+	//   PathArc:                [0] is the center, [1] the X and Y radii.
+	Points [3]math.Vec2
+	// Start and Sweep are the start angle and the sweep of a PathArc, in
+	// radians.
+	Start, Sweep float32
+}
+
+// Path2D is a shape built from lines, Bézier curves and elliptical arcs, split
+// into one or more contours. All coordinates are in DIPs. The zero value is
+// an empty path using the NonZeroFill rule.
+type Path2D struct {
+	FillRule FillRule
+	Segments []PathSegment
+}
+
+// MoveTo begins a new contour at the point to.
+func (p *Path2D) MoveTo(to math.Vec2) {
+	p.Segments = append(p.Segments, PathSegment{Command: PathMoveTo, Points: [3]math.Vec2{to}})
+}
+
+// LineTo adds a straight line from the current point to the point to.
+func (p *Path2D) LineTo(to math.Vec2) {
+	p.Segments = append(p.Segments, PathSegment{Command: PathLineTo, Points: [3]math.Vec2{to}})
+}
+
+// QuadTo adds a quadratic Bézier curve from the current point to the point to,
+// using the control point c.
+func (p *Path2D) QuadTo(c, to math.Vec2) {
+	p.Segments = append(p.Segments, PathSegment{Command: PathQuadTo, Points: [3]math.Vec2{c, to}})
+}
+
+// CubicTo adds a cubic Bézier curve from the current point to the point to,
+// using the control points c0 and c1.
+func (p *Path2D) CubicTo(c0, c1, to math.Vec2) {
+	p.Segments = append(p.Segments, PathSegment{Command: PathCubicTo, Points: [3]math.Vec2{c0, c1, to}})
+}
+
+// Arc adds an elliptical arc with the given center and X and Y radii,
+// starting at the angle start and sweeping by sweep radians. Positive sweeps
+// are clockwise. If the path has a current point, a straight line joins it to
+// the start of the arc.
+func (p *Path2D) Arc(center, radii math.Vec2, start, sweep float32) {
+	p.Segments = append(p.Segments, PathSegment{
+		Command: PathArc,
+		Points:  [3]math.Vec2{center, radii},
+		Start:   start,
+		Sweep:   sweep,
+	})
+}
+
+// Close closes the current contour with a straight line back to its start.
+func (p *Path2D) Close() {
+	p.Segments = append(p.Segments, PathSegment{Command: PathClose})
+}
+
+// IsEmpty returns true if the path has no segments.
+func (p *Path2D) IsEmpty() bool {
+	return len(p.Segments) == 0
+}
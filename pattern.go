@@ -0,0 +1,23 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"github.com/google/gxui/math"
+)
+
+// Pattern fills shapes by repeating a texture at its natural size.
+type Pattern struct {
+	Texture Texture
+
+	// Offset is the position of a tile's top-left corner, in the DIPs of the
+	// filled shape.
+	Offset math.Point
+}
+
+// CreatePatternBrush returns a Brush that fills by repeating the texture.
+func CreatePatternBrush(texture Texture) Brush {
+	return Brush{Pattern: &Pattern{Texture: texture}}
+}
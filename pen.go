@@ -8,11 +8,76 @@ var DefaultPen Pen = CreatePen(1.0, Black)
 var TransparentPen Pen = CreatePen(0.0, Transparent)
 var WhitePen Pen = CreatePen(1.0, White)
 
+// DefaultMiterLimit is the miter limit used by pens with a zero MiterLimit
+// that are dashed or have a non-default cap or join.
+const DefaultMiterLimit = 4
+
+// LineCap is an enumerator of shapes drawn at the ends of open lines and
+// dashes.
+type LineCap int
+
+const (
+	// ButtCap ends the line flat at the end point.
+	ButtCap LineCap = iota
+	// RoundCap ends the line with a semicircle centered on the end point.
+	RoundCap
+	// SquareCap ends the line flat, extended by half the pen width past the
+	// end point.
+	SquareCap
+)
+
+// LineJoin is an enumerator of shapes drawn where two line segments meet.
+type LineJoin int
+
+const (
+	// MiterJoin extends the outer edges of the segments until they meet,
+	// falling back to BevelJoin if the miter length exceeds the miter limit.
+	MiterJoin LineJoin = iota
+	// RoundJoin rounds the outer corner with an arc centered on the vertex.
+	RoundJoin
+	// BevelJoin cuts the outer corner off with a straight line.
+	BevelJoin
+)
+
+// DashPattern describes the dashes of a dashed Pen.
+type DashPattern struct {
+	// Lengths holds the alternating lengths of the dashes and gaps in dips,
+	// starting with a dash. If there is an odd number of lengths, the lengths
+	// are repeated to yield an even number.
+	Lengths []float32
+
+	// Offset is the distance into the pattern at which the line starts.
+	Offset float32
+}
+
 type Pen struct {
 	Width float32
 	Color Color
+	Cap   LineCap
+	Join  LineJoin
+
+	// MiterLimit is the maximum ratio of the miter length to the pen width
+	// before a MiterJoin is drawn as a BevelJoin. Pens that are solid, use
+	// ButtCap and MiterJoin, and have a zero MiterLimit draw unlimited miters.
+	MiterLimit float32
+
+	// Dash is the pattern of dashes drawn by the pen, or nil for a solid pen.
+	Dash *DashPattern
 }
 
 func CreatePen(width float32, color Color) Pen {
-	return Pen{width, color}
+	return Pen{Width: width, Color: color}
+}
+
+// CreateDashedPen returns a pen that draws dashes with the alternating dash
+// and gap lengths, starting offset dips into the pattern.
+func CreateDashedPen(width float32, color Color, offset float32, lengths ...float32) Pen {
+	return Pen{
+		Width: width,
+		Color: color,
+		Dash: &DashPattern{
+			Lengths: append([]float32{}, lengths...),
+			Offset:  offset,
+		},
+	}
 }
@@ -0,0 +1,31 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"github.com/google/gxui/math"
+)
+
+// RegionRedrawer is the optional interface implemented by Parents that can
+// limit a redraw to a sub-region of their bounds. Windows use the union of the
+// redrawn regions to only repaint the parts of the viewport that changed.
+type RegionRedrawer interface {
+	// RedrawRegion requests a redraw of the rectangle r, in the local
+	// coordinate space of the Parent.
+	RedrawRegion(r math.Rect)
+}
+
+// RedrawChildRegion requests that the parent p redraws the rectangle r, which
+// is given in the local coordinate space of child. If p does not implement
+// RegionRedrawer then RedrawChildRegion falls back to calling p.Redraw().
+func RedrawChildRegion(p Parent, child Control, r math.Rect) {
+	if rr, ok := p.(RegionRedrawer); ok {
+		if c := p.Children().Find(child); c != nil {
+			rr.RedrawRegion(c.ToParentRect(r))
+			return
+		}
+	}
+	p.Redraw()
+}
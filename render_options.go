@@ -0,0 +1,68 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+// TextAntiAliasing is an enumerator of the ways glyphs can be anti-aliased.
+type TextAntiAliasing int
+
+const (
+	// TextAntiAliasGrayscale blends glyph edges using a single coverage value
+	// per pixel.
+	TextAntiAliasGrayscale TextAntiAliasing = iota
+	// TextAntiAliasNone draws glyphs with hard, aliased edges.
+	TextAntiAliasNone
+	// TextAntiAliasLCD uses the horizontal red, green and blue subpixels of
+	// LCD displays to triple the horizontal resolution of glyphs. The
+	// coverage is filtered to reduce color fringes.
+	TextAntiAliasLCD
+	// TextAntiAliasLCDBGR is like TextAntiAliasLCD, but for displays with
+	// their subpixels in blue, green, red order.
+	TextAntiAliasLCDBGR
+)
+
+// IsLCD returns true if a filters glyphs for the subpixels of LCD displays.
+func (a TextAntiAliasing) IsLCD() bool {
+	return a == TextAntiAliasLCD || a == TextAntiAliasLCDBGR
+}
+
+// RenderOptions control the quality of the anti-aliasing performed by a
+// Viewport.
+type RenderOptions struct {
+	// Samples is the number of multisamples per pixel used to anti-alias the
+	// edges of shapes. Values of 0 or 1 disable multisampling. Multisampling
+	// requires support from the platform, and can only be chosen when the
+	// viewport is created.
+	Samples int
+
+	// TextAntiAliasing is the way glyph edges are anti-aliased.
+	TextAntiAliasing TextAntiAliasing
+
+	// SubpixelPositioning places glyphs at fractions of a pixel, instead of
+	// snapping them to whole pixels. This preserves the spacing of glyphs at
+	// non-integer scales, at the cost of rasterizing each glyph a few times.
+	SubpixelPositioning bool
+
+	// TextGamma is the gamma used to blend glyphs with the pixels behind
+	// them. Blending with the display's gamma, typically 2.2, keeps the
+	// apparent weight of text the same for dark and light colors. A gamma of
+	// 1 blends the glyph coverage linearly.
+	TextGamma float32
+
+	// DistanceFieldText draws glyphs from a single atlas of signed distance
+	// fields, which stay sharp at any scale or transform. Glyphs drawn with
+	// an em size of less than DistanceFieldMinSize pixels are drawn from
+	// bitmaps instead, as distance fields lose detail at small sizes.
+	DistanceFieldText    bool
+	DistanceFieldMinSize float32
+}
+
+// DefaultRenderOptions are the RenderOptions used by drivers until they are
+// changed.
+var DefaultRenderOptions = RenderOptions{
+	Samples:              4,
+	TextAntiAliasing:     TextAntiAliasGrayscale,
+	TextGamma:            1,
+	DistanceFieldMinSize: 24,
+}
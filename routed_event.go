@@ -0,0 +1,151 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"github.com/google/gxui/math"
+)
+
+// RoutedEventKind identifies the input event carried by a RoutedEvent.
+type RoutedEventKind int
+
+const (
+	RoutedMouseMove RoutedEventKind = iota
+	RoutedMouseDown
+	RoutedMouseUp
+	RoutedMouseScroll
+	RoutedClick
+	RoutedDoubleClick
+	RoutedKeyDown
+	RoutedKeyUp
+	RoutedKeyPress
+	RoutedKeyStroke
+)
+
+// RoutePhase is the direction in which a routed event is travelling.
+type RoutePhase int
+
+const (
+	// TunnelPhase is the first phase of a route, from the window down to the
+	// target control. Handlers in the tunnel phase see the event before the
+	// controls below them, so containers can intercept events.
+	TunnelPhase RoutePhase = iota
+
+	// BubblePhase is the second phase of a route, from the target control
+	// back up to the window.
+	BubblePhase
+)
+
+// RoutedEventTarget is the interface implemented by windows and controls to
+// receive routed events.
+type RoutedEventTarget interface {
+	// OnRoutedEvent subscribes f to be called whenever a routed event of the
+	// kind passes through the target in the phase.
+	OnRoutedEvent(kind RoutedEventKind, phase RoutePhase, f func(*RoutedEvent)) EventSubscription
+
+	// HandleRoutedEvent calls the functions subscribed with OnRoutedEvent
+	// for the kind and phase of the event.
+	// HandleRoutedEvent is called by Route.Dispatch and should not be called
+	// by the user.
+	HandleRoutedEvent(*RoutedEvent)
+}
+
+// RoutedEvent is an input event routed from the window to the target control
+// in the tunnel phase, then back up to the window in the bubble phase.
+//
+// Once the route is complete, the event's default action is performed unless
+// prevented with PreventDefault. The default action is the delivery of the
+// event to the Control methods, such as MouseDown or KeyPress, and any
+// behaviour that follows from them, such as focus changes or keymap commands.
+// The exception is the mouse-up, which is always delivered to the controls
+// that received the mouse-down; its default action is the click and focus
+// handling that follows.
+type RoutedEvent struct {
+	Kind  RoutedEventKind
+	Phase RoutePhase
+
+	// Target is the innermost window or control of the route: the control
+	// under the mouse or with focus.
+	Target RoutedEventTarget
+
+	// Current is the window or control whose handlers are being called.
+	Current RoutedEventTarget
+
+	// Mouse holds the mouse event for the mouse, click and double-click
+	// kinds. Point is local to Current.
+	Mouse MouseEvent
+
+	// Keyboard holds the keyboard event for the key-down, key-up and
+	// key-press kinds.
+	Keyboard KeyboardEvent
+
+	// KeyStroke holds the key-stroke event for the RoutedKeyStroke kind.
+	KeyStroke KeyStrokeEvent
+
+	propagationStopped bool
+	defaultPrevented   bool
+}
+
+// StopPropagation prevents the event from being passed to any further
+// handlers of the route. The default action is still performed unless
+// PreventDefault is also called.
+func (e *RoutedEvent) StopPropagation() {
+	e.propagationStopped = true
+}
+
+// IsPropagationStopped returns true if StopPropagation has been called.
+func (e *RoutedEvent) IsPropagationStopped() bool {
+	return e.propagationStopped
+}
+
+// PreventDefault prevents the default action of the event.
+func (e *RoutedEvent) PreventDefault() {
+	e.defaultPrevented = true
+}
+
+// IsDefaultPrevented returns true if PreventDefault has been called.
+func (e *RoutedEvent) IsDefaultPrevented() bool {
+	return e.defaultPrevented
+}
+
+// RouteStep is a window or control along a Route, and the position of the
+// mouse local to it.
+type RouteStep struct {
+	Target RoutedEventTarget
+	Point  math.Point
+}
+
+// Route is the path of a routed event, from the window to the target.
+type Route []RouteStep
+
+// Dispatch passes ev to each step of the route in the tunnel phase, and then
+// in reverse order in the bubble phase, until propagation is stopped.
+// Dispatch returns true if the default action of the event should be
+// performed.
+func (r Route) Dispatch(ev *RoutedEvent) (doDefault bool) {
+	if len(r) == 0 {
+		return true
+	}
+	ev.Target = r[len(r)-1].Target
+	visit := func(s RouteStep) bool {
+		ev.Current = s.Target
+		ev.Mouse.Point = s.Point
+		s.Target.HandleRoutedEvent(ev)
+		return !ev.propagationStopped
+	}
+	ev.Phase = TunnelPhase
+	for _, s := range r {
+		if !visit(s) {
+			return !ev.defaultPrevented
+		}
+	}
+	ev.Phase = BubblePhase
+	for i := len(r) - 1; i >= 0; i-- {
+		if !visit(r[i]) {
+			break
+		}
+	}
+	return !ev.defaultPrevented
+}
@@ -0,0 +1,69 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/gxui/math"
+	test "github.com/google/gxui/testing"
+)
+
+type testRoutedEventTarget struct {
+	name    string
+	visited *[]string
+	handle  func(*RoutedEvent)
+}
+
+func (t *testRoutedEventTarget) OnRoutedEvent(RoutedEventKind, RoutePhase, func(*RoutedEvent)) EventSubscription {
+	panic("Not implemented")
+}
+
+func (t *testRoutedEventTarget) HandleRoutedEvent(ev *RoutedEvent) {
+	phase := map[RoutePhase]string{TunnelPhase: "tunnel", BubblePhase: "bubble"}[ev.Phase]
+	p := ev.Mouse.Point
+	*t.visited = append(*t.visited, fmt.Sprintf("%s %s %d,%d", phase, t.name, p.X, p.Y))
+	if t.handle != nil {
+		t.handle(ev)
+	}
+}
+
+func TestRouteDispatch(t *testing.T) {
+	visited := []string{}
+	window := &testRoutedEventTarget{name: "window", visited: &visited}
+	panel := &testRoutedEventTarget{name: "panel", visited: &visited}
+	button := &testRoutedEventTarget{name: "button", visited: &visited}
+	route := Route{
+		{window, math.Point{X: 10, Y: 10}},
+		{panel, math.Point{X: 5, Y: 5}},
+		{button, math.Point{X: 1, Y: 1}},
+	}
+
+	test.AssertEquals(t, true, route.Dispatch(&RoutedEvent{Kind: RoutedMouseDown}))
+	test.AssertEquals(t, []string{
+		"tunnel window 10,10",
+		"tunnel panel 5,5",
+		"tunnel button 1,1",
+		"bubble button 1,1",
+		"bubble panel 5,5",
+		"bubble window 10,10",
+	}, visited)
+
+	// The panel intercepts the event before it reaches the button.
+	visited = visited[:0]
+	panel.handle = func(ev *RoutedEvent) {
+		ev.StopPropagation()
+		ev.PreventDefault()
+	}
+	ev := &RoutedEvent{Kind: RoutedMouseDown}
+	test.AssertEquals(t, false, route.Dispatch(ev))
+	test.AssertEquals(t, []string{
+		"tunnel window 10,10",
+		"tunnel panel 5,5",
+	}, visited)
+	test.AssertEquals(t, true, ev.Target == button)
+	test.AssertEquals(t, true, ev.Current == panel)
+}
@@ -11,10 +11,13 @@ import (
 	"image/draw"
 	_ "image/jpeg"
 	_ "image/png"
+	"io/ioutil"
 	"os"
+	"strings"
 
 	"github.com/google/gxui"
 	"github.com/google/gxui/drivers/gl"
+	"github.com/google/gxui/math"
 	"github.com/google/gxui/samples/flags"
 )
 
@@ -26,32 +29,48 @@ func appMain(driver gxui.Driver) {
 	}
 
 	file := args[0]
-	f, err := os.Open(file)
-	if err != nil {
-		fmt.Printf("Failed to open image '%s': %v\n", file, err)
-		os.Exit(1)
-	}
-
-	source, _, err := image.Decode(f)
-	if err != nil {
-		fmt.Printf("Failed to read image '%s': %v\n", file, err)
-		os.Exit(1)
-	}
-
 	theme := flags.CreateTheme(driver)
 	img := theme.CreateImage()
 
-	mx := source.Bounds().Max
-	window := theme.CreateWindow(mx.X, mx.Y, "Image viewer")
+	var size math.Size
+	if strings.HasSuffix(strings.ToLower(file), ".svg") {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			fmt.Printf("Failed to open image '%s': %v\n", file, err)
+			os.Exit(1)
+		}
+		svg, err := gxui.ParseSVG(data)
+		if err != nil {
+			fmt.Printf("Failed to read image '%s': %v\n", file, err)
+			os.Exit(1)
+		}
+		img.SetSVG(svg)
+		size = svg.Size()
+	} else {
+		f, err := os.Open(file)
+		if err != nil {
+			fmt.Printf("Failed to open image '%s': %v\n", file, err)
+			os.Exit(1)
+		}
+
+		source, _, err := image.Decode(f)
+		if err != nil {
+			fmt.Printf("Failed to read image '%s': %v\n", file, err)
+			os.Exit(1)
+		}
+
+		// Copy the image to a RGBA format before handing to a gxui.Texture
+		rgba := image.NewRGBA(source.Bounds())
+		draw.Draw(rgba, source.Bounds(), source, image.ZP, draw.Src)
+		texture := driver.CreateTexture(rgba, 1)
+		img.SetTexture(texture)
+		size = texture.Size()
+	}
+
+	window := theme.CreateWindow(size.W, size.H, "Image viewer")
 	window.SetScale(flags.DefaultScaleFactor)
 	window.AddChild(img)
 
-	// Copy the image to a RGBA format before handing to a gxui.Texture
-	rgba := image.NewRGBA(source.Bounds())
-	draw.Draw(rgba, source.Bounds(), source, image.ZP, draw.Src)
-	texture := driver.CreateTexture(rgba, 1)
-	img.SetTexture(texture)
-
 	window.OnClose(driver.Terminate)
 }
 
@@ -4,6 +4,8 @@
 
 package gxui
 
+import "time"
+
 type ScrollBar interface {
 	Control
 
@@ -16,4 +18,13 @@ type ScrollBar interface {
 	SetAutoHide(l bool)
 	Orientation() Orientation
 	SetOrientation(Orientation)
+
+	// AutoHideDelay returns the duration of inactivity after which the scroll
+	// bar is hidden. A duration of 0 means the scroll bar does not hide.
+	AutoHideDelay() time.Duration
+
+	// SetAutoHideDelay sets the duration of inactivity after which the scroll
+	// bar is hidden. Scrolling or hovering the mouse over the scroll bar
+	// reveals it again. A duration of 0 disables inactivity hiding.
+	SetAutoHideDelay(time.Duration)
 }
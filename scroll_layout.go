@@ -4,6 +4,12 @@
 
 package gxui
 
+import (
+	"time"
+
+	"github.com/google/gxui/math"
+)
+
 type ScrollLayout interface {
 	Control
 	Parent
@@ -15,4 +21,43 @@ type ScrollLayout interface {
 	SetBorderPen(Pen)
 	BackgroundBrush() Brush
 	SetBackgroundBrush(Brush)
+
+	// ScrollOffset returns the offset of the child control's top-left corner
+	// from the top-left of the visible area.
+	ScrollOffset() math.Point
+
+	// SetScrollOffset immediately scrolls to the specified offset, cancelling
+	// any scroll animation. SetScrollOffset returns true if the offset changed.
+	SetScrollOffset(math.Point) bool
+
+	// ScrollTo animates the scroll offset to the specified offset.
+	ScrollTo(math.Point)
+
+	// ScrollIntoView scrolls by the smallest distance that makes the specified
+	// descendant control fully visible. The scroll is animated if smooth
+	// scrolling is enabled.
+	ScrollIntoView(Control)
+
+	// SmoothScrolling returns true if mouse-wheel and trackpad scrolling is
+	// kinetic, and ScrollIntoView is animated.
+	SmoothScrolling() bool
+
+	// SetSmoothScrolling enables or disables kinetic scrolling and animated
+	// ScrollIntoView calls.
+	SetSmoothScrolling(bool)
+
+	// OverscrollBounce returns true if kinetic scrolling is permitted to travel
+	// past the scroll limits before springing back.
+	OverscrollBounce() bool
+
+	// SetOverscrollBounce enables or disables bouncing at the scroll limits.
+	SetOverscrollBounce(bool)
+
+	// ScrollBarAutoHideDelay returns the duration of inactivity after which the
+	// scroll bars are hidden. A duration of 0 means the scroll bars do not hide.
+	ScrollBarAutoHideDelay() time.Duration
+
+	// SetScrollBarAutoHideDelay sets the duration of inactivity after which the
+	// scroll bars are hidden. A duration of 0 means the scroll bars do not hide.
+	SetScrollBarAutoHideDelay(time.Duration)
 }
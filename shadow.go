@@ -0,0 +1,48 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"github.com/google/gxui/math"
+)
+
+var NoShadow = Shadow{}
+
+// Shadow describes a box shadow cast by a rectangle, in the style of the CSS
+// box-shadow property.
+type Shadow struct {
+	// Offset moves the shadow relative to the rectangle casting it.
+	Offset math.Point
+
+	// Spread grows (or if negative, shrinks) the shadow on all sides before
+	// it is blurred.
+	Spread int
+
+	// Blur is the blur radius. As with CSS, the shadow is blurred with a
+	// gaussian with a standard deviation of half the blur radius.
+	Blur float32
+
+	Color Color
+}
+
+func CreateShadow(offset math.Point, blur float32, spread int, color Color) Shadow {
+	return Shadow{
+		Offset: offset,
+		Spread: spread,
+		Blur:   blur,
+		Color:  color,
+	}
+}
+
+// Transparent returns true if the shadow would not draw any visible pixels.
+func (s Shadow) Transparent() bool {
+	return s.Color.A == 0
+}
+
+// Bounds returns the area covered by the shadow of the rectangle r.
+func (s Shadow) Bounds(r math.Rect) math.Rect {
+	// The gaussian is negligible beyond three standard deviations.
+	return r.Offset(s.Offset).ExpandI(s.Spread + int(math.Ceilf(s.Blur*1.5)))
+}
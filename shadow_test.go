@@ -0,0 +1,53 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"testing"
+
+	"github.com/google/gxui/math"
+	test "github.com/google/gxui/testing"
+)
+
+func TestShadowBounds(t *testing.T) {
+	r := math.CreateRect(10, 20, 30, 40)
+	for _, c := range []struct {
+		offset   math.Point
+		blur     float32
+		spread   int
+		expected math.Rect
+	}{
+		{math.Point{}, 0, 0, math.CreateRect(10, 20, 30, 40)},
+		{math.Point{X: 3, Y: -2}, 0, 0, math.CreateRect(13, 18, 33, 38)},
+		{math.Point{}, 0, 2, math.CreateRect(8, 18, 32, 42)},
+		{math.Point{}, 0, -5, math.CreateRect(15, 25, 25, 35)},
+		{math.Point{}, 4, 0, math.CreateRect(4, 14, 36, 46)}, // 3 standard deviations
+		{math.Point{}, 1, 0, math.CreateRect(8, 18, 32, 42)}, // Rounded up to whole pixels
+		{math.Point{Y: 2}, 4, 1, math.CreateRect(3, 15, 37, 49)},
+	} {
+		s := CreateShadow(c.offset, c.blur, c.spread, Black)
+		test.AssertEquals(t, c.expected, s.Bounds(r))
+	}
+}
+
+func TestCreateShadow(t *testing.T) {
+	s := CreateShadow(math.Point{X: 1, Y: 2}, 3, 4, Red)
+	test.AssertEquals(t, Shadow{Offset: math.Point{X: 1, Y: 2}, Blur: 3, Spread: 4, Color: Red}, s)
+	test.AssertEquals(t, false, s.Transparent())
+
+	for _, c := range []struct {
+		color       Color
+		transparent bool
+	}{
+		{Black, false},
+		{Transparent, true},
+		{Color{R: 1, G: 1, B: 1, A: 0}, true},
+		{Color{A: 0.01}, false},
+	} {
+		s := CreateShadow(math.Point{}, 4, 0, c.color)
+		test.AssertEquals(t, c.transparent, s.Transparent())
+	}
+	test.AssertEquals(t, true, NoShadow.Transparent())
+}
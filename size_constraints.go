@@ -0,0 +1,86 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/gxui/math"
+)
+
+// SizeLimits returns the min and max size limits of the parent combined with
+// the minimum and maximum sizes of the control c.
+// The parent's max limit always takes precedence, as the control cannot be
+// given more space than is available. The control's own maximum size takes
+// precedence over the parent's min limit, and the control's minimum size takes
+// precedence over its maximum size.
+func SizeLimits(c Control, min, max math.Size) (math.Size, math.Size) {
+	cmin := c.MinSize()
+	hi := max.Min(c.MaxSize().Max(cmin))
+	lo := min.Max(cmin).Min(hi)
+	return lo, hi
+}
+
+// ConstrainedDesiredSize returns the desired size of the control c, honoring
+// the control's minimum, maximum and preferred sizes. Layouts should use
+// ConstrainedDesiredSize instead of calling c.DesiredSize directly.
+func ConstrainedDesiredSize(c Control, min, max math.Size) math.Size {
+	lo, hi := SizeLimits(c, min, max)
+	s := c.DesiredSize(lo, hi)
+	p := c.PreferredSize()
+	if p.W > 0 {
+		s.W = p.W
+	}
+	if p.H > 0 {
+		s.H = p.H
+	}
+	return s.Clamp(lo, hi)
+}
+
+// ConstrainSize returns the size s clamped to the minimum and maximum sizes of
+// the control c, without exceeding s. ConstrainSize is used by layouts that
+// assign sizes to their children without calling DesiredSize.
+func ConstrainSize(c Control, s math.Size) math.Size {
+	lo, hi := SizeLimits(c, math.ZeroSize, s)
+	p := c.PreferredSize()
+	if p.W > 0 {
+		hi.W = math.Clamp(p.W, lo.W, hi.W)
+	}
+	if p.H > 0 {
+		hi.H = math.Clamp(p.H, lo.H, hi.H)
+	}
+	return hi
+}
+
+// ValidateSizeConstraints checks the minimum, maximum and preferred sizes of
+// the control c and all of its descendants, returning an error describing
+// every conflicting constraint, or nil if there are no conflicts.
+func ValidateSizeConstraints(c Control) error {
+	var problems []string
+	validateSizeConstraints(c, &problems)
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("Conflicting size constraints:\n%s", strings.Join(problems, "\n"))
+}
+
+func validateSizeConstraints(c Control, problems *[]string) {
+	min, max, pref := c.MinSize(), c.MaxSize(), c.PreferredSize()
+	if min.W > max.W || min.H > max.H {
+		*problems = append(*problems, fmt.Sprintf("%T: minimum size %v is greater than maximum size %v", c, min, max))
+	}
+	if pref.W > 0 && (pref.W < min.W || pref.W > max.W) {
+		*problems = append(*problems, fmt.Sprintf("%T: preferred width %d is outside of the range [%d, %d]", c, pref.W, min.W, max.W))
+	}
+	if pref.H > 0 && (pref.H < min.H || pref.H > max.H) {
+		*problems = append(*problems, fmt.Sprintf("%T: preferred height %d is outside of the range [%d, %d]", c, pref.H, min.H, max.H))
+	}
+	if p, ok := c.(Parent); ok {
+		for _, child := range p.Children() {
+			validateSizeConstraints(child.Control, problems)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"testing"
+
+	"github.com/google/gxui/math"
+	test "github.com/google/gxui/testing"
+)
+
+type testSizedControl struct {
+	Control
+	min, max, preferred, desired math.Size
+}
+
+func createTestSizedControl(min, max, preferred, desired math.Size) *testSizedControl {
+	return &testSizedControl{min: min, max: max, preferred: preferred, desired: desired}
+}
+
+func (c *testSizedControl) MinSize() math.Size                       { return c.min }
+func (c *testSizedControl) MaxSize() math.Size                       { return c.max }
+func (c *testSizedControl) PreferredSize() math.Size                 { return c.preferred }
+func (c *testSizedControl) DesiredSize(min, max math.Size) math.Size { return c.desired }
+
+type testSizedContainer struct {
+	testSizedControl
+	children Children
+}
+
+func (c *testSizedContainer) Children() Children { return c.children }
+func (c *testSizedContainer) Relayout()          {}
+func (c *testSizedContainer) Redraw()            {}
+
+func TestSizeLimits(t *testing.T) {
+	size := func(w, h int) math.Size { return math.Size{W: w, H: h} }
+	for _, c := range []struct {
+		cmin, cmax, min, max, lo, hi math.Size
+	}{
+		// Unconstrained controls take the parent's limits.
+		{size(0, 0), math.MaxSize, size(10, 20), size(100, 100), size(10, 20), size(100, 100)},
+		// The control's minimum raises the parent's.
+		{size(30, 5), math.MaxSize, size(10, 20), size(100, 100), size(30, 20), size(100, 100)},
+		// The control's maximum takes precedence over the parent's minimum.
+		{size(0, 0), size(50, 60), size(70, 10), size(100, 100), size(50, 10), size(50, 60)},
+		// The control's minimum takes precedence over its maximum.
+		{size(80, 80), size(50, 50), size(0, 0), size(100, 100), size(80, 80), size(80, 80)},
+		// The parent's maximum takes precedence over everything.
+		{size(50, 50), math.MaxSize, size(0, 0), size(30, 40), size(30, 40), size(30, 40)},
+		// Unbounded maximums stay unbounded.
+		{size(0, 0), math.MaxSize, size(0, 0), math.MaxSize, size(0, 0), math.MaxSize},
+	} {
+		ctrl := createTestSizedControl(c.cmin, c.cmax, math.ZeroSize, math.ZeroSize)
+		lo, hi := SizeLimits(ctrl, c.min, c.max)
+		test.AssertEquals(t, c.lo, lo)
+		test.AssertEquals(t, c.hi, hi)
+	}
+}
+
+func TestConstrainedDesiredSize(t *testing.T) {
+	size := func(w, h int) math.Size { return math.Size{W: w, H: h} }
+	for _, c := range []struct {
+		cmin, cmax, preferred, desired, max, expected math.Size
+	}{
+		{size(0, 0), math.MaxSize, size(0, 0), size(20, 30), size(100, 100), size(20, 30)},
+		{size(0, 0), math.MaxSize, size(0, 0), size(200, 30), size(100, 100), size(100, 30)},
+		{size(25, 35), math.MaxSize, size(0, 0), size(20, 30), size(100, 100), size(25, 35)},
+		{size(0, 0), size(15, 100), size(0, 0), size(20, 30), size(100, 100), size(15, 30)},
+		// Preferred sizes replace the desired size on each axis.
+		{size(0, 0), math.MaxSize, size(40, 0), size(20, 30), size(100, 100), size(40, 30)},
+		{size(0, 0), size(35, 100), size(40, 50), size(20, 30), size(100, 100), size(35, 50)},
+		// Unbounded maximums do not limit the desired size.
+		{size(0, 0), math.MaxSize, size(0, 0), size(500, 600), math.MaxSize, size(500, 600)},
+	} {
+		ctrl := createTestSizedControl(c.cmin, c.cmax, c.preferred, c.desired)
+		test.AssertEquals(t, c.expected, ConstrainedDesiredSize(ctrl, math.ZeroSize, c.max))
+	}
+}
+
+func TestConstrainSize(t *testing.T) {
+	size := func(w, h int) math.Size { return math.Size{W: w, H: h} }
+	s := size(100, 50)
+	for _, c := range []struct {
+		cmin, cmax, preferred, expected math.Size
+	}{
+		{size(0, 0), math.MaxSize, size(0, 0), size(100, 50)},
+		{size(0, 0), size(40, 1000), size(0, 0), size(40, 50)},
+		{size(0, 0), math.MaxSize, size(30, 0), size(30, 50)},
+		{size(20, 0), math.MaxSize, size(10, 0), size(20, 50)},
+		// The size is never exceeded.
+		{size(200, 10), math.MaxSize, size(0, 0), size(100, 50)},
+		{size(0, 0), math.MaxSize, size(300, 70), size(100, 50)},
+	} {
+		ctrl := createTestSizedControl(c.cmin, c.cmax, c.preferred, math.ZeroSize)
+		test.AssertEquals(t, c.expected, ConstrainSize(ctrl, s))
+	}
+}
+
+func TestValidateSizeConstraints(t *testing.T) {
+	size := func(w, h int) math.Size { return math.Size{W: w, H: h} }
+	valid := createTestSizedControl(size(10, 10), math.MaxSize, size(20, 0), math.ZeroSize)
+	test.AssertEquals(t, nil, ValidateSizeConstraints(valid))
+
+	// Unbounded maximums never conflict.
+	unbounded := createTestSizedControl(size(0, 0), math.MaxSize, size(5000, 5000), math.ZeroSize)
+	test.AssertEquals(t, nil, ValidateSizeConstraints(unbounded))
+
+	minOverMax := createTestSizedControl(size(80, 10), size(50, 50), size(0, 0), math.ZeroSize)
+	preferred := createTestSizedControl(size(10, 10), size(50, 50), size(5, 60), math.ZeroSize)
+	parent := &testSizedContainer{
+		testSizedControl: *createTestSizedControl(size(0, 0), math.MaxSize, size(0, 0), math.ZeroSize),
+		children:         Children{{Control: valid}, {Control: minOverMax}, {Control: preferred}},
+	}
+	err := ValidateSizeConstraints(parent)
+	test.AssertEquals(t, "Conflicting size constraints:\n"+
+		"*gxui.testSizedControl: minimum size {80 10} is greater than maximum size {50 50}\n"+
+		"*gxui.testSizedControl: preferred width 5 is outside of the range [10, 50]\n"+
+		"*gxui.testSizedControl: preferred height 60 is outside of the range [10, 50]",
+		err.Error())
+}
@@ -0,0 +1,95 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+// StyleState is a set of flags describing the interactive state of a control,
+// used by themes to pick the variant of the control's style.
+type StyleState int
+
+const (
+	// StyleDefault is the state of a control with none of the other flags.
+	// Styles for StyleDefault apply in every state.
+	StyleDefault StyleState = 0
+
+	StyleFocused  StyleState = 1 << 0
+	StyleOver     StyleState = 1 << 1
+	StylePressed  StyleState = 1 << 2
+	StyleDisabled StyleState = 1 << 3
+)
+
+// styleCascade is the order in which the style variants of each state are
+// applied, where later states take precedence.
+var styleCascade = []StyleState{StyleFocused, StyleOver, StylePressed, StyleDisabled}
+
+// Cascade returns StyleDefault followed by each of the flags of s, in the
+// order their style variants are applied: focused, over, pressed then
+// disabled.
+func (s StyleState) Cascade() []StyleState {
+	states := []StyleState{StyleDefault}
+	for _, f := range styleCascade {
+		if s&f != 0 {
+			states = append(states, f)
+		}
+	}
+	return states
+}
+
+// IsSingle returns true if s is StyleDefault or a single state flag.
+func (s StyleState) IsSingle() bool {
+	return s&(s-1) == 0
+}
+
+func (s StyleState) String() string {
+	switch s {
+	case StyleDefault:
+		return "default"
+	case StyleFocused:
+		return "focused"
+	case StyleOver:
+		return "over"
+	case StylePressed:
+		return "pressed"
+	case StyleDisabled:
+		return "disabled"
+	}
+	str := ""
+	for _, f := range s.Cascade()[1:] {
+		if str != "" {
+			str += "|"
+		}
+		str += f.String()
+	}
+	return str
+}
+
+// StyleOverride is a set of changes to a style. Only the non-nil fields are
+// changed.
+type StyleOverride struct {
+	FontColor *Color
+	Brush     *Brush
+	Pen       *Pen
+	Skin      *NinePatch
+	Shadow    *Shadow
+}
+
+// Merge returns o with the non-nil fields of p replacing those of o.
+func (o StyleOverride) Merge(p StyleOverride) StyleOverride {
+	if p.FontColor != nil {
+		o.FontColor = p.FontColor
+	}
+	if p.Brush != nil {
+		o.Brush = p.Brush
+	}
+	if p.Pen != nil {
+		o.Pen = p.Pen
+	}
+	if p.Skin != nil {
+		o.Skin = p.Skin
+	}
+	if p.Shadow != nil {
+		o.Shadow = p.Shadow
+	}
+	return o
+}
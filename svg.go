@@ -0,0 +1,114 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"github.com/google/gxui/math"
+)
+
+// SVGShape is a single filled and stroked path of an SVG image.
+type SVGShape struct {
+	// Path is the outline of the shape in the element's user units. Arcs are
+	// converted to cubic Bézier curves so that the path can be transformed
+	// exactly.
+	Path Path2D
+	// Transform maps the path from user units to the units of the SVG's
+	// view box.
+	Transform math.Mat3
+	// Pen strokes the path. Widths and dash lengths are in user units.
+	Pen Pen
+	// Brush fills the path. Gradients are relative to the bounds of Path.
+	Brush Brush
+}
+
+// SVG is a resolution-independent image parsed from a subset of the SVG 1.1
+// format by ParseSVG. As an SVG is painted as paths, it is drawn crisply at
+// any Window scale.
+type SVG struct {
+	// Width and Height are the intrinsic size of the image in DIPs.
+	Width, Height float32
+	// ViewBoxMin and ViewBoxSize are the region of the SVG's user space that
+	// is mapped to the bounds the image is drawn into.
+	ViewBoxMin, ViewBoxSize math.Vec2
+	// AspectNone is true if the view box is stretched to fill the bounds.
+	// Otherwise the view box is uniformly scaled to fit the bounds, or to cover
+	// them if AspectSlice is true, and aligned by AspectAlign where 0 is the
+	// left or top, and 1 is the right or bottom of the bounds.
+	AspectNone  bool
+	AspectSlice bool
+	AspectAlign math.Vec2
+	// Shapes are drawn in order, back to front.
+	Shapes []SVGShape
+}
+
+// Size returns the intrinsic size of the image in DIPs, rounded up.
+func (s *SVG) Size() math.Size {
+	return math.Size{W: int(math.Ceilf(s.Width)), H: int(math.Ceilf(s.Height))}
+}
+
+// ViewTransform returns the transform from the units of the view box to the
+// DIPs of the rectangle r.
+func (s *SVG) ViewTransform(r math.Rect) math.Mat3 {
+	vbMin, vbSize := s.ViewBoxMin, s.ViewBoxSize
+	if vbSize.X <= 0 || vbSize.Y <= 0 {
+		vbMin, vbSize = math.Vec2{}, math.Vec2{X: s.Width, Y: s.Height}
+	}
+	if vbSize.X <= 0 || vbSize.Y <= 0 {
+		return math.CreateMat3Translate(float32(r.Min.X), float32(r.Min.Y))
+	}
+	sx, sy := float32(r.W())/vbSize.X, float32(r.H())/vbSize.Y
+	align := math.Vec2{}
+	if !s.AspectNone {
+		if s.AspectSlice {
+			sx = math.Maxf(sx, sy)
+		} else {
+			sx = math.Minf(sx, sy)
+		}
+		sy, align = sx, s.AspectAlign
+	}
+	tx := float32(r.Min.X) + (float32(r.W())-vbSize.X*sx)*align.X
+	ty := float32(r.Min.Y) + (float32(r.H())-vbSize.Y*sy)*align.Y
+	return math.CreateMat3Translate(-vbMin.X, -vbMin.Y).
+		Mul(math.CreateMat3Scale(sx, sy)).
+		Mul(math.CreateMat3Translate(tx, ty))
+}
+
+// Draw paints the image to the canvas, fitting the view box into the
+// rectangle r. The paths are transformed to DIPs before they are drawn, so
+// curves are flattened at the resolution they are displayed.
+func (s *SVG) Draw(c Canvas, r math.Rect) {
+	view := s.ViewTransform(r)
+	if !s.AspectNone && s.AspectSlice {
+		c.Push()
+		c.AddClip(r)
+		defer c.Pop()
+	}
+	for _, shape := range s.Shapes {
+		m := shape.Transform.Mul(view)
+		pen := shape.Pen
+		if scale := math.Sqrtf(math.Absf(m[0]*m[4] - m[1]*m[3])); scale != 1 {
+			pen.Width *= scale
+			if pen.Dash != nil {
+				dash := &DashPattern{Offset: pen.Dash.Offset * scale}
+				for _, l := range pen.Dash.Lengths {
+					dash.Lengths = append(dash.Lengths, l*scale)
+				}
+				pen.Dash = dash
+			}
+		}
+		c.DrawPath(transformSVGPath(shape.Path, m), pen, shape.Brush)
+	}
+}
+
+func transformSVGPath(p Path2D, m math.Mat3) Path2D {
+	out := Path2D{FillRule: p.FillRule, Segments: make([]PathSegment, len(p.Segments))}
+	for i, s := range p.Segments {
+		for j := range s.Points {
+			s.Points[j] = s.Points[j].Vec3(1).MulM(m).XY()
+		}
+		out.Segments[i] = s
+	}
+	return out
+}
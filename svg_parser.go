@@ -0,0 +1,939 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/google/gxui/math"
+)
+
+// ParseSVG parses an SVG document. The supported subset of SVG 1.1 is:
+//   - The svg, g, defs, path, rect, circle, ellipse, line, polyline and
+//     polygon elements.
+//   - The linearGradient and radialGradient elements with stop children,
+//     gradientUnits, spreadMethod and href inheritance. Gradient transforms
+//     and focal points are ignored.
+//   - The transform attribute.
+//   - The fill, fill-opacity, fill-rule, stroke, stroke-width,
+//     stroke-opacity, stroke-linecap, stroke-linejoin, stroke-miterlimit,
+//     stroke-dasharray, stroke-dashoffset, color, opacity and display
+//     properties, as presentation attributes or in the style attribute.
+//     Group opacity is applied to each shape individually, and strokes use
+//     the first stop of a gradient.
+//
+// Other elements, such as text, use and title, are ignored along with their
+// children.
+func ParseSVG(data []byte) (*SVG, error) {
+	p := &svgParser{gradients: map[string]*svgGradient{}}
+	if err := p.parse(xml.NewDecoder(bytes.NewReader(data))); err != nil {
+		return nil, fmt.Errorf("Failed to parse SVG: %v", err)
+	}
+	return p.svg, nil
+}
+
+type svgPaintKind int
+
+const (
+	svgPaintNone svgPaintKind = iota
+	svgPaintColor
+	svgPaintCurrentColor
+	svgPaintURL
+)
+
+type svgPaint struct {
+	kind     svgPaintKind
+	color    Color
+	url      string
+	fallback *svgPaint
+}
+
+// svgStyle holds the properties inherited by an element from its ancestors.
+type svgStyle struct {
+	fill, stroke                        svgPaint
+	fillOpacity, strokeOpacity, opacity float32
+	fillRule                            FillRule
+	strokeWidth, miterLimit             float32
+	cap                                 LineCap
+	join                                LineJoin
+	dash                                []float32
+	dashOffset                          float32
+	color                               Color
+	transform                           math.Mat3
+}
+
+var svgDefaultStyle = svgStyle{
+	fill:          svgPaint{kind: svgPaintColor, color: Black},
+	fillOpacity:   1,
+	strokeOpacity: 1,
+	opacity:       1,
+	strokeWidth:   1,
+	miterLimit:    4,
+	color:         Black,
+	transform:     math.Mat3Ident,
+}
+
+type svgGradient struct {
+	radial bool
+	attrs  map[string]string
+	stops  []GradientStop
+}
+
+type svgElement struct {
+	path  Path2D
+	style svgStyle
+}
+
+type svgFrame struct {
+	style    svgStyle
+	gradient *svgGradient
+	defs     bool
+}
+
+type svgParser struct {
+	svg       *SVG
+	gradients map[string]*svgGradient
+	elements  []svgElement
+}
+
+func svgAttrs(e xml.StartElement) map[string]string {
+	attrs := map[string]string{}
+	for _, a := range e.Attr {
+		attrs[a.Name.Local] = strings.TrimSpace(a.Value)
+	}
+	return attrs
+}
+
+func (p *svgParser) parse(d *xml.Decoder) error {
+	stack := []svgFrame{}
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			name := t.Name.Local
+			if p.svg == nil && name != "svg" {
+				return fmt.Errorf("expected <svg> root element, got <%s>", name)
+			}
+			frame := svgFrame{style: svgDefaultStyle}
+			if len(stack) > 0 {
+				frame = stack[len(stack)-1]
+			}
+			attrs := svgAttrs(t)
+			if attrs["display"] == "none" {
+				d.Skip()
+				continue
+			}
+			if err := p.element(name, attrs, &frame, len(stack) == 0); err != nil {
+				return fmt.Errorf("<%s>: %v", name, err)
+			}
+			if !svgContainers[name] {
+				// Only the content of containers and gradients is parsed.
+				d.Skip()
+				continue
+			}
+			stack = append(stack, frame)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		}
+	}
+	if p.svg == nil {
+		return fmt.Errorf("missing <svg> element")
+	}
+	return p.buildShapes()
+}
+
+var svgContainers = map[string]bool{
+	"svg":            true,
+	"g":              true,
+	"a":              true,
+	"defs":           true,
+	"linearGradient": true,
+	"radialGradient": true,
+}
+
+// element parses the start of an element, updating the frame that will be
+// used for its children.
+func (p *svgParser) element(name string, attrs map[string]string, f *svgFrame, root bool) error {
+	switch name {
+	case "svg":
+		if !root {
+			return fmt.Errorf("nested <svg> elements are not supported")
+		}
+		p.svg = &SVG{AspectAlign: math.Vec2{X: 0.5, Y: 0.5}}
+		return p.root(attrs, f)
+	case "stop":
+		if f.gradient != nil {
+			return p.stop(attrs, f)
+		}
+		return nil
+	case "linearGradient", "radialGradient":
+		g := &svgGradient{radial: name == "radialGradient", attrs: attrs}
+		if id := attrs["id"]; id != "" {
+			p.gradients[id] = g
+		}
+		f.gradient = g
+		return nil
+	case "defs":
+		f.defs = true
+		return nil
+	}
+
+	if err := f.style.apply(attrs); err != nil {
+		return err
+	}
+	if f.defs {
+		return nil
+	}
+
+	var path Path2D
+	var err error
+	switch name {
+	case "path":
+		path, err = parseSVGPathData(attrs["d"])
+	case "rect":
+		path, err = svgRect(attrs)
+	case "circle", "ellipse":
+		path, err = svgEllipse(attrs, name == "circle")
+	case "line":
+		var v []float32
+		if v, err = svgLengths(attrs, "x1", "y1", "x2", "y2"); err == nil {
+			path.MoveTo(math.Vec2{X: v[0], Y: v[1]})
+			path.LineTo(math.Vec2{X: v[2], Y: v[3]})
+		}
+	case "polyline", "polygon":
+		path, err = parseSVGPoints(attrs["points"], name == "polygon")
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !path.IsEmpty() {
+		p.elements = append(p.elements, svgElement{path, f.style})
+	}
+	return nil
+}
+
+func (p *svgParser) root(attrs map[string]string, f *svgFrame) error {
+	s := p.svg
+	if vb, ok := attrs["viewBox"]; ok {
+		v, err := parseSVGNumbers(vb)
+		if err != nil || len(v) != 4 {
+			return fmt.Errorf("invalid viewBox '%s'", vb)
+		}
+		s.ViewBoxMin = math.Vec2{X: v[0], Y: v[1]}
+		s.ViewBoxSize = math.Vec2{X: v[2], Y: v[3]}
+	}
+	s.Width, s.Height = s.ViewBoxSize.X, s.ViewBoxSize.Y
+	for _, a := range []struct {
+		name string
+		v    *float32
+	}{{"width", &s.Width}, {"height", &s.Height}} {
+		// Percentages are relative to the container, so use the view box.
+		if str := attrs[a.name]; str != "" && !strings.HasSuffix(str, "%") {
+			l, err := parseSVGLength(str)
+			if err != nil {
+				return err
+			}
+			*a.v = l
+		}
+	}
+	if str := attrs["preserveAspectRatio"]; str != "" {
+		if err := s.parseAspect(str); err != nil {
+			return err
+		}
+	}
+	return f.style.apply(attrs)
+}
+
+func (s *SVG) parseAspect(str string) error {
+	fields := strings.Fields(str)
+	if len(fields) > 0 && fields[0] == "defer" {
+		fields = fields[1:]
+	}
+	if len(fields) == 0 || len(fields) > 2 {
+		return fmt.Errorf("invalid preserveAspectRatio '%s'", str)
+	}
+	if len(fields) == 2 {
+		switch fields[1] {
+		case "meet":
+		case "slice":
+			s.AspectSlice = true
+		default:
+			return fmt.Errorf("invalid preserveAspectRatio '%s'", str)
+		}
+	}
+	align := fields[0]
+	if align == "none" {
+		s.AspectNone = true
+		return nil
+	}
+	axis := func(a string) (float32, bool) {
+		switch a {
+		case "Min":
+			return 0, true
+		case "Mid":
+			return 0.5, true
+		case "Max":
+			return 1, true
+		}
+		return 0, false
+	}
+	if len(align) == 8 && align[0] == 'x' && align[4] == 'Y' {
+		x, okX := axis(align[1:4])
+		y, okY := axis(align[5:8])
+		if okX && okY {
+			s.AspectAlign = math.Vec2{X: x, Y: y}
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid preserveAspectRatio '%s'", str)
+}
+
+func (p *svgParser) stop(attrs map[string]string, f *svgFrame) error {
+	props := map[string]string{
+		"stop-color":   attrs["stop-color"],
+		"stop-opacity": attrs["stop-opacity"],
+	}
+	for k, v := range parseSVGStyleAttr(attrs["style"]) {
+		props[k] = v
+	}
+	stop := GradientStop{Color: Black}
+	offset := attrs["offset"]
+	if offset != "" {
+		o, err := parseSVGFraction(offset)
+		if err != nil {
+			return err
+		}
+		stop.Offset = math.Clampf(o, 0, 1)
+	}
+	if c := props["stop-color"]; c != "" {
+		color, err := parseSVGColor(c, f.style.color)
+		if err != nil {
+			return err
+		}
+		stop.Color = color
+	}
+	if o := props["stop-opacity"]; o != "" {
+		a, err := parseSVGFraction(o)
+		if err != nil {
+			return err
+		}
+		stop.Color.A *= math.Clampf(a, 0, 1)
+	}
+	g := f.gradient
+	if n := len(g.stops); n > 0 && stop.Offset < g.stops[n-1].Offset {
+		stop.Offset = g.stops[n-1].Offset
+	}
+	g.stops = append(g.stops, stop)
+	if len(g.stops) > MaxGradientStops {
+		return fmt.Errorf("gradients can have at most %d stops", MaxGradientStops)
+	}
+	return nil
+}
+
+// apply updates the style with the presentation attributes and the
+// declarations of the style attribute.
+func (s *svgStyle) apply(attrs map[string]string) error {
+	// Opacity is not inherited, but applies to all the descendants.
+	opacity := float32(1)
+	props := parseSVGStyleAttr(attrs["style"])
+	for k, v := range attrs {
+		if _, ok := props[k]; !ok {
+			props[k] = v
+		}
+	}
+	if v, ok := props["color"]; ok && v != "inherit" {
+		c, err := parseSVGColor(v, s.color)
+		if err != nil {
+			return err
+		}
+		s.color = c
+	}
+	for k, v := range props {
+		if v == "inherit" {
+			continue
+		}
+		var err error
+		switch k {
+		case "fill":
+			s.fill, err = parseSVGPaint(v, s.color)
+		case "stroke":
+			s.stroke, err = parseSVGPaint(v, s.color)
+		case "fill-opacity":
+			s.fillOpacity, err = parseSVGFraction(v)
+		case "stroke-opacity":
+			s.strokeOpacity, err = parseSVGFraction(v)
+		case "opacity":
+			opacity, err = parseSVGFraction(v)
+		case "fill-rule":
+			switch v {
+			case "nonzero":
+				s.fillRule = NonZeroFill
+			case "evenodd":
+				s.fillRule = EvenOddFill
+			default:
+				err = fmt.Errorf("invalid fill-rule '%s'", v)
+			}
+		case "stroke-width":
+			s.strokeWidth, err = parseSVGLength(v)
+		case "stroke-miterlimit":
+			s.miterLimit, err = parseSVGLength(v)
+		case "stroke-linecap":
+			switch v {
+			case "butt":
+				s.cap = ButtCap
+			case "round":
+				s.cap = RoundCap
+			case "square":
+				s.cap = SquareCap
+			default:
+				err = fmt.Errorf("invalid stroke-linecap '%s'", v)
+			}
+		case "stroke-linejoin":
+			switch v {
+			case "miter":
+				s.join = MiterJoin
+			case "round":
+				s.join = RoundJoin
+			case "bevel":
+				s.join = BevelJoin
+			default:
+				err = fmt.Errorf("invalid stroke-linejoin '%s'", v)
+			}
+		case "stroke-dasharray":
+			if v == "none" {
+				s.dash = nil
+			} else {
+				s.dash, err = parseSVGNumbers(strings.Replace(v, "px", "", -1))
+			}
+		case "stroke-dashoffset":
+			s.dashOffset, err = parseSVGLength(v)
+		case "transform":
+			var m math.Mat3
+			if m, err = parseSVGTransform(v); err == nil {
+				s.transform = m.Mul(s.transform)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	s.opacity *= math.Clampf(opacity, 0, 1)
+	return nil
+}
+
+func parseSVGStyleAttr(style string) map[string]string {
+	props := map[string]string{}
+	for _, decl := range strings.Split(style, ";") {
+		if i := strings.Index(decl, ":"); i >= 0 {
+			props[strings.TrimSpace(decl[:i])] = strings.TrimSpace(decl[i+1:])
+		}
+	}
+	return props
+}
+
+func parseSVGPaint(str string, current Color) (svgPaint, error) {
+	switch {
+	case str == "none":
+		return svgPaint{kind: svgPaintNone}, nil
+	case str == "currentColor":
+		return svgPaint{kind: svgPaintCurrentColor, color: current}, nil
+	case strings.HasPrefix(str, "url("):
+		end := strings.Index(str, ")")
+		if end < 0 {
+			return svgPaint{}, fmt.Errorf("invalid paint '%s'", str)
+		}
+		url := strings.Trim(strings.TrimSpace(str[4:end]), `'"`)
+		if !strings.HasPrefix(url, "#") {
+			return svgPaint{}, fmt.Errorf("only local paint servers are supported, got '%s'", url)
+		}
+		paint := svgPaint{kind: svgPaintURL, url: url[1:]}
+		if rest := strings.TrimSpace(str[end+1:]); rest != "" {
+			fallback, err := parseSVGPaint(rest, current)
+			if err != nil {
+				return svgPaint{}, err
+			}
+			paint.fallback = &fallback
+		}
+		return paint, nil
+	}
+	c, err := parseSVGColor(str, current)
+	return svgPaint{kind: svgPaintColor, color: c}, err
+}
+
+// svgLengthUnits holds the size of each unit in user units, which are CSS
+// pixels.
+var svgLengthUnits = map[string]float32{
+	"px": 1,
+	"pt": 4.0 / 3.0,
+	"pc": 16,
+	"mm": 96 / 25.4,
+	"cm": 96 / 2.54,
+	"in": 96,
+}
+
+func parseSVGLength(str string) (float32, error) {
+	scale := float32(1)
+	for unit, s := range svgLengthUnits {
+		if strings.HasSuffix(str, unit) {
+			str, scale = strings.TrimSpace(str[:len(str)-len(unit)]), s
+			break
+		}
+	}
+	f, err := strconv.ParseFloat(str, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid length '%s'", str)
+	}
+	return float32(f) * scale, nil
+}
+
+// svgLengths returns the lengths of the named attributes, which default to 0.
+func svgLengths(attrs map[string]string, names ...string) ([]float32, error) {
+	v := make([]float32, len(names))
+	for i, n := range names {
+		if str := attrs[n]; str != "" {
+			l, err := parseSVGLength(str)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = l
+		}
+	}
+	return v, nil
+}
+
+// parseSVGFraction parses a number or a percentage.
+func parseSVGFraction(str string) (float32, error) {
+	scale := float32(1)
+	if strings.HasSuffix(str, "%") {
+		str, scale = str[:len(str)-1], 0.01
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(str), 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number '%s'", str)
+	}
+	return float32(f) * scale, nil
+}
+
+func svgRect(attrs map[string]string) (Path2D, error) {
+	v, err := svgLengths(attrs, "x", "y", "width", "height", "rx", "ry")
+	if err != nil {
+		return Path2D{}, err
+	}
+	x, y, w, h, rx, ry := v[0], v[1], v[2], v[3], v[4], v[5]
+	p := Path2D{}
+	if w <= 0 || h <= 0 {
+		return p, nil
+	}
+	switch {
+	case attrs["rx"] == "":
+		rx = ry
+	case attrs["ry"] == "":
+		ry = rx
+	}
+	rx, ry = math.Clampf(rx, 0, w/2), math.Clampf(ry, 0, h/2)
+	if rx == 0 || ry == 0 {
+		p.MoveTo(math.Vec2{X: x, Y: y})
+		p.LineTo(math.Vec2{X: x + w, Y: y})
+		p.LineTo(math.Vec2{X: x + w, Y: y + h})
+		p.LineTo(math.Vec2{X: x, Y: y + h})
+		p.Close()
+		return p, nil
+	}
+	r := math.Vec2{X: rx, Y: ry}
+	p.MoveTo(math.Vec2{X: x + rx, Y: y})
+	p.LineTo(math.Vec2{X: x + w - rx, Y: y})
+	appendSVGArc(&p, math.Vec2{X: x + w - rx, Y: y + ry}, r, 0, -math.Pi/2, math.Pi/2)
+	p.LineTo(math.Vec2{X: x + w, Y: y + h - ry})
+	appendSVGArc(&p, math.Vec2{X: x + w - rx, Y: y + h - ry}, r, 0, 0, math.Pi/2)
+	p.LineTo(math.Vec2{X: x + rx, Y: y + h})
+	appendSVGArc(&p, math.Vec2{X: x + rx, Y: y + h - ry}, r, 0, math.Pi/2, math.Pi/2)
+	p.LineTo(math.Vec2{X: x, Y: y + ry})
+	appendSVGArc(&p, math.Vec2{X: x + rx, Y: y + ry}, r, 0, math.Pi, math.Pi/2)
+	p.Close()
+	return p, nil
+}
+
+func svgEllipse(attrs map[string]string, circle bool) (Path2D, error) {
+	names := []string{"cx", "cy", "rx", "ry"}
+	if circle {
+		names = []string{"cx", "cy", "r", "r"}
+	}
+	v, err := svgLengths(attrs, names...)
+	if err != nil {
+		return Path2D{}, err
+	}
+	p := Path2D{}
+	if v[2] > 0 && v[3] > 0 {
+		p.MoveTo(math.Vec2{X: v[0] + v[2], Y: v[1]})
+		appendSVGArc(&p, math.Vec2{X: v[0], Y: v[1]}, math.Vec2{X: v[2], Y: v[3]}, 0, 0, 2*math.Pi)
+		p.Close()
+	}
+	return p, nil
+}
+
+// parseSVGTransform parses a transform list into a single matrix.
+func parseSVGTransform(str string) (math.Mat3, error) {
+	s := svgScanner{s: str}
+	m := math.Mat3Ident
+	for s.skip() {
+		start := s.i
+		for s.i < len(s.s) && isSVGLetter(s.s[s.i]) {
+			s.i++
+		}
+		name := s.s[start:s.i]
+		for s.i < len(s.s) && isSVGSpace(s.s[s.i]) {
+			s.i++
+		}
+		if s.i >= len(s.s) || s.s[s.i] != '(' {
+			return m, s.error("'('")
+		}
+		s.i++
+		args := []float32{}
+		for s.hasNumber() {
+			f, err := s.number()
+			if err != nil {
+				return m, err
+			}
+			args = append(args, f)
+		}
+		if !s.skip() || s.s[s.i] != ')' {
+			return m, s.error("')'")
+		}
+		s.i++
+
+		var t math.Mat3
+		n := len(args)
+		switch {
+		case name == "matrix" && n == 6:
+			t = math.CreateMat3(
+				args[0], args[1], 0,
+				args[2], args[3], 0,
+				args[4], args[5], 1,
+			)
+		case name == "translate" && n == 1:
+			t = math.CreateMat3Translate(args[0], 0)
+		case name == "translate" && n == 2:
+			t = math.CreateMat3Translate(args[0], args[1])
+		case name == "scale" && n == 1:
+			t = math.CreateMat3Scale(args[0], args[0])
+		case name == "scale" && n == 2:
+			t = math.CreateMat3Scale(args[0], args[1])
+		case name == "rotate" && n == 1:
+			t = math.CreateMat3Rotate(math.D2R(args[0]))
+		case name == "rotate" && n == 3:
+			t = math.CreateMat3Translate(-args[1], -args[2]).
+				Mul(math.CreateMat3Rotate(math.D2R(args[0]))).
+				Mul(math.CreateMat3Translate(args[1], args[2]))
+		case name == "skewX" && n == 1:
+			t = math.CreateMat3Skew(math.Tanf(math.D2R(args[0])), 0)
+		case name == "skewY" && n == 1:
+			t = math.CreateMat3Skew(0, math.Tanf(math.D2R(args[0])))
+		default:
+			return m, fmt.Errorf("invalid transform '%s' with %d arguments", name, n)
+		}
+		// The rightmost transform in the list is applied first.
+		m = t.Mul(m)
+	}
+	return m, nil
+}
+
+// buildShapes converts the parsed elements to the SVG's shapes, resolving
+// references to gradients.
+func (p *svgParser) buildShapes() error {
+	for _, e := range p.elements {
+		st := e.style
+		shape := SVGShape{
+			Path:      e.path,
+			Transform: st.transform,
+			Pen:       TransparentPen,
+			Brush:     TransparentBrush,
+		}
+		shape.Path.FillRule = st.fillRule
+		if st.fill.kind != svgPaintNone {
+			b, err := p.brush(st.fill, st.fillOpacity*st.opacity, e.path)
+			if err != nil {
+				return err
+			}
+			shape.Brush = b
+		}
+		if st.stroke.kind != svgPaintNone && st.strokeWidth > 0 {
+			b, err := p.brush(st.stroke, st.strokeOpacity*st.opacity, e.path)
+			if err != nil {
+				return err
+			}
+			pen := Pen{
+				Width:      st.strokeWidth,
+				Color:      b.Color,
+				Cap:        st.cap,
+				Join:       st.join,
+				MiterLimit: st.miterLimit,
+			}
+			if b.Gradient != nil {
+				pen.Color = b.Gradient.Stops[0].Color
+			}
+			if len(st.dash) > 0 {
+				pen.Dash = &DashPattern{Lengths: st.dash, Offset: st.dashOffset}
+			}
+			shape.Pen = pen
+		}
+		if !shape.Brush.Transparent() || shape.Pen.Color.A > 0 {
+			p.svg.Shapes = append(p.svg.Shapes, shape)
+		}
+	}
+	return nil
+}
+
+// gradientAttr returns the attribute of the gradient with the given id,
+// following href references to the gradients it inherits from.
+func (p *svgParser) gradientAttr(g *svgGradient, name string) string {
+	for i := 0; g != nil && i < 16; i++ {
+		if v, ok := g.attrs[name]; ok {
+			return v
+		}
+		g = p.gradients[strings.TrimPrefix(g.attrs["href"], "#")]
+	}
+	return ""
+}
+
+func (p *svgParser) brush(paint svgPaint, opacity float32, path Path2D) (Brush, error) {
+	opacity = math.Clampf(opacity, 0, 1)
+	switch paint.kind {
+	case svgPaintColor, svgPaintCurrentColor:
+		c := paint.color
+		c.A *= opacity
+		return CreateBrush(c), nil
+	case svgPaintURL:
+		g, ok := p.gradients[paint.url]
+		if !ok {
+			if paint.fallback != nil {
+				return p.brush(*paint.fallback, opacity, path)
+			}
+			return TransparentBrush, nil
+		}
+		return p.gradientBrush(g, opacity, path)
+	}
+	return TransparentBrush, nil
+}
+
+func (p *svgParser) gradientBrush(g *svgGradient, opacity float32, path Path2D) (Brush, error) {
+	stops := g.stops
+	for i, h := 0, g; len(stops) == 0 && h != nil && i < 16; i++ {
+		h = p.gradients[strings.TrimPrefix(h.attrs["href"], "#")]
+		if h != nil {
+			stops = h.stops
+		}
+	}
+	if len(stops) == 0 {
+		return TransparentBrush, nil
+	}
+	stops = append([]GradientStop{}, stops...)
+	for i := range stops {
+		stops[i].Color.A *= opacity
+	}
+	if len(stops) == 1 {
+		return CreateBrush(stops[0].Color), nil
+	}
+
+	spread := SpreadPad
+	switch m := p.gradientAttr(g, "spreadMethod"); m {
+	case "", "pad":
+	case "repeat":
+		spread = SpreadRepeat
+	case "reflect":
+		spread = SpreadReflect
+	default:
+		return Brush{}, fmt.Errorf("invalid spreadMethod '%s'", m)
+	}
+
+	// Gradient brushes are relative to the bounds of the shape, so map
+	// coordinates in user space to fractions of the path's bounds.
+	userSpace := p.gradientAttr(g, "gradientUnits") == "userSpaceOnUse"
+	min, size := svgPathBounds(path)
+	size = math.Vec2{X: math.Maxf(size.X, 1e-6), Y: math.Maxf(size.Y, 1e-6)}
+	// user returns the attribute in user units, where percentages are
+	// relative to the length ref.
+	user := func(str string, ref float32) (float32, error) {
+		if strings.HasSuffix(str, "%") {
+			f, err := parseSVGFraction(str)
+			return f * ref, err
+		}
+		return parseSVGLength(str)
+	}
+	vb := p.svg.ViewBoxSize
+	coord := func(name, def string, axis int) (float32, error) {
+		str := p.gradientAttr(g, name)
+		if str == "" {
+			str = def
+		}
+		if !userSpace {
+			return parseSVGFraction(str)
+		}
+		if axis == 0 {
+			v, err := user(str, vb.X)
+			return (v - min.X) / size.X, err
+		}
+		v, err := user(str, vb.Y)
+		return (v - min.Y) / size.Y, err
+	}
+
+	var v [4]float32
+	var err error
+	if g.radial {
+		names := [][2]string{{"cx", "50%"}, {"cy", "50%"}}
+		for i, n := range names {
+			if v[i], err = coord(n[0], n[1], i); err != nil {
+				return Brush{}, err
+			}
+		}
+		str := p.gradientAttr(g, "r")
+		if str == "" {
+			str = "50%"
+		}
+		var r math.Vec2
+		if userSpace {
+			// Percentages are of the normalized diagonal of the view box.
+			l, err := user(str, math.Sqrtf((vb.X*vb.X+vb.Y*vb.Y)/2))
+			if err != nil {
+				return Brush{}, err
+			}
+			r = math.Vec2{X: l / size.X, Y: l / size.Y}
+		} else {
+			l, err := parseSVGFraction(str)
+			if err != nil {
+				return Brush{}, err
+			}
+			r = math.Vec2{X: l, Y: l}
+		}
+		return CreateRadialGradientBrush(math.Vec2{X: v[0], Y: v[1]}, r, spread, stops...), nil
+	}
+	names := [][2]string{{"x1", "0%"}, {"y1", "0%"}, {"x2", "100%"}, {"y2", "0%"}}
+	for i, n := range names {
+		if v[i], err = coord(n[0], n[1], i%2); err != nil {
+			return Brush{}, err
+		}
+	}
+	return CreateLinearGradientBrush(
+		math.Vec2{X: v[0], Y: v[1]},
+		math.Vec2{X: v[2], Y: v[3]},
+		spread, stops...), nil
+}
+
+// svgPathBounds returns the minimum point and size of the bounds of the
+// path's points and control points.
+func svgPathBounds(p Path2D) (min, size math.Vec2) {
+	first := true
+	var max math.Vec2
+	for _, s := range p.Segments {
+		n := 0
+		switch s.Command {
+		case PathMoveTo, PathLineTo:
+			n = 1
+		case PathQuadTo:
+			n = 2
+		case PathCubicTo:
+			n = 3
+		}
+		for _, v := range s.Points[:n] {
+			if first {
+				min, max, first = v, v, false
+			}
+			min = math.Vec2{X: math.Minf(min.X, v.X), Y: math.Minf(min.Y, v.Y)}
+			max = math.Vec2{X: math.Maxf(max.X, v.X), Y: math.Maxf(max.Y, v.Y)}
+		}
+	}
+	return min, max.Sub(min)
+}
+
+// svgNamedColors holds the ARGB values of the commonly used SVG color
+// keywords.
+var svgNamedColors = map[string]uint32{
+	"black":       0xff000000,
+	"silver":      0xffc0c0c0,
+	"gray":        0xff808080,
+	"grey":        0xff808080,
+	"darkgray":    0xffa9a9a9,
+	"darkgrey":    0xffa9a9a9,
+	"lightgray":   0xffd3d3d3,
+	"lightgrey":   0xffd3d3d3,
+	"white":       0xffffffff,
+	"maroon":      0xff800000,
+	"red":         0xffff0000,
+	"purple":      0xff800080,
+	"fuchsia":     0xffff00ff,
+	"magenta":     0xffff00ff,
+	"green":       0xff008000,
+	"lime":        0xff00ff00,
+	"olive":       0xff808000,
+	"yellow":      0xffffff00,
+	"navy":        0xff000080,
+	"blue":        0xff0000ff,
+	"teal":        0xff008080,
+	"aqua":        0xff00ffff,
+	"cyan":        0xff00ffff,
+	"orange":      0xffffa500,
+	"gold":        0xffffd700,
+	"brown":       0xffa52a2a,
+	"pink":        0xffffc0cb,
+	"transparent": 0x00000000,
+}
+
+// parseSVGColor parses a color keyword, a #rgb or #rrggbb hex color, an
+// rgb() or rgba() function, or currentColor, which is replaced with current.
+func parseSVGColor(str string, current Color) (Color, error) {
+	str = strings.TrimSpace(str)
+	switch {
+	case str == "currentColor":
+		return current, nil
+	case strings.HasPrefix(str, "#"):
+		hex := str[1:]
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		v, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil || len(hex) != 6 {
+			return Color{}, fmt.Errorf("invalid color '%s'", str)
+		}
+		return ColorFromHex(0xff000000 | uint32(v)), nil
+	case strings.HasPrefix(str, "rgb(") || strings.HasPrefix(str, "rgba("):
+		open, end := strings.Index(str, "("), strings.LastIndex(str, ")")
+		if end < open {
+			return Color{}, fmt.Errorf("invalid color '%s'", str)
+		}
+		args := strings.Split(str[open+1:end], ",")
+		if len(args) != 3 && len(args) != 4 {
+			return Color{}, fmt.Errorf("invalid color '%s'", str)
+		}
+		v := [4]float32{0, 0, 0, 1}
+		for i, a := range args {
+			a = strings.TrimSpace(a)
+			f, err := parseSVGFraction(a)
+			if err != nil {
+				return Color{}, fmt.Errorf("invalid color '%s'", str)
+			}
+			if i < 3 && !strings.HasSuffix(a, "%") {
+				f /= 255
+			}
+			v[i] = math.Clampf(f, 0, 1)
+		}
+		return Color{R: v[0], G: v[1], B: v[2], A: v[3]}, nil
+	}
+	if v, ok := svgNamedColors[strings.ToLower(str)]; ok {
+		return ColorFromHex(v), nil
+	}
+	return Color{}, fmt.Errorf("unsupported color '%s'", str)
+}
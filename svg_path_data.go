@@ -0,0 +1,336 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/google/gxui/math"
+)
+
+// svgScanner reads the numbers, flags and commands of SVG attribute values
+// such as path data, point lists and transform lists.
+type svgScanner struct {
+	s string
+	i int
+}
+
+func isSVGSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+func isSVGDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isSVGLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// skip skips whitespace and commas, returning false at the end of the string.
+func (s *svgScanner) skip() bool {
+	for s.i < len(s.s) && (isSVGSpace(s.s[s.i]) || s.s[s.i] == ',') {
+		s.i++
+	}
+	return s.i < len(s.s)
+}
+
+// hasNumber returns true if the next token is a number.
+func (s *svgScanner) hasNumber() bool {
+	if !s.skip() {
+		return false
+	}
+	c := s.s[s.i]
+	return isSVGDigit(c) || c == '-' || c == '+' || c == '.'
+}
+
+func (s *svgScanner) error(expected string) error {
+	if s.i >= len(s.s) {
+		return fmt.Errorf("expected %s at end of '%s'", expected, s.s)
+	}
+	return fmt.Errorf("expected %s at offset %d of '%s'", expected, s.i, s.s)
+}
+
+func (s *svgScanner) number() (float32, error) {
+	if !s.hasNumber() {
+		return 0, s.error("number")
+	}
+	start := s.i
+	if c := s.s[s.i]; c == '-' || c == '+' {
+		s.i++
+	}
+	digits := 0
+	for ; s.i < len(s.s) && isSVGDigit(s.s[s.i]); s.i++ {
+		digits++
+	}
+	if s.i < len(s.s) && s.s[s.i] == '.' {
+		for s.i++; s.i < len(s.s) && isSVGDigit(s.s[s.i]); s.i++ {
+			digits++
+		}
+	}
+	if digits == 0 {
+		s.i = start
+		return 0, s.error("number")
+	}
+	if s.i < len(s.s) && (s.s[s.i] == 'e' || s.s[s.i] == 'E') {
+		e := s.i + 1
+		if e < len(s.s) && (s.s[e] == '-' || s.s[e] == '+') {
+			e++
+		}
+		if e < len(s.s) && isSVGDigit(s.s[e]) {
+			for s.i = e; s.i < len(s.s) && isSVGDigit(s.s[s.i]); s.i++ {
+			}
+		}
+	}
+	f, err := strconv.ParseFloat(s.s[start:s.i], 32)
+	if err != nil {
+		return 0, err
+	}
+	return float32(f), nil
+}
+
+// numbers reads n numbers.
+func (s *svgScanner) numbers(n int) ([]float32, error) {
+	v := make([]float32, n)
+	for i := range v {
+		f, err := s.number()
+		if err != nil {
+			return nil, err
+		}
+		v[i] = f
+	}
+	return v, nil
+}
+
+// flag reads an arc flag, which may not be followed by a separator.
+func (s *svgScanner) flag() (bool, error) {
+	if !s.skip() || (s.s[s.i] != '0' && s.s[s.i] != '1') {
+		return false, s.error("flag")
+	}
+	s.i++
+	return s.s[s.i-1] == '1', nil
+}
+
+// parseSVGNumbers parses a list of numbers separated by whitespace or commas.
+func parseSVGNumbers(str string) ([]float32, error) {
+	s := svgScanner{s: str}
+	v := []float32{}
+	for s.skip() {
+		f, err := s.number()
+		if err != nil {
+			return nil, err
+		}
+		v = append(v, f)
+	}
+	return v, nil
+}
+
+// parseSVGPoints parses the points attribute of a polyline or polygon into a
+// path.
+func parseSVGPoints(str string, closed bool) (Path2D, error) {
+	v, err := parseSVGNumbers(str)
+	if err != nil {
+		return Path2D{}, err
+	}
+	p := Path2D{}
+	for i := 0; i+1 < len(v); i += 2 {
+		pt := math.Vec2{X: v[i], Y: v[i+1]}
+		if i == 0 {
+			p.MoveTo(pt)
+		} else {
+			p.LineTo(pt)
+		}
+	}
+	if closed && !p.IsEmpty() {
+		p.Close()
+	}
+	return p, nil
+}
+
+// parseSVGPathData parses the d attribute of a path element. Arcs are
+// converted to cubic Bézier curves.
+func parseSVGPathData(str string) (Path2D, error) {
+	s := svgScanner{s: str}
+	p := Path2D{}
+	var cur, start, ctrl math.Vec2
+	var cmd, last byte
+	for s.skip() {
+		if c := s.s[s.i]; isSVGLetter(c) {
+			cmd = c
+			s.i++
+		} else if cmd == 0 {
+			return Path2D{}, s.error("command")
+		}
+		rel := cmd >= 'a'
+		abs := func(x, y float32) math.Vec2 {
+			if rel {
+				return math.Vec2{X: cur.X + x, Y: cur.Y + y}
+			}
+			return math.Vec2{X: x, Y: y}
+		}
+		// reflect returns the reflection of the last control point if the
+		// previous command was one of the given curve commands.
+		reflect := func(curves string) math.Vec2 {
+			for i := range curves {
+				if last == curves[i] || last == curves[i]+'a'-'A' {
+					return cur.MulS(2).Sub(ctrl)
+				}
+			}
+			return cur
+		}
+
+		var v []float32
+		var err error
+		switch cmd {
+		case 'Z', 'z':
+			p.Close()
+			cur = start
+		case 'M', 'm':
+			if v, err = s.numbers(2); err == nil {
+				cur = abs(v[0], v[1])
+				start = cur
+				p.MoveTo(cur)
+				// Subsequent coordinate pairs are implicit LineTos.
+				if rel {
+					cmd = 'l'
+				} else {
+					cmd = 'L'
+				}
+			}
+		case 'L', 'l':
+			if v, err = s.numbers(2); err == nil {
+				cur = abs(v[0], v[1])
+				p.LineTo(cur)
+			}
+		case 'H', 'h':
+			if v, err = s.numbers(1); err == nil {
+				cur = math.Vec2{X: abs(v[0], 0).X, Y: cur.Y}
+				p.LineTo(cur)
+			}
+		case 'V', 'v':
+			if v, err = s.numbers(1); err == nil {
+				cur = math.Vec2{X: cur.X, Y: abs(0, v[0]).Y}
+				p.LineTo(cur)
+			}
+		case 'C', 'c':
+			if v, err = s.numbers(6); err == nil {
+				c0, c1, to := abs(v[0], v[1]), abs(v[2], v[3]), abs(v[4], v[5])
+				p.CubicTo(c0, c1, to)
+				ctrl, cur = c1, to
+			}
+		case 'S', 's':
+			if v, err = s.numbers(4); err == nil {
+				c0, c1, to := reflect("CS"), abs(v[0], v[1]), abs(v[2], v[3])
+				p.CubicTo(c0, c1, to)
+				ctrl, cur = c1, to
+			}
+		case 'Q', 'q':
+			if v, err = s.numbers(4); err == nil {
+				c, to := abs(v[0], v[1]), abs(v[2], v[3])
+				p.QuadTo(c, to)
+				ctrl, cur = c, to
+			}
+		case 'T', 't':
+			if v, err = s.numbers(2); err == nil {
+				c, to := reflect("QT"), abs(v[0], v[1])
+				p.QuadTo(c, to)
+				ctrl, cur = c, to
+			}
+		case 'A', 'a':
+			var large, sweep bool
+			if v, err = s.numbers(3); err == nil {
+				if large, err = s.flag(); err == nil {
+					if sweep, err = s.flag(); err == nil {
+						var to []float32
+						if to, err = s.numbers(2); err == nil {
+							end := abs(to[0], to[1])
+							appendSVGEndpointArc(&p, cur, end, v[0], v[1], v[2], large, sweep)
+							cur = end
+						}
+					}
+				}
+			}
+		default:
+			return Path2D{}, fmt.Errorf("unknown path command '%c' in '%s'", cmd, str)
+		}
+		if err != nil {
+			return Path2D{}, err
+		}
+		last = cmd
+		if cmd == 'Z' || cmd == 'z' {
+			cmd = 0
+		}
+	}
+	return p, nil
+}
+
+// appendSVGEndpointArc appends the elliptical arc from the point from to the
+// point to, using the SVG endpoint parameterization with the radii, the X
+// axis rotation in degrees, and the large-arc and sweep flags.
+func appendSVGEndpointArc(p *Path2D, from, to math.Vec2, rx, ry, rotation float32, large, sweep bool) {
+	if from == to {
+		return
+	}
+	rx, ry = math.Absf(rx), math.Absf(ry)
+	if rx == 0 || ry == 0 {
+		p.LineTo(to)
+		return
+	}
+	φ := math.D2R(rotation)
+	sinφ, cosφ := math.Sinf(φ), math.Cosf(φ)
+	h := from.Sub(to).MulS(0.5)
+	x1, y1 := cosφ*h.X+sinφ*h.Y, -sinφ*h.X+cosφ*h.Y
+
+	// Scale up radii that are too small to span the end points.
+	if λ := x1*x1/(rx*rx) + y1*y1/(ry*ry); λ > 1 {
+		rx, ry = rx*math.Sqrtf(λ), ry*math.Sqrtf(λ)
+	}
+	num := rx*rx*ry*ry - rx*rx*y1*y1 - ry*ry*x1*x1
+	den := rx*rx*y1*y1 + ry*ry*x1*x1
+	k := math.Sqrtf(math.Maxf(num/den, 0))
+	if large == sweep {
+		k = -k
+	}
+	cx, cy := k*rx*y1/ry, -k*ry*x1/rx
+	mid := from.Add(to).MulS(0.5)
+	center := math.Vec2{X: cosφ*cx - sinφ*cy + mid.X, Y: sinφ*cx + cosφ*cy + mid.Y}
+
+	θ1 := math.Atan2f((y1-cy)/ry, (x1-cx)/rx)
+	θ2 := math.Atan2f((-y1-cy)/ry, (-x1-cx)/rx)
+	Δθ := θ2 - θ1
+	switch {
+	case sweep && Δθ < 0:
+		Δθ += 2 * math.Pi
+	case !sweep && Δθ > 0:
+		Δθ -= 2 * math.Pi
+	}
+	appendSVGArc(p, center, math.Vec2{X: rx, Y: ry}, φ, θ1, Δθ)
+}
+
+// appendSVGArc appends an arc of the ellipse with the center, radii and
+// rotation in radians, from the angle start sweeping by sweep, as cubic
+// Bézier curves of at most a quarter turn each.
+func appendSVGArc(p *Path2D, center, radii math.Vec2, rotation, start, sweep float32) {
+	sinφ, cosφ := math.Sinf(rotation), math.Cosf(rotation)
+	at := func(u math.Vec2) math.Vec2 {
+		x, y := u.X*radii.X, u.Y*radii.Y
+		return math.Vec2{X: center.X + cosφ*x - sinφ*y, Y: center.Y + sinφ*x + cosφ*y}
+	}
+	n := int(math.Ceilf(math.Absf(sweep)/(math.Pi/2) - 0.001))
+	if n < 1 {
+		n = 1
+	}
+	step := sweep / float32(n)
+	k := 4.0 / 3.0 * math.Tanf(step/4)
+	for i := 0; i < n; i++ {
+		a, b := start+step*float32(i), start+step*float32(i+1)
+		ea := math.Vec2{X: math.Cosf(a), Y: math.Sinf(a)}
+		eb := math.Vec2{X: math.Cosf(b), Y: math.Sinf(b)}
+		c0 := ea.Add(math.Vec2{X: -ea.Y, Y: ea.X}.MulS(k))
+		c1 := eb.Sub(math.Vec2{X: -eb.Y, Y: eb.X}.MulS(k))
+		p.CubicTo(at(c0), at(c1), at(eb))
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"testing"
+
+	"github.com/google/gxui/math"
+	test "github.com/google/gxui/testing"
+)
+
+func TestParseSVGPathData(t *testing.T) {
+	p, err := parseSVGPathData("M10,20 l5-5h10 V0q5,5 10,0t10 0 z m1 1")
+	test.AssertEquals(t, nil, err)
+	test.AssertEquals(t, Path2D{Segments: []PathSegment{
+		{Command: PathMoveTo, Points: [3]math.Vec2{{X: 10, Y: 20}}},
+		{Command: PathLineTo, Points: [3]math.Vec2{{X: 15, Y: 15}}},
+		{Command: PathLineTo, Points: [3]math.Vec2{{X: 25, Y: 15}}},
+		{Command: PathLineTo, Points: [3]math.Vec2{{X: 25, Y: 0}}},
+		{Command: PathQuadTo, Points: [3]math.Vec2{{X: 30, Y: 5}, {X: 35, Y: 0}}},
+		{Command: PathQuadTo, Points: [3]math.Vec2{{X: 40, Y: -5}, {X: 45, Y: 0}}},
+		{Command: PathClose},
+		{Command: PathMoveTo, Points: [3]math.Vec2{{X: 11, Y: 21}}},
+	}}, p)
+}
+
+func TestParseSVGPathDataArc(t *testing.T) {
+	// A half circle of radius 10 from (0, 0) to (20, 0), with the flags
+	// written without separators.
+	p, err := parseSVGPathData("M0 0a10 10 0 0120 0")
+	test.AssertEquals(t, nil, err)
+	test.AssertEquals(t, 3, len(p.Segments))
+	for _, s := range p.Segments[1:] {
+		test.AssertEquals(t, PathCubicTo, s.Command)
+	}
+	end := p.Segments[2].Points[2]
+	test.AssertEquals(t, true, end.Sub(math.Vec2{X: 20}).Len() < 0.001)
+	mid := p.Segments[1].Points[2]
+	test.AssertEquals(t, true, mid.Sub(math.Vec2{X: 10, Y: -10}).Len() < 0.001)
+}
+
+func TestParseSVGPathDataErrors(t *testing.T) {
+	for _, d := range []string{"10 20", "M10", "M0 0 A1 1 0 2 0 5 5", "M0 0 X"} {
+		_, err := parseSVGPathData(d)
+		test.AssertEquals(t, true, err != nil)
+	}
+}
+
+func TestParseSVGTransform(t *testing.T) {
+	m, err := parseSVGTransform("translate(10, 20) scale(2)")
+	test.AssertEquals(t, nil, err)
+	p := math.Vec2{X: 1, Y: 1}.Vec3(1).MulM(m).XY()
+	test.AssertEquals(t, math.Vec2{X: 12, Y: 22}, p)
+
+	m, err = parseSVGTransform("rotate(90 10 10)")
+	test.AssertEquals(t, nil, err)
+	p = math.Vec2{X: 20, Y: 10}.Vec3(1).MulM(m).XY()
+	test.AssertEquals(t, true, p.Sub(math.Vec2{X: 10, Y: 20}).Len() < 0.001)
+}
+
+func TestParseSVG(t *testing.T) {
+	s, err := ParseSVG([]byte(`
+<svg xmlns="http://www.w3.org/2000/svg" width="48" height="24" viewBox="0 0 24 12">
+  <defs>
+    <linearGradient id="g" x1="0" y1="0" x2="0" y2="1">
+      <stop offset="0" stop-color="#f00"/>
+      <stop offset="100%" style="stop-color: blue; stop-opacity: 0.5"/>
+    </linearGradient>
+  </defs>
+  <title>Ignored</title>
+  <g fill="url(#g)" stroke="currentColor" color="white" opacity="0.5">
+    <rect width="10" height="10" stroke-width="2"/>
+    <circle cx="18" cy="6" r="4" fill="none" display="none"/>
+    <circle cx="18" cy="6" r="4" fill="none" stroke-dasharray="1 2"/>
+  </g>
+</svg>`))
+	test.AssertEquals(t, nil, err)
+	test.AssertEquals(t, math.Size{W: 48, H: 24}, s.Size())
+	test.AssertEquals(t, math.Vec2{X: 24, Y: 12}, s.ViewBoxSize)
+	test.AssertEquals(t, 2, len(s.Shapes))
+
+	rect := s.Shapes[0]
+	test.AssertEquals(t, Color{1, 1, 1, 0.5}, rect.Pen.Color)
+	test.AssertEquals(t, float32(2), rect.Pen.Width)
+	g := rect.Brush.Gradient
+	test.AssertEquals(t, true, g != nil)
+	test.AssertEquals(t, math.Vec2{X: 0, Y: 1}, g.End)
+	test.AssertEquals(t, []GradientStop{
+		{Offset: 0, Color: Color{1, 0, 0, 0.5}},
+		{Offset: 1, Color: Color{0, 0, 1, 0.25}},
+	}, g.Stops)
+
+	circle := s.Shapes[1]
+	test.AssertEquals(t, true, circle.Brush.Transparent())
+	test.AssertEquals(t, []float32{1, 2}, circle.Pen.Dash.Lengths)
+}
+
+func TestParseSVGErrors(t *testing.T) {
+	for _, doc := range []string{
+		`<g/>`,
+		`<svg><path d="M0 0 L"/></svg>`,
+		`<svg><rect fill="bogus" width="1" height="1"/></svg>`,
+		`<svg viewBox="0 0 1"/>`,
+	} {
+		_, err := ParseSVG([]byte(doc))
+		test.AssertEquals(t, true, err != nil)
+	}
+}
+
+func TestSVGViewTransform(t *testing.T) {
+	s := &SVG{
+		ViewBoxSize: math.Vec2{X: 10, Y: 10},
+		AspectAlign: math.Vec2{X: 0.5, Y: 0.5},
+	}
+	m := s.ViewTransform(math.CreateRect(0, 0, 40, 20))
+	test.AssertEquals(t, math.Vec2{X: 10, Y: 0}, math.Vec2{}.Vec3(1).MulM(m).XY())
+	test.AssertEquals(t, math.Vec2{X: 30, Y: 20}, math.Vec2{X: 10, Y: 10}.Vec3(1).MulM(m).XY())
+
+	s.AspectNone = true
+	m = s.ViewTransform(math.CreateRect(0, 0, 40, 20))
+	test.AssertEquals(t, math.Vec2{X: 40, Y: 20}, math.Vec2{X: 10, Y: 10}.Vec3(1).MulM(m).XY())
+}
@@ -6,7 +6,6 @@ package basic
 
 import (
 	"github.com/google/gxui"
-	"github.com/google/gxui/math"
 	"github.com/google/gxui/mixins"
 )
 
@@ -18,10 +17,32 @@ type BubbleOverlay struct {
 func CreateBubbleOverlay(theme *Theme) gxui.BubbleOverlay {
 	b := &BubbleOverlay{}
 	b.Init(b, theme)
-	b.SetMargin(math.Spacing{L: 3, T: 3, R: 3, B: 3})
-	b.SetPadding(math.Spacing{L: 5, T: 5, R: 5, B: 5})
-	b.SetPen(theme.BubbleOverlayStyle.Pen)
-	b.SetBrush(theme.BubbleOverlayStyle.Brush)
+	b.SetMargin(theme.Metrics.BubbleOverlayMargin)
+	b.SetPadding(theme.Metrics.BubbleOverlayPadding)
 	b.theme = theme
+	b.updateStyle()
+	b.OnStyleChanged(b.updateStyle)
+	onThemeChanged(b, b.themeChanged)
 	return b
 }
+
+func (b *BubbleOverlay) updateStyle() {
+	style := b.theme.ResolveStyle(b, "BubbleOverlay", gxui.StyleDefault, b.theme.BubbleOverlayStyle)
+	b.SetPen(style.Pen)
+	b.SetBrush(style.Brush)
+	b.SetShadow(style.Shadow)
+}
+
+// themeChanged updates the properties of the overlay that were set from the
+// previous theme.
+func (b *BubbleOverlay) themeChanged(theme *Theme) {
+	old := b.theme
+	b.theme = theme
+	if b.Margin() == old.Metrics.BubbleOverlayMargin {
+		b.SetMargin(theme.Metrics.BubbleOverlayMargin)
+	}
+	if b.Padding() == old.Metrics.BubbleOverlayPadding {
+		b.SetPadding(theme.Metrics.BubbleOverlayPadding)
+	}
+	b.updateStyle()
+}
@@ -6,7 +6,6 @@ package basic
 
 import (
 	"github.com/google/gxui"
-	"github.com/google/gxui/math"
 	"github.com/google/gxui/mixins"
 )
 
@@ -19,57 +18,70 @@ func CreateButton(theme *Theme) gxui.Button {
 	b := &Button{}
 	b.Init(b, theme)
 	b.theme = theme
-	b.SetPadding(math.Spacing{L: 3, T: 3, R: 3, B: 3})
-	b.SetMargin(math.Spacing{L: 3, T: 3, R: 3, B: 3})
+	b.SetPadding(theme.Metrics.ButtonPadding)
+	b.SetMargin(theme.Metrics.ButtonMargin)
 	b.SetBackgroundBrush(b.theme.ButtonDefaultStyle.Brush)
 	b.SetBorderPen(b.theme.ButtonDefaultStyle.Pen)
-	b.OnMouseEnter(func(gxui.MouseEvent) { b.Redraw() })
-	b.OnMouseExit(func(gxui.MouseEvent) { b.Redraw() })
-	b.OnMouseDown(func(gxui.MouseEvent) { b.Redraw() })
-	b.OnMouseUp(func(gxui.MouseEvent) { b.Redraw() })
-	b.OnGainedFocus(b.Redraw)
-	b.OnLostFocus(b.Redraw)
+	onStyleStatesChanged(b, b.Redraw)
+	onThemeChanged(b, b.themeChanged)
 	return b
 }
 
 // Button internal overrides
 func (b *Button) Paint(c gxui.Canvas) {
-	pen := b.Button.BorderPen()
-	brush := b.Button.BackgroundBrush()
-	fontColor := b.theme.ButtonDefaultStyle.FontColor
+	states := styleStates(b)
+	style := b.theme.ButtonDefaultStyle
+	style.Pen = b.Button.BorderPen()
+	style.Brush = b.Button.BackgroundBrush()
 
 	switch {
-	case b.IsMouseDown(gxui.MouseButtonLeft) && b.IsMouseOver():
-		pen = b.theme.ButtonPressedStyle.Pen
-		brush = b.theme.ButtonPressedStyle.Brush
-		fontColor = b.theme.ButtonPressedStyle.FontColor
-	case b.IsMouseOver():
-		pen = b.theme.ButtonOverStyle.Pen
-		brush = b.theme.ButtonOverStyle.Brush
-		fontColor = b.theme.ButtonOverStyle.FontColor
+	case states&gxui.StyleDisabled != 0:
+		style = b.theme.ButtonDisabledStyle
+	case states&gxui.StylePressed != 0:
+		style = b.theme.ButtonPressedStyle
+	case states&gxui.StyleOver != 0:
+		style = b.theme.ButtonOverStyle
 	}
+	style = b.theme.ResolveStyle(b, "Button", states, style)
 
 	if l := b.Label(); l != nil {
-		l.SetColor(fontColor)
+		l.SetColor(style.FontColor)
 	}
 
 	r := b.Size().Rect()
 
-	c.DrawRoundedRect(r, 2, 2, 2, 2, gxui.TransparentPen, brush)
+	style.PaintBackground(c, r, 2, 2, 2, 2)
 
 	b.PaintChildren.Paint(c)
 
-	c.DrawRoundedRect(r, 2, 2, 2, 2, pen, gxui.TransparentBrush)
+	style.PaintBorder(c, r, 2, 2, 2, 2)
 
 	if b.IsChecked() {
-		pen = b.theme.HighlightStyle.Pen
-		brush = b.theme.HighlightStyle.Brush
-		c.DrawRoundedRect(r, 2.0, 2.0, 2.0, 2.0, pen, brush)
+		style = b.theme.HighlightStyle
+		c.DrawRoundedRect(r, 2.0, 2.0, 2.0, 2.0, style.Pen, style.Brush)
 	}
 
 	if b.HasFocus() {
-		pen = b.theme.FocusedStyle.Pen
-		brush = b.theme.FocusedStyle.Brush
-		c.DrawRoundedRect(r.ContractI(int(pen.Width)), 3.0, 3.0, 3.0, 3.0, pen, brush)
+		style = b.theme.FocusedStyle
+		c.DrawRoundedRect(r.ContractI(int(style.Pen.Width)), 3.0, 3.0, 3.0, 3.0, style.Pen, style.Brush)
+	}
+}
+
+// themeChanged updates the properties of the button that were set from the
+// previous theme.
+func (b *Button) themeChanged(theme *Theme) {
+	old := b.theme
+	b.theme = theme
+	if b.Padding() == old.Metrics.ButtonPadding {
+		b.SetPadding(theme.Metrics.ButtonPadding)
+	}
+	if b.Margin() == old.Metrics.ButtonMargin {
+		b.SetMargin(theme.Metrics.ButtonMargin)
+	}
+	if b.BackgroundBrush() == old.ButtonDefaultStyle.Brush {
+		b.SetBackgroundBrush(theme.ButtonDefaultStyle.Brush)
+	}
+	if b.BorderPen() == old.ButtonDefaultStyle.Pen {
+		b.SetBorderPen(theme.ButtonDefaultStyle.Pen)
 	}
 }
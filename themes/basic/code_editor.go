@@ -6,7 +6,6 @@ package basic
 
 import (
 	"github.com/google/gxui"
-	"github.com/google/gxui/math"
 	"github.com/google/gxui/mixins"
 )
 
@@ -20,9 +19,10 @@ func CreateCodeEditor(theme *Theme) gxui.CodeEditor {
 	t.theme = theme
 	t.Init(t, theme.Driver(), theme, theme.DefaultMonospaceFont())
 	t.SetTextColor(theme.TextBoxDefaultStyle.FontColor)
-	t.SetMargin(math.Spacing{L: 3, T: 3, R: 3, B: 3})
-	t.SetPadding(math.Spacing{L: 3, T: 3, R: 3, B: 3})
+	t.SetMargin(theme.Metrics.CodeEditorMargin)
+	t.SetPadding(theme.Metrics.CodeEditorPadding)
 	t.SetBorderPen(gxui.TransparentPen)
+	onThemeChanged(t, t.themeChanged)
 
 	return t
 }
@@ -43,3 +43,22 @@ func (t *CodeEditor) CreateSuggestionList() gxui.List {
 	l.SetBorderPen(t.theme.CodeSuggestionListStyle.Pen)
 	return l
 }
+
+// themeChanged updates the properties of the code editor that were set from
+// the previous theme.
+func (t *CodeEditor) themeChanged(theme *Theme) {
+	old := t.theme
+	t.theme = theme
+	if t.Font() == old.DefaultMonospaceFont() {
+		t.SetFont(theme.DefaultMonospaceFont())
+	}
+	if t.TextColor() == old.TextBoxDefaultStyle.FontColor {
+		t.SetTextColor(theme.TextBoxDefaultStyle.FontColor)
+	}
+	if t.Margin() == old.Metrics.CodeEditorMargin {
+		t.SetMargin(theme.Metrics.CodeEditorMargin)
+	}
+	if t.Padding() == old.Metrics.CodeEditorPadding {
+		t.SetPadding(theme.Metrics.CodeEditorPadding)
+	}
+}
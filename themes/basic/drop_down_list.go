@@ -22,19 +22,28 @@ func CreateDropDownList(theme *Theme) gxui.DropDownList {
 	l.OnLostFocus(l.Redraw)
 	l.List().OnAttach(l.Redraw)
 	l.List().OnDetach(l.Redraw)
-	l.OnMouseEnter(func(gxui.MouseEvent) {
-		l.SetBorderPen(theme.DropDownListOverStyle.Pen)
-	})
-	l.OnMouseExit(func(gxui.MouseEvent) {
-		l.SetBorderPen(theme.DropDownListDefaultStyle.Pen)
-	})
-	l.SetPadding(math.CreateSpacing(2))
-	l.SetBorderPen(theme.DropDownListDefaultStyle.Pen)
-	l.SetBackgroundBrush(theme.DropDownListDefaultStyle.Brush)
+	l.SetPadding(theme.Metrics.ListPadding)
 	l.theme = theme
+	l.updateStyle()
+	onStyleStatesChanged(l, l.updateStyle)
+	onThemeChanged(l, l.themeChanged)
 	return l
 }
 
+func (l *DropDownList) updateStyle() {
+	states := styleStates(l)
+	style := l.theme.DropDownListDefaultStyle
+	switch {
+	case states&gxui.StyleDisabled != 0:
+		style = l.theme.DropDownListDisabledStyle
+	case states&gxui.StyleOver != 0:
+		style = l.theme.DropDownListOverStyle
+	}
+	style = l.theme.ResolveStyle(l, "DropDownList", states, style)
+	l.SetBackgroundBrush(style.Brush)
+	l.SetBorderPen(style.Pen)
+}
+
 // mixin.List overrides
 func (l *DropDownList) Paint(c gxui.Canvas) {
 	l.DropDownList.Paint(c)
@@ -47,3 +56,14 @@ func (l *DropDownList) Paint(c gxui.Canvas) {
 func (l *DropDownList) DrawSelection(c gxui.Canvas, r math.Rect) {
 	c.DrawRoundedRect(r, 2.0, 2.0, 2.0, 2.0, l.theme.HighlightStyle.Pen, l.theme.HighlightStyle.Brush)
 }
+
+// themeChanged updates the properties of the list that were set from the
+// previous theme.
+func (l *DropDownList) themeChanged(theme *Theme) {
+	old := l.theme
+	l.theme = theme
+	if l.Padding() == old.Metrics.ListPadding {
+		l.SetPadding(theme.Metrics.ListPadding)
+	}
+	l.updateStyle()
+}
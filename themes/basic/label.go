@@ -6,13 +6,47 @@ package basic
 
 import (
 	"github.com/google/gxui"
-	"github.com/google/gxui/math"
 	"github.com/google/gxui/mixins"
 )
 
+// LinkStyleClass is the style class of labels that act as links. Labels with
+// the class show the hand cursor.
+const LinkStyleClass = "link"
+
 func CreateLabel(theme *Theme) gxui.Label {
 	l := &mixins.Label{}
 	l.Init(l, theme, theme.DefaultFont(), theme.LabelStyle.FontColor)
-	l.SetMargin(math.Spacing{L: 3, T: 3, R: 3, B: 3})
+	l.SetMargin(theme.Metrics.LabelMargin)
+	color := func(theme *Theme) gxui.Color {
+		if !l.IsEnabled() {
+			return theme.ResolveStyle(l, "Label", gxui.StyleDisabled, theme.LabelDisabledStyle).FontColor
+		}
+		return theme.ResolveStyle(l, "Label", gxui.StyleDefault, theme.LabelStyle).FontColor
+	}
+	updateColor := func() { l.SetColor(color(l.Theme().(*Theme))) }
+	l.OnStyleChanged(updateColor)
+	hand := gxui.CreateCursor(gxui.HandCursor)
+	l.OnStyleChanged(func() {
+		switch {
+		case l.HasStyleClass(LinkStyleClass):
+			l.SetCursor(hand)
+		case l.Cursor() == hand:
+			l.SetCursor(gxui.Cursor{})
+		}
+	})
+	l.OnEnabledChanged(updateColor)
+	// Update the properties that were set from the previous theme.
+	onThemeChanged(l, func(new *Theme) {
+		if l.Font() == theme.DefaultFont() {
+			l.SetFont(new.DefaultFont())
+		}
+		if l.Color() == color(theme) {
+			l.SetColor(color(new))
+		}
+		if l.Margin() == theme.Metrics.LabelMargin {
+			l.SetMargin(new.Metrics.LabelMargin)
+		}
+		theme = new
+	})
 	return l
 }
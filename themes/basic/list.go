@@ -20,9 +20,10 @@ func CreateList(theme *Theme) gxui.List {
 	l.Init(l, theme)
 	l.OnGainedFocus(l.Redraw)
 	l.OnLostFocus(l.Redraw)
-	l.SetPadding(math.CreateSpacing(2))
+	l.SetPadding(theme.Metrics.ListPadding)
 	l.SetBorderPen(gxui.TransparentPen)
 	l.theme = theme
+	onThemeChanged(l, l.themeChanged)
 	return l
 }
 
@@ -42,3 +43,13 @@ func (l *List) PaintSelection(c gxui.Canvas, r math.Rect) {
 func (l *List) PaintMouseOverBackground(c gxui.Canvas, r math.Rect) {
 	c.DrawRoundedRect(r, 2.0, 2.0, 2.0, 2.0, gxui.TransparentPen, gxui.CreateBrush(gxui.Gray15))
 }
+
+// themeChanged updates the properties of the list that were set from the
+// previous theme.
+func (l *List) themeChanged(theme *Theme) {
+	old := l.theme
+	l.theme = theme
+	if l.Padding() == old.Metrics.ListPadding {
+		l.SetPadding(theme.Metrics.ListPadding)
+	}
+}
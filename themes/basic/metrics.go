@@ -0,0 +1,46 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package basic
+
+import (
+	"github.com/google/gxui/math"
+)
+
+// Metrics holds the sizes used to lay out the theme's controls, in DIPs.
+// Metrics are applied when a control is created.
+type Metrics struct {
+	BubbleOverlayMargin  math.Spacing
+	BubbleOverlayPadding math.Spacing
+	ButtonMargin         math.Spacing
+	ButtonPadding        math.Spacing
+	CodeEditorMargin     math.Spacing
+	CodeEditorPadding    math.Spacing
+	LabelMargin          math.Spacing
+	ListPadding          math.Spacing // Also used by DropDownList.
+	PanelHolderMargin    math.Spacing
+	PanelTabPadding      math.Spacing
+	TextBoxMargin        math.Spacing
+	TextBoxPadding       math.Spacing
+	TreePadding          math.Spacing
+	TreeIndent           int // The indentation of each level of a Tree.
+}
+
+// DefaultMetrics are the metrics used by the standard themes.
+var DefaultMetrics = Metrics{
+	BubbleOverlayMargin:  math.CreateSpacing(3),
+	BubbleOverlayPadding: math.CreateSpacing(5),
+	ButtonMargin:         math.CreateSpacing(3),
+	ButtonPadding:        math.CreateSpacing(3),
+	CodeEditorMargin:     math.CreateSpacing(3),
+	CodeEditorPadding:    math.CreateSpacing(3),
+	LabelMargin:          math.CreateSpacing(3),
+	ListPadding:          math.CreateSpacing(2),
+	PanelHolderMargin:    math.Spacing{L: 0, T: 2, R: 0, B: 0},
+	PanelTabPadding:      math.Spacing{L: 5, T: 3, R: 5, B: 3},
+	TextBoxMargin:        math.CreateSpacing(3),
+	TextBoxPadding:       math.CreateSpacing(3),
+	TreePadding:          math.CreateSpacing(3),
+	TreeIndent:           16,
+}
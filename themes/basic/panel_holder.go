@@ -6,7 +6,6 @@ package basic
 
 import (
 	"github.com/google/gxui"
-	"github.com/google/gxui/math"
 	"github.com/google/gxui/mixins"
 )
 
@@ -19,7 +18,13 @@ func CreatePanelHolder(theme *Theme) gxui.PanelHolder {
 	p := &PanelHolder{}
 	p.PanelHolder.Init(p, theme)
 	p.theme = theme
-	p.SetMargin(math.Spacing{L: 0, T: 2, R: 0, B: 0})
+	p.SetMargin(theme.Metrics.PanelHolderMargin)
+	onThemeChanged(p, func(new *Theme) {
+		if p.Margin() == p.theme.Metrics.PanelHolderMargin {
+			p.SetMargin(new.Metrics.PanelHolderMargin)
+		}
+		p.theme = new
+	})
 	return p
 }
 
@@ -31,7 +36,9 @@ func (p *PanelHolder) Paint(c gxui.Canvas) {
 	panel := p.SelectedPanel()
 	if panel != nil {
 		bounds := p.Children().Find(panel).Bounds()
-		c.DrawRoundedRect(bounds, 0.0, 0.0, 3.0, 3.0, p.theme.PanelBackgroundStyle.Pen, p.theme.PanelBackgroundStyle.Brush)
+		style := p.theme.PanelBackgroundStyle
+		style.PaintBackground(c, bounds, 0.0, 0.0, 3.0, 3.0)
+		style.PaintBorder(c, bounds, 0.0, 0.0, 3.0, 3.0)
 	}
 	p.PanelHolder.Paint(c)
 }
@@ -20,13 +20,14 @@ func CreatePanelTab(theme *Theme) mixins.PanelTab {
 	t := &PanelTab{}
 	t.Button.Init(t, theme)
 	t.theme = theme
-	t.SetPadding(math.Spacing{L: 5, T: 3, R: 5, B: 3})
-	t.OnMouseEnter(func(gxui.MouseEvent) { t.Redraw() })
-	t.OnMouseExit(func(gxui.MouseEvent) { t.Redraw() })
-	t.OnMouseDown(func(gxui.MouseEvent) { t.Redraw() })
-	t.OnMouseUp(func(gxui.MouseEvent) { t.Redraw() })
-	t.OnGainedFocus(t.Redraw)
-	t.OnLostFocus(t.Redraw)
+	t.SetPadding(theme.Metrics.PanelTabPadding)
+	onStyleStatesChanged(t, t.Redraw)
+	onThemeChanged(t, func(new *Theme) {
+		if t.Padding() == t.theme.Metrics.PanelTabPadding {
+			t.SetPadding(new.Metrics.PanelTabPadding)
+		}
+		t.theme = new
+	})
 	return t
 }
 
@@ -37,20 +38,23 @@ func (t *PanelTab) SetActive(active bool) {
 
 func (t *PanelTab) Paint(c gxui.Canvas) {
 	s := t.Size()
+	states := styleStates(t)
 	var style Style
 	switch {
-	case t.IsMouseDown(gxui.MouseButtonLeft) && t.IsMouseOver():
+	case states&gxui.StylePressed != 0:
 		style = t.theme.TabPressedStyle
-	case t.IsMouseOver():
+	case states&gxui.StyleOver != 0:
 		style = t.theme.TabOverStyle
 	default:
 		style = t.theme.TabDefaultStyle
 	}
+	style = t.theme.ResolveStyle(t, "PanelTab", states, style)
 	if l := t.Label(); l != nil {
 		l.SetColor(style.FontColor)
 	}
 
-	c.DrawRoundedRect(s.Rect(), 5.0, 5.0, 0.0, 0.0, style.Pen, style.Brush)
+	style.PaintBackground(c, s.Rect(), 5.0, 5.0, 0.0, 0.0)
+	style.PaintBorder(c, s.Rect(), 5.0, 5.0, 0.0, 0.0)
 
 	if t.HasFocus() {
 		style = t.theme.FocusedStyle
@@ -48,6 +48,7 @@ func CreateProgressBar(theme *Theme) gxui.ProgressBar {
 	})
 	b.SetBackgroundBrush(gxui.CreateBrush(gxui.Gray10))
 	b.SetBorderPen(gxui.CreatePen(1, gxui.Gray40))
+	onThemeChanged(b, func(new *Theme) { b.theme = new })
 	return b
 }
 
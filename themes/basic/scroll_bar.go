@@ -16,26 +16,24 @@ type ScrollBar struct {
 func CreateScrollBar(theme *Theme) gxui.ScrollBar {
 	s := &ScrollBar{}
 	s.ScrollBar.Init(s, theme)
-	s.SetBarBrush(theme.ScrollBarBarDefaultStyle.Brush)
-	s.SetBarPen(theme.ScrollBarBarDefaultStyle.Pen)
-	s.SetRailBrush(theme.ScrollBarRailDefaultStyle.Brush)
-	s.SetRailPen(theme.ScrollBarRailDefaultStyle.Pen)
 	updateColors := func() {
-		switch {
-		case s.IsMouseOver():
-			s.SetBarBrush(theme.ScrollBarBarOverStyle.Brush)
-			s.SetBarPen(theme.ScrollBarBarOverStyle.Pen)
-			s.SetRailBrush(theme.ScrollBarRailOverStyle.Brush)
-			s.SetRailPen(theme.ScrollBarRailOverStyle.Pen)
-		default:
-			s.SetBarBrush(theme.ScrollBarBarDefaultStyle.Brush)
-			s.SetBarPen(theme.ScrollBarBarDefaultStyle.Pen)
-			s.SetRailBrush(theme.ScrollBarRailDefaultStyle.Brush)
-			s.SetRailPen(theme.ScrollBarRailDefaultStyle.Pen)
+		theme := s.Theme().(*Theme)
+		states := styleStates(s)
+		bar, rail := theme.ScrollBarBarDefaultStyle, theme.ScrollBarRailDefaultStyle
+		if states&gxui.StyleOver != 0 {
+			bar, rail = theme.ScrollBarBarOverStyle, theme.ScrollBarRailOverStyle
 		}
+		// The rail is styled by the classes of the ScrollBarRail type.
+		bar = theme.ResolveStyle(s, "ScrollBar", states, bar)
+		rail = theme.ResolveStyle(s, "ScrollBarRail", states, rail)
+		s.SetBarBrush(bar.Brush)
+		s.SetBarPen(bar.Pen)
+		s.SetRailBrush(rail.Brush)
+		s.SetRailPen(rail.Pen)
 		s.Redraw()
 	}
-	s.OnMouseEnter(func(gxui.MouseEvent) { updateColors() })
-	s.OnMouseExit(func(gxui.MouseEvent) { updateColors() })
+	updateColors()
+	onStyleStatesChanged(s, updateColors)
+	s.OnThemeChanged(updateColors)
 	return s
 }
@@ -19,6 +19,7 @@ func CreateSplitterLayout(theme *Theme) gxui.SplitterLayout {
 	l := &SplitterLayout{}
 	l.theme = theme
 	l.Init(l, theme)
+	onThemeChanged(l, func(new *Theme) { l.theme = new })
 	return l
 }
 
@@ -45,5 +46,9 @@ func (l *SplitterLayout) CreateSplitterBar() gxui.Control {
 	b.OnDragStart(func(gxui.MouseEvent) { updateForegroundColor() })
 	b.OnMouseEnter(func(gxui.MouseEvent) { updateForegroundColor() })
 	b.OnMouseExit(func(gxui.MouseEvent) { updateForegroundColor() })
+	b.OnThemeChanged(func() {
+		b.SetBackgroundColor(l.theme.SplitterBarDefaultStyle.Brush.Color)
+		updateForegroundColor()
+	})
 	return b
 }
@@ -6,18 +6,83 @@ package basic
 
 import (
 	"github.com/google/gxui"
+	"github.com/google/gxui/math"
 )
 
 type Style struct {
 	FontColor gxui.Color
 	Brush     gxui.Brush
 	Pen       gxui.Pen
+
+	// Skin, if non-nil, is a bitmap drawn for the background and border
+	// instead of Brush and Pen. Skins are only drawn by the Button and PanelTab
+	// styles, and by PanelBackgroundStyle. Other controls ignore them.
+	Skin *gxui.NinePatch
+
+	// Shadow is cast beneath controls that float above others, such as
+	// overlays, to give them elevation.
+	Shadow gxui.Shadow
 }
 
 func CreateStyle(fontColor, brushColor, penColor gxui.Color, penWidth float32) Style {
+	return CreateBrushStyle(fontColor, gxui.CreateBrush(brushColor), penColor, penWidth)
+}
+
+// CreateBrushStyle returns a Style using the specified brush, which may be a
+// gradient brush.
+func CreateBrushStyle(fontColor gxui.Color, brush gxui.Brush, penColor gxui.Color, penWidth float32) Style {
 	return Style{
 		FontColor: fontColor,
 		Pen:       gxui.CreatePen(penWidth, penColor),
-		Brush:     gxui.CreateBrush(brushColor),
+		Brush:     brush,
+	}
+}
+
+// CreateSkinStyle returns a Style that paints backgrounds and borders with
+// the nine-patch skin.
+func CreateSkinStyle(fontColor gxui.Color, skin gxui.NinePatch) Style {
+	return Style{
+		FontColor: fontColor,
+		Pen:       gxui.TransparentPen,
+		Brush:     gxui.TransparentBrush,
+		Skin:      &skin,
+	}
+}
+
+// PaintBackground draws the skin into r, or if the style has no skin, fills r
+// with the brush using the corner radii.
+func (s Style) PaintBackground(c gxui.Canvas, r math.Rect, tl, tr, bl, br float32) {
+	if s.Skin != nil {
+		c.DrawNinePatch(*s.Skin, r)
+	} else {
+		c.DrawRoundedRect(r, tl, tr, bl, br, gxui.TransparentPen, s.Brush)
+	}
+}
+
+// PaintBorder outlines r with the pen using the corner radii. Skinned styles
+// have no separate border, as the skin is drawn by PaintBackground.
+func (s Style) PaintBorder(c gxui.Canvas, r math.Rect, tl, tr, bl, br float32) {
+	if s.Skin == nil {
+		c.DrawRoundedRect(r, tl, tr, bl, br, s.Pen, gxui.TransparentBrush)
+	}
+}
+
+// Override returns the style with the changes of o applied.
+func (s Style) Override(o gxui.StyleOverride) Style {
+	if o.FontColor != nil {
+		s.FontColor = *o.FontColor
+	}
+	if o.Brush != nil {
+		s.Brush = *o.Brush
+	}
+	if o.Pen != nil {
+		s.Pen = *o.Pen
+	}
+	if o.Skin != nil {
+		s.Skin = o.Skin
+	}
+	if o.Shadow != nil {
+		s.Shadow = *o.Shadow
 	}
+	return s
 }
@@ -0,0 +1,109 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package basic
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/gxui"
+)
+
+// StyleRule holds the changes to the style of the controls matching a
+// selector, for each state.
+type StyleRule map[gxui.StyleState]gxui.StyleOverride
+
+// SetClassStyle sets the changes to the style of the controls matching the
+// selector in the state. The selector is either a class name, such as
+// "primary", which matches controls of any type with the class, or a control
+// type and class name, such as "Button.primary". state must be StyleDefault or
+// a single state flag.
+func (t *Theme) SetClassStyle(selector string, state gxui.StyleState, o gxui.StyleOverride) {
+	if !state.IsSingle() {
+		panic(fmt.Errorf("Class styles must be for a single state, got %v", state))
+	}
+	if selector == "" || strings.Count(selector, ".") > 1 || strings.HasSuffix(selector, ".") {
+		panic(fmt.Errorf("Invalid style selector %q", selector))
+	}
+	if t.Classes == nil {
+		t.Classes = make(map[string]StyleRule)
+	}
+	rule, ok := t.Classes[selector]
+	if !ok {
+		rule = make(StyleRule)
+		t.Classes[selector] = rule
+	}
+	rule[state] = o
+}
+
+// ResolveStyle returns the style of the control c, whose type is named
+// controlType, such as "Button", in the states. base is the theme's style for
+// the control type in the states, such as ButtonOverStyle.
+//
+// Starting from base, the style is changed by the following in order, where
+// later changes take precedence:
+//
+//  1. The rules of the control's classes for any control type.
+//  2. The rules of the control's classes for controlType.
+//  3. The control's style overrides.
+//
+// Each of these is applied for StyleDefault, and then for each of the states
+// in the order focused, over, pressed then disabled. Rules for the same state
+// are applied in the order the classes were added to the control.
+func (t *Theme) ResolveStyle(c gxui.Control, controlType string, states gxui.StyleState, base Style) Style {
+	classes := c.StyleClasses()
+	style := base
+	for _, typed := range []bool{false, true} {
+		for _, state := range states.Cascade() {
+			for _, class := range classes {
+				selector := class
+				if typed {
+					selector = controlType + "." + class
+				}
+				if o, ok := t.Classes[selector][state]; ok {
+					style = style.Override(o)
+				}
+			}
+		}
+	}
+	for _, state := range states.Cascade() {
+		style = style.Override(c.StyleOverride(state))
+	}
+	return style
+}
+
+// styleStates returns the states of the control used to resolve its style.
+// Disabled controls are only in the disabled state.
+func styleStates(c gxui.Control) gxui.StyleState {
+	if !c.IsEnabled() {
+		return gxui.StyleDisabled
+	}
+	var states gxui.StyleState
+	if f, ok := c.(gxui.Focusable); ok && f.HasFocus() {
+		states |= gxui.StyleFocused
+	}
+	if c.IsMouseOver() {
+		states |= gxui.StyleOver
+		if c.IsMouseDown(gxui.MouseButtonLeft) {
+			states |= gxui.StylePressed
+		}
+	}
+	return states
+}
+
+// onStyleStatesChanged calls f whenever the states or style overrides of the
+// control may have changed.
+func onStyleStatesChanged(c gxui.Control, f func()) {
+	c.OnMouseEnter(func(gxui.MouseEvent) { f() })
+	c.OnMouseExit(func(gxui.MouseEvent) { f() })
+	c.OnMouseDown(func(gxui.MouseEvent) { f() })
+	c.OnMouseUp(func(gxui.MouseEvent) { f() })
+	if fc, ok := c.(gxui.Focusable); ok {
+		fc.OnGainedFocus(f)
+		fc.OnLostFocus(f)
+	}
+	c.OnEnabledChanged(f)
+	c.OnStyleChanged(f)
+}
@@ -6,7 +6,6 @@ package basic
 
 import (
 	"github.com/google/gxui"
-	"github.com/google/gxui/math"
 	"github.com/google/gxui/mixins"
 )
 
@@ -18,25 +17,31 @@ type TextBox struct {
 func CreateTextBox(theme *Theme) gxui.TextBox {
 	t := &TextBox{}
 	t.Init(t, theme.Driver(), theme, theme.DefaultFont())
-	t.SetTextColor(theme.TextBoxDefaultStyle.FontColor)
-	t.SetMargin(math.Spacing{L: 3, T: 3, R: 3, B: 3})
-	t.SetPadding(math.Spacing{L: 3, T: 3, R: 3, B: 3})
-	t.SetBackgroundBrush(theme.TextBoxDefaultStyle.Brush)
-	t.SetBorderPen(theme.TextBoxDefaultStyle.Pen)
-	t.OnMouseEnter(func(gxui.MouseEvent) {
-		t.SetBackgroundBrush(theme.TextBoxOverStyle.Brush)
-		t.SetBorderPen(theme.TextBoxOverStyle.Pen)
-	})
-	t.OnMouseExit(func(gxui.MouseEvent) {
-		t.SetBackgroundBrush(theme.TextBoxDefaultStyle.Brush)
-		t.SetBorderPen(theme.TextBoxDefaultStyle.Pen)
-	})
-
+	t.SetMargin(theme.Metrics.TextBoxMargin)
+	t.SetPadding(theme.Metrics.TextBoxPadding)
 	t.theme = theme
+	t.updateStyle()
+	onStyleStatesChanged(t, t.updateStyle)
+	onThemeChanged(t, t.themeChanged)
 
 	return t
 }
 
+func (t *TextBox) updateStyle() {
+	states := styleStates(t)
+	style := t.theme.TextBoxDefaultStyle
+	switch {
+	case states&gxui.StyleDisabled != 0:
+		style = t.theme.TextBoxDisabledStyle
+	case states&gxui.StyleOver != 0:
+		style = t.theme.TextBoxOverStyle
+	}
+	style = t.theme.ResolveStyle(t, "TextBox", states, style)
+	t.SetTextColor(style.FontColor)
+	t.SetBackgroundBrush(style.Brush)
+	t.SetBorderPen(style.Pen)
+}
+
 // mixins.TextBox overrides
 func (t *TextBox) Paint(c gxui.Canvas) {
 	t.TextBox.Paint(c)
@@ -47,3 +52,20 @@ func (t *TextBox) Paint(c gxui.Canvas) {
 		c.DrawRoundedRect(r, 3, 3, 3, 3, s.Pen, s.Brush)
 	}
 }
+
+// themeChanged updates the properties of the text box that were set from the
+// previous theme.
+func (t *TextBox) themeChanged(theme *Theme) {
+	old := t.theme
+	t.theme = theme
+	if t.Font() == old.DefaultFont() {
+		t.SetFont(theme.DefaultFont())
+	}
+	if t.Margin() == old.Metrics.TextBoxMargin {
+		t.SetMargin(theme.Metrics.TextBoxMargin)
+	}
+	if t.Padding() == old.Metrics.TextBoxPadding {
+		t.SetPadding(theme.Metrics.TextBoxPadding)
+	}
+	t.updateStyle()
+}
@@ -15,15 +15,20 @@ type Theme struct {
 
 	WindowBackground gxui.Color
 
+	Metrics Metrics
+
 	BubbleOverlayStyle        Style
 	ButtonDefaultStyle        Style
+	ButtonDisabledStyle       Style
 	ButtonOverStyle           Style
 	ButtonPressedStyle        Style
 	CodeSuggestionListStyle   Style
 	DropDownListDefaultStyle  Style
+	DropDownListDisabledStyle Style
 	DropDownListOverStyle     Style
 	FocusedStyle              Style
 	HighlightStyle            Style
+	LabelDisabledStyle        Style
 	LabelStyle                Style
 	PanelBackgroundStyle      Style
 	ScrollBarBarDefaultStyle  Style
@@ -37,7 +42,12 @@ type Theme struct {
 	TabOverStyle              Style
 	TabPressedStyle           Style
 	TextBoxDefaultStyle       Style
+	TextBoxDisabledStyle      Style
 	TextBoxOverStyle          Style
+
+	// Classes holds the styles of style classes, keyed by selector. See
+	// ResolveStyle.
+	Classes map[string]StyleRule
 }
 
 // gxui.Theme compliance
@@ -128,3 +138,9 @@ func (t *Theme) CreateTree() gxui.Tree {
 func (t *Theme) CreateWindow(width, height int, title string) gxui.Window {
 	return CreateWindow(t, width, height, title)
 }
+
+// onThemeChanged calls f with the new theme of the control c whenever it is
+// changed.
+func onThemeChanged(c gxui.Control, f func(theme *Theme)) {
+	c.OnThemeChanged(func() { f(c.Theme().(*Theme)) })
+}
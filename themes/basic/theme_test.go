@@ -0,0 +1,151 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package basic
+
+import (
+	"testing"
+
+	"github.com/google/gxui"
+	"github.com/google/gxui/math"
+	test "github.com/google/gxui/testing"
+)
+
+// testDriver is a Driver for controls that are never attached.
+type testDriver struct {
+	gxui.Driver
+}
+
+func (testDriver) AssertUIGoroutine() {}
+
+// testFont is a monospace Font with square glyphs.
+type testFont struct {
+	name string
+}
+
+func (*testFont) LoadGlyphs(first, last rune) {}
+func (*testFont) Size() int                   { return 10 }
+func (*testFont) GlyphMaxSize() math.Size     { return math.Size{W: 10, H: 10} }
+
+func (*testFont) Measure(t *gxui.TextBlock) math.Size {
+	return math.Size{W: 10 * len(t.Runes), H: 10}
+}
+
+func (*testFont) Layout(t *gxui.TextBlock) []math.Point {
+	offsets := make([]math.Point, len(t.Runes))
+	for i := range offsets {
+		offsets[i] = math.Point{X: 10 * i}
+	}
+	return offsets
+}
+
+func createTestTheme(font gxui.Font, metrics Metrics, style Style) *Theme {
+	return &Theme{
+		DriverInfo:           testDriver{},
+		DefaultFontInfo:      font,
+		Metrics:              metrics,
+		ButtonDefaultStyle:   style,
+		LabelStyle:           style,
+		TextBoxDefaultStyle:  style,
+		TextBoxDisabledStyle: style,
+	}
+}
+
+func createTestThemes() (light, dark *Theme) {
+	light = createTestTheme(&testFont{name: "light"}, DefaultMetrics,
+		CreateStyle(gxui.Black, gxui.White, gxui.Gray50, 1))
+	metrics := DefaultMetrics
+	metrics.ButtonPadding = math.CreateSpacing(7)
+	metrics.ButtonMargin = math.CreateSpacing(6)
+	metrics.LabelMargin = math.CreateSpacing(5)
+	metrics.ListPadding = math.CreateSpacing(4)
+	metrics.TextBoxMargin = math.CreateSpacing(3)
+	metrics.TextBoxPadding = math.CreateSpacing(2)
+	dark = createTestTheme(&testFont{name: "dark"}, metrics,
+		CreateStyle(gxui.White, gxui.Black, gxui.Gray20, 2))
+	return light, dark
+}
+
+func TestSetThemeButton(t *testing.T) {
+	light, dark := createTestThemes()
+	b := CreateButton(light)
+	b.SetText("OK")
+	b.SetMargin(math.CreateSpacing(20))
+
+	b.SetTheme(dark)
+	test.AssertEquals(t, gxui.Theme(dark), b.Theme())
+	test.AssertEquals(t, dark.Metrics.ButtonPadding, b.Padding())
+	test.AssertEquals(t, dark.ButtonDefaultStyle.Brush, b.BackgroundBrush())
+	test.AssertEquals(t, dark.ButtonDefaultStyle.Pen, b.BorderPen())
+	test.AssertEquals(t, dark.DefaultFont(), b.(*Button).Label().Font())
+	test.AssertEquals(t, "OK", b.Text())
+
+	// Values set by the application are kept.
+	test.AssertEquals(t, math.CreateSpacing(20), b.Margin())
+}
+
+func TestSetThemeTextBox(t *testing.T) {
+	light, dark := createTestThemes()
+	tb := CreateTextBox(light).(*TextBox)
+	tb.SetText("hello")
+	tb.SetPadding(math.CreateSpacing(20))
+
+	tb.SetTheme(dark)
+	test.AssertEquals(t, dark.DefaultFont(), tb.Font())
+	test.AssertEquals(t, dark.Metrics.TextBoxMargin, tb.Margin())
+	test.AssertEquals(t, dark.TextBoxDefaultStyle.FontColor, tb.TextColor())
+	test.AssertEquals(t, dark.TextBoxDefaultStyle.Brush, tb.BackgroundBrush())
+	test.AssertEquals(t, dark.TextBoxDefaultStyle.Pen, tb.BorderPen())
+	test.AssertEquals(t, "hello", tb.Text())
+	test.AssertEquals(t, math.CreateSpacing(20), tb.Padding())
+
+	font := &testFont{name: "custom"}
+	tb.SetFont(font)
+	tb.SetTheme(light)
+	test.AssertEquals(t, gxui.Font(font), tb.Font())
+	test.AssertEquals(t, light.TextBoxDefaultStyle.Brush, tb.BackgroundBrush())
+	test.AssertEquals(t, "hello", tb.Text())
+}
+
+func TestSetThemeList(t *testing.T) {
+	light, dark := createTestThemes()
+	l := CreateList(light).(*List)
+
+	l.SetTheme(dark)
+	test.AssertEquals(t, dark.Metrics.ListPadding, l.Padding())
+
+	l.SetPadding(math.CreateSpacing(20))
+	l.SetTheme(light)
+	test.AssertEquals(t, math.CreateSpacing(20), l.Padding())
+}
+
+func TestSetThemeLabel(t *testing.T) {
+	light, dark := createTestThemes()
+	l := CreateLabel(light)
+
+	l.SetTheme(dark)
+	test.AssertEquals(t, dark.DefaultFont(), l.Font())
+	test.AssertEquals(t, dark.LabelStyle.FontColor, l.Color())
+	test.AssertEquals(t, dark.Metrics.LabelMargin, l.Margin())
+
+	l.SetColor(gxui.Red)
+	l.SetMargin(math.CreateSpacing(20))
+	l.SetTheme(light)
+	test.AssertEquals(t, light.DefaultFont(), l.Font())
+	test.AssertEquals(t, gxui.Red, l.Color())
+	test.AssertEquals(t, math.CreateSpacing(20), l.Margin())
+}
+
+func TestSetThemeChildren(t *testing.T) {
+	light, dark := createTestThemes()
+	layout := CreateLinearLayout(light)
+	b := CreateButton(light)
+	l := CreateLabel(light)
+	layout.AddChild(b)
+	layout.AddChild(l)
+
+	layout.SetTheme(dark)
+	test.AssertEquals(t, dark.Metrics.ButtonPadding, b.Padding())
+	test.AssertEquals(t, dark.DefaultFont(), l.Font())
+}
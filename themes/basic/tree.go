@@ -27,13 +27,59 @@ var collapsedPoly = gxui.Polygon{
 	gxui.PolygonVertex{Position: math.Point{X: 3, Y: 8}},
 }
 
+var expanderSize = math.Size{W: 10, H: 10}
+
+// mirrorPolygon returns the polygon p mirrored horizontally within width,
+// with the vertices reversed to keep their winding order.
+func mirrorPolygon(p gxui.Polygon, width int) gxui.Polygon {
+	m := make(gxui.Polygon, len(p))
+	for i, v := range p {
+		v.Position.X = width - v.Position.X
+		m[len(p)-1-i] = v
+	}
+	return m
+}
+
+// treeExpander is the arrow of a node's expand button. The collapsed arrow
+// points towards the node's content, so it is mirrored in right-to-left
+// layouts.
+type treeExpander struct {
+	mixins.Image
+	expanded bool
+	brush    gxui.Brush
+}
+
+func (e *treeExpander) Paint(c gxui.Canvas) {
+	poly := collapsedPoly
+	switch {
+	case e.expanded:
+		poly = expandedPoly
+	case gxui.ResolveLayoutDirection(e).RightToLeft():
+		poly = mirrorPolygon(collapsedPoly, expanderSize.W)
+	}
+	c.DrawPolygon(poly, gxui.TransparentPen, e.brush)
+}
+
+func (e *treeExpander) set(expanded bool, brush gxui.Brush) {
+	if e.expanded != expanded || e.brush != brush {
+		e.expanded, e.brush = expanded, brush
+		e.Redraw()
+	}
+}
+
 func CreateTree(theme *Theme) gxui.Tree {
 	t := &Tree{}
 	t.Init(t, theme)
-	t.SetPadding(math.Spacing{L: 3, T: 3, R: 3, B: 3})
+	t.SetPadding(theme.Metrics.TreePadding)
 	t.SetBorderPen(gxui.TransparentPen)
 	t.theme = theme
 	t.SetControlCreator(treeControlCreator{})
+	onThemeChanged(t, func(new *Theme) {
+		if t.Padding() == t.theme.Metrics.TreePadding {
+			t.SetPadding(new.Metrics.TreePadding)
+		}
+		t.theme = new
+	})
 
 	return t
 }
@@ -63,8 +109,9 @@ func (l *Tree) PaintSelection(c gxui.Canvas, r math.Rect) {
 type treeControlCreator struct{}
 
 func (treeControlCreator) Create(theme gxui.Theme, control gxui.Control, node *mixins.TreeToListNode) gxui.Control {
-	img := theme.CreateImage()
-	imgSize := math.Size{W: 10, H: 10}
+	img := &treeExpander{}
+	img.Init(img, theme)
+	img.SetExplicitSize(expanderSize)
 
 	ll := theme.CreateLinearLayout()
 	ll.SetDirection(gxui.LeftToRight)
@@ -81,21 +128,12 @@ func (treeControlCreator) Create(theme gxui.Theme, control gxui.Control, node *m
 	btn.AddChild(img)
 
 	update := func() {
-		expanded := node.IsExpanded()
-		canvas := theme.Driver().CreateCanvas(imgSize)
 		btn.SetVisible(!node.IsLeaf())
-		switch {
-		case !btn.IsMouseDown(gxui.MouseButtonLeft) && expanded:
-			canvas.DrawPolygon(expandedPoly, gxui.TransparentPen, gxui.CreateBrush(gxui.Gray70))
-		case !btn.IsMouseDown(gxui.MouseButtonLeft) && !expanded:
-			canvas.DrawPolygon(collapsedPoly, gxui.TransparentPen, gxui.CreateBrush(gxui.Gray70))
-		case expanded:
-			canvas.DrawPolygon(expandedPoly, gxui.TransparentPen, gxui.CreateBrush(gxui.Gray30))
-		case !expanded:
-			canvas.DrawPolygon(collapsedPoly, gxui.TransparentPen, gxui.CreateBrush(gxui.Gray30))
+		brush := gxui.CreateBrush(gxui.Gray70)
+		if btn.IsMouseDown(gxui.MouseButtonLeft) {
+			brush = gxui.CreateBrush(gxui.Gray30)
 		}
-		canvas.Complete()
-		img.SetCanvas(canvas)
+		img.set(node.IsExpanded(), brush)
 	}
 	btn.OnMouseDown(func(gxui.MouseEvent) { update() })
 	btn.OnMouseUp(func(gxui.MouseEvent) { update() })
@@ -105,7 +143,11 @@ func (treeControlCreator) Create(theme gxui.Theme, control gxui.Control, node *m
 
 	ll.AddChild(btn)
 	ll.AddChild(control)
-	ll.SetPadding(math.Spacing{L: 16 * node.Depth()})
+	indent := DefaultMetrics.TreeIndent
+	if t, ok := theme.(*Theme); ok {
+		indent = t.Metrics.TreeIndent
+	}
+	ll.SetPadding(math.Spacing{L: indent * node.Depth()})
 	return ll
 }
 
@@ -17,5 +17,13 @@ func CreateWindow(theme *Theme, width, height int, title string) gxui.Window {
 	w := &Window{}
 	w.Window.Init(w, theme.Driver(), width, height, title)
 	w.SetBackgroundBrush(gxui.CreateBrush(theme.WindowBackground))
+	w.SetTheme(theme)
+	w.OnThemeChanged(func() {
+		new := w.Theme().(*Theme)
+		if w.BackgroundBrush() == gxui.CreateBrush(theme.WindowBackground) {
+			w.SetBackgroundBrush(gxui.CreateBrush(new.WindowBackground))
+		}
+		theme = new
+	})
 	return w
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/google/gxui"
 	"github.com/google/gxui/gxfont"
+	"github.com/google/gxui/math"
 	"github.com/google/gxui/themes/basic"
 )
 
@@ -36,22 +37,32 @@ func CreateTheme(driver gxui.Driver) gxui.Theme {
 	neonBlue := gxui.ColorFromHex(0xFF5C8CFF)
 	focus := gxui.ColorFromHex(0xA0C4D6FF)
 
+	shadow := gxui.Black
+	shadow.A = 0.6
+
+	bubbleOverlayStyle := basic.CreateStyle(gxui.Gray80, gxui.Gray20, gxui.Gray40, 1.0)
+	bubbleOverlayStyle.Shadow = gxui.CreateShadow(math.Point{X: 0, Y: 2}, 8, 0, shadow)
+
 	return &basic.Theme{
 		DriverInfo:               driver,
 		DefaultFontInfo:          defaultFont,
 		DefaultMonospaceFontInfo: defaultMonospaceFont,
+		Metrics:                  basic.DefaultMetrics,
 		WindowBackground:         gxui.Black,
 
 		//                                   fontColor    brushColor   penColor
-		BubbleOverlayStyle:        basic.CreateStyle(gxui.Gray80, gxui.Gray20, gxui.Gray40, 1.0),
+		BubbleOverlayStyle:        bubbleOverlayStyle,
 		ButtonDefaultStyle:        basic.CreateStyle(gxui.Gray80, gxui.Gray10, gxui.Gray20, 1.0),
+		ButtonDisabledStyle:       basic.CreateStyle(gxui.Gray40, gxui.Gray10, gxui.Gray15, 1.0),
 		ButtonOverStyle:           basic.CreateStyle(gxui.Gray90, gxui.Gray15, gxui.Gray50, 1.0),
 		ButtonPressedStyle:        basic.CreateStyle(gxui.Gray20, gxui.Gray70, gxui.Gray30, 1.0),
 		CodeSuggestionListStyle:   basic.CreateStyle(gxui.Gray80, gxui.Gray20, gxui.Gray10, 1.0),
 		DropDownListDefaultStyle:  basic.CreateStyle(gxui.Gray80, gxui.Gray10, gxui.Gray20, 1.0),
+		DropDownListDisabledStyle: basic.CreateStyle(gxui.Gray40, gxui.Gray10, gxui.Gray15, 1.0),
 		DropDownListOverStyle:     basic.CreateStyle(gxui.Gray80, gxui.Gray15, gxui.Gray50, 1.0),
 		FocusedStyle:              basic.CreateStyle(gxui.Gray80, gxui.Transparent, focus, 1.0),
 		HighlightStyle:            basic.CreateStyle(gxui.Gray80, gxui.Transparent, neonBlue, 2.0),
+		LabelDisabledStyle:        basic.CreateStyle(gxui.Gray40, gxui.Transparent, gxui.Transparent, 0.0),
 		LabelStyle:                basic.CreateStyle(gxui.Gray80, gxui.Transparent, gxui.Transparent, 0.0),
 		PanelBackgroundStyle:      basic.CreateStyle(gxui.Gray80, gxui.Gray10, gxui.Gray15, 1.0),
 		ScrollBarBarDefaultStyle:  basic.CreateStyle(gxui.Gray80, gxui.Gray30, gxui.Gray40, 1.0),
@@ -65,6 +76,7 @@ func CreateTheme(driver gxui.Driver) gxui.Theme {
 		TabOverStyle:              basic.CreateStyle(gxui.Gray90, gxui.Gray30, gxui.Gray50, 1.0),
 		TabPressedStyle:           basic.CreateStyle(gxui.Gray20, gxui.Gray70, gxui.Gray30, 1.0),
 		TextBoxDefaultStyle:       basic.CreateStyle(gxui.Gray80, gxui.Gray10, gxui.Gray20, 1.0),
+		TextBoxDisabledStyle:      basic.CreateStyle(gxui.Gray40, gxui.Gray10, gxui.Gray15, 1.0),
 		TextBoxOverStyle:          basic.CreateStyle(gxui.Gray80, gxui.Gray10, gxui.Gray50, 1.0),
 	}
 }
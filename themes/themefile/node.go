@@ -0,0 +1,159 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package themefile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// node is a JSON value along with the offset of its first byte in the file.
+// value is one of nil, bool, float64, string, []*node or *object.
+type node struct {
+	offset int
+	value  interface{}
+}
+
+// object is a JSON object that remembers the order and offsets of its keys.
+type object struct {
+	keys   []*node // The key strings, in file order.
+	values map[string]*node
+}
+
+func (o *object) get(key string) *node {
+	return o.values[key]
+}
+
+// parse parses data as a single JSON value into a tree of nodes.
+func parse(data []byte) (*node, error) {
+	p := &parser{data: data, dec: json.NewDecoder(bytes.NewReader(data))}
+	p.dec.UseNumber()
+	n, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.dec.Token(); err != io.EOF {
+		return nil, &syntaxError{p.start(), "unexpected data after the theme"}
+	}
+	return n, nil
+}
+
+// syntaxError is a JSON syntax error at a byte offset.
+type syntaxError struct {
+	offset int
+	msg    string
+}
+
+func (e *syntaxError) Error() string {
+	return e.msg
+}
+
+type parser struct {
+	data []byte
+	dec  *json.Decoder
+}
+
+// start returns the offset of the next token, skipping the whitespace and
+// separators that follow the decoder's last token.
+func (p *parser) start() int {
+	i := int(p.dec.InputOffset())
+	for i < len(p.data) {
+		if !isSpace(p.data[i]) && p.data[i] != ',' && p.data[i] != ':' {
+			return i
+		}
+		i++
+	}
+	return i
+}
+
+func (p *parser) token() (json.Token, int, error) {
+	offset := p.start()
+	t, err := p.dec.Token()
+	if err == io.EOF {
+		return nil, offset, &syntaxError{offset, "unexpected end of file"}
+	}
+	if err != nil {
+		if s, ok := err.(*json.SyntaxError); ok {
+			// The offset follows the last valid token, so skip to the
+			// invalid character.
+			offset = int(s.Offset)
+			for offset < len(p.data) && isSpace(p.data[offset]) {
+				offset++
+			}
+		}
+		return nil, offset, &syntaxError{offset, err.Error()}
+	}
+	return t, offset, nil
+}
+
+func (p *parser) value() (*node, error) {
+	t, offset, err := p.token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := t.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			o := &object{values: make(map[string]*node)}
+			for p.dec.More() {
+				k, koffset, err := p.token()
+				if err != nil {
+					return nil, err
+				}
+				key := k.(string)
+				if _, dup := o.values[key]; dup {
+					return nil, &syntaxError{koffset, fmt.Sprintf("duplicate key %q", key)}
+				}
+				v, err := p.value()
+				if err != nil {
+					return nil, err
+				}
+				o.keys = append(o.keys, &node{koffset, key})
+				o.values[key] = v
+			}
+			if _, _, err := p.token(); err != nil {
+				return nil, err
+			}
+			return &node{offset, o}, nil
+		case '[':
+			var a []*node
+			for p.dec.More() {
+				v, err := p.value()
+				if err != nil {
+					return nil, err
+				}
+				a = append(a, v)
+			}
+			if _, _, err := p.token(); err != nil {
+				return nil, err
+			}
+			return &node{offset, a}, nil
+		}
+	case json.Number:
+		f, err := t.Float64()
+		if err != nil {
+			return nil, &syntaxError{offset, err.Error()}
+		}
+		return &node{offset, f}, nil
+	}
+	return &node{offset, t}, nil
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
+
+// position returns the 1-based line and column of the byte offset in data.
+func position(data []byte, offset int) (line, column int) {
+	if offset > len(data) {
+		offset = len(data)
+	}
+	line = 1 + bytes.Count(data[:offset], []byte{'\n'})
+	column = 1 + offset - (bytes.LastIndexByte(data[:offset], '\n') + 1)
+	return line, column
+}
@@ -0,0 +1,1011 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package themefile loads basic.Themes from declarative JSON theme files.
+//
+// A theme file is a JSON object that describes how it differs from a base
+// theme. Every field is optional:
+//
+//	{
+//	  "inherits": "dark",
+//	  "colors": {
+//	    "accent": "#5C8CFF"
+//	  },
+//	  "fonts": {
+//	    "default":   {"file": "fonts/Inter.ttf", "size": 13},
+//	    "monospace": {"size": 12}
+//	  },
+//	  "windowBackground": "gray10",
+//	  "metrics": {
+//	    "buttonPadding": 4,
+//	    "panelTabPadding": {"l": 6, "r": 6},
+//	    "treeIndent": 12
+//	  },
+//	  "styles": {
+//	    "buttonDefault": {
+//	      "fontColor": "gray80",
+//	      "brush": "gray10",
+//	      "pen": {"color": "accent", "width": 1}
+//	    },
+//	    "bubbleOverlay": {
+//	      "shadow": {"offset": [0, 2], "blur": 8, "color": "#00000099"}
+//	    }
+//	  }
+//	}
+//
+// inherits names the base theme, which is either one of the built-in themes
+// "dark" or "light", or the path of another theme file. If omitted, the base
+// theme is "dark".
+//
+// Colors are written as "#RGB", "#RRGGBB" or "#RRGGBBAA", as the lower case
+// name of a gxui color such as "gray80" or "transparent", or as the name of
+// an entry in the file's colors table.
+//
+// The keys of metrics are the fields of basic.Metrics, and the keys of styles
+// are the Style fields of basic.Theme without the Style suffix, both starting
+// with a lower case letter. Spacings are either a single number used for all
+// sides, or an object of the sides "l", "t", "r" and "b" to change. Each style
+// only changes the properties that are listed:
+//
+//	fontColor  a color.
+//	brush      a color, or an object with either a "color" or a "gradient".
+//	           Gradients have a "kind" of "linear" or "radial", a "spread" of
+//	           "pad", "repeat" or "reflect", "start", "end", "center" and
+//	           "radius" positions as [x, y] pairs, and "stops" as a list of
+//	           [offset, color] pairs.
+//	pen        a color, or an object with a "color", "width", and optionally
+//	           "dash" lengths and a "dashOffset".
+//	shadow     "none", or an object with an "offset" as an [x, y] pair, and a
+//	           "blur", "spread" and "color".
+//	skin       "none", or an object with an "image" path, "insets" spacing,
+//	           "edges" and "center" modes of "stretch" or "tile", and a
+//	           "scale" of image pixels per DIP. Skins are only allowed on
+//	           the button, tab and panelBackground styles, and on classes
+//	           that do not name a control type or name Button or PanelTab.
+//
+// classes holds the styles of style classes, keyed by a selector of either a
+// class name or a control type and class name, such as "Button.primary". Each
+// selector has a style for any of the states "default", "focused", "over",
+// "pressed" and "disabled", in the same form as styles. See
+// basic.Theme.ResolveStyle for how they are applied.
+//
+// Paths are relative to the directory of the theme file.
+package themefile
+
+import (
+	"fmt"
+	"image"
+	_ "image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/gxui"
+	"github.com/google/gxui/gxfont"
+	"github.com/google/gxui/math"
+	"github.com/google/gxui/themes/basic"
+	"github.com/google/gxui/themes/dark"
+	"github.com/google/gxui/themes/light"
+)
+
+// Error is a problem found in a theme file.
+type Error struct {
+	File         string
+	Line, Column int
+	Message      string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Message)
+}
+
+// ErrorList is the list of problems found loading a theme file.
+type ErrorList []*Error
+
+func (l ErrorList) Error() string {
+	s := make([]string, len(l))
+	for i, e := range l {
+		s[i] = e.Error()
+	}
+	return strings.Join(s, "\n")
+}
+
+// Load loads the theme file at path. Any problems found in the file are
+// returned as an ErrorList.
+func Load(driver gxui.Driver, path string) (*basic.Theme, error) {
+	t, _, err := newLoader(driver).load(path)
+	if err != nil {
+		return nil, err
+	}
+	return t.Theme, nil
+}
+
+// Parse parses data as the contents of a theme file. filename is used to
+// report problems, and to resolve the paths the theme refers to.
+func Parse(driver gxui.Driver, filename string, data []byte) (*basic.Theme, error) {
+	t, err := newLoader(driver).parse(filename, data)
+	if err != nil {
+		return nil, err
+	}
+	return t.Theme, nil
+}
+
+// theme is a basic.Theme along with the sources of its fonts, so that the
+// themes inheriting from it can change just the size of a font.
+type theme struct {
+	*basic.Theme
+	fonts map[string]fontSource
+}
+
+type fontSource struct {
+	data []byte
+	size int
+}
+
+type loader struct {
+	driver  gxui.Driver
+	bases   map[string]func(gxui.Driver) gxui.Theme
+	loading map[string]bool
+	files   []string // Every file read by the loader.
+}
+
+func newLoader(driver gxui.Driver) *loader {
+	return &loader{
+		driver: driver,
+		bases: map[string]func(gxui.Driver) gxui.Theme{
+			"dark":  dark.CreateTheme,
+			"light": light.CreateTheme,
+		},
+		loading: make(map[string]bool),
+	}
+}
+
+// load loads the theme file at path, returning the theme and the list of
+// files it was loaded from.
+func (l *loader) load(path string) (*theme, []string, error) {
+	data, err := l.readFile(path)
+	if err != nil {
+		return nil, l.files, err
+	}
+	t, err := l.parse(path, data)
+	return t, l.files, err
+}
+
+func (l *loader) readFile(path string) ([]byte, error) {
+	l.files = append(l.files, path)
+	return ioutil.ReadFile(path)
+}
+
+func (l *loader) base(name string) (*theme, bool) {
+	create, ok := l.bases[name]
+	if !ok {
+		return nil, false
+	}
+	return &theme{
+		Theme: create(l.driver).(*basic.Theme),
+		fonts: map[string]fontSource{
+			"default":   {gxfont.Default, 12},
+			"monospace": {gxfont.Monospace, 12},
+		},
+	}, true
+}
+
+func (l *loader) parse(filename string, data []byte) (*theme, error) {
+	l.loading[filepath.Clean(filename)] = true
+	defer delete(l.loading, filepath.Clean(filename))
+
+	f := &file{
+		loader: l,
+		name:   filename,
+		dir:    filepath.Dir(filename),
+		data:   data,
+		colors: make(map[string]gxui.Color),
+	}
+	root, err := parse(data)
+	if err != nil {
+		s := err.(*syntaxError)
+		f.errorAt(s.offset, "%s", s.msg)
+		return nil, f.errs
+	}
+	o, ok := f.object(root)
+	if !ok {
+		return nil, f.errs
+	}
+	base := f.inherits(o.get("inherits"))
+	if base == nil {
+		return nil, f.errs
+	}
+	f.checkKeys(o, "inherits", "colors", "fonts", "windowBackground", "metrics", "styles", "classes")
+	t := &theme{Theme: &basic.Theme{}, fonts: make(map[string]fontSource)}
+	*t.Theme = *base.Theme
+	for k, v := range base.fonts {
+		t.fonts[k] = v
+	}
+	t.Classes = nil
+	for selector, rule := range base.Classes {
+		for state, o := range rule {
+			t.SetClassStyle(selector, state, o)
+		}
+	}
+
+	if n := o.get("colors"); n != nil {
+		f.colorTable(n)
+	}
+	if n := o.get("fonts"); n != nil {
+		f.fonts(n, t)
+	}
+	if n := o.get("windowBackground"); n != nil {
+		if c, ok := f.color(n); ok {
+			t.WindowBackground = c
+		}
+	}
+	if n := o.get("metrics"); n != nil {
+		f.metrics(n, &t.Metrics)
+	}
+	if n := o.get("styles"); n != nil {
+		f.styles(n, t.Theme)
+	}
+	if n := o.get("classes"); n != nil {
+		f.classes(n, t.Theme)
+	}
+	if len(f.errs) > 0 {
+		sort.SliceStable(f.errs, func(i, j int) bool {
+			a, b := f.errs[i], f.errs[j]
+			return a.Line < b.Line || (a.Line == b.Line && a.Column < b.Column)
+		})
+		return nil, f.errs
+	}
+	return t, nil
+}
+
+// file holds the state of a theme file being parsed.
+type file struct {
+	loader *loader
+	name   string
+	dir    string
+	data   []byte
+	errs   ErrorList
+	colors map[string]gxui.Color
+}
+
+func (f *file) errorAt(offset int, format string, args ...interface{}) {
+	line, column := position(f.data, offset)
+	f.errs = append(f.errs, &Error{
+		File:    f.name,
+		Line:    line,
+		Column:  column,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (f *file) errorf(n *node, format string, args ...interface{}) {
+	f.errorAt(n.offset, format, args...)
+}
+
+func (f *file) path(p string) string {
+	if filepath.IsAbs(p) {
+		return p
+	}
+	return filepath.Join(f.dir, p)
+}
+
+func (f *file) inherits(n *node) *theme {
+	name := "dark"
+	if n != nil {
+		var ok bool
+		if name, ok = f.str(n); !ok {
+			return nil
+		}
+	}
+	if t, ok := f.loader.base(name); ok {
+		return t
+	}
+	path := filepath.Clean(f.path(name))
+	if f.loader.loading[path] {
+		f.errorf(n, "theme %q inherits from itself", path)
+		return nil
+	}
+	data, err := f.loader.readFile(path)
+	if err != nil {
+		f.errorf(n, "cannot read base theme: %v", err)
+		return nil
+	}
+	t, err := f.loader.parse(path, data)
+	if err != nil {
+		f.errs = append(f.errs, err.(ErrorList)...)
+		return nil
+	}
+	return t
+}
+
+func (f *file) checkKeys(o *object, keys ...string) {
+	for _, k := range o.keys {
+		known := false
+		for _, key := range keys {
+			known = known || k.value == key
+		}
+		if !known {
+			f.errorf(k, "unknown key %q, expected one of: %s", k.value, strings.Join(keys, ", "))
+		}
+	}
+}
+
+func (f *file) object(n *node) (*object, bool) {
+	o, ok := n.value.(*object)
+	if !ok {
+		f.errorf(n, "expected an object")
+	}
+	return o, ok
+}
+
+func (f *file) str(n *node) (string, bool) {
+	s, ok := n.value.(string)
+	if !ok {
+		f.errorf(n, "expected a string")
+	}
+	return s, ok
+}
+
+func (f *file) number(n *node) (float32, bool) {
+	v, ok := n.value.(float64)
+	if !ok {
+		f.errorf(n, "expected a number")
+	}
+	return float32(v), ok
+}
+
+func (f *file) integer(n *node) (int, bool) {
+	v, ok := n.value.(float64)
+	if !ok || v != float64(int(v)) {
+		f.errorf(n, "expected an integer")
+		return 0, false
+	}
+	return int(v), true
+}
+
+func (f *file) numbers(n *node, count int) ([]float32, bool) {
+	a, ok := n.value.([]*node)
+	if !ok || (count > 0 && len(a) != count) {
+		if count > 0 {
+			f.errorf(n, "expected a list of %d numbers", count)
+		} else {
+			f.errorf(n, "expected a list of numbers")
+		}
+		return nil, false
+	}
+	v := make([]float32, len(a))
+	for i, e := range a {
+		if v[i], ok = f.number(e); !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+func (f *file) vec2(n *node) (math.Vec2, bool) {
+	v, ok := f.numbers(n, 2)
+	if !ok {
+		return math.Vec2{}, false
+	}
+	return math.Vec2{X: v[0], Y: v[1]}, true
+}
+
+func (f *file) point(n *node) (math.Point, bool) {
+	a, ok := n.value.([]*node)
+	if !ok || len(a) != 2 {
+		f.errorf(n, "expected a list of 2 integers")
+		return math.Point{}, false
+	}
+	x, okx := f.integer(a[0])
+	y, oky := f.integer(a[1])
+	return math.Point{X: x, Y: y}, okx && oky
+}
+
+// enum returns the index of the string n in names.
+func (f *file) enum(n *node, names ...string) (int, bool) {
+	s, ok := f.str(n)
+	if !ok {
+		return 0, false
+	}
+	for i, name := range names {
+		if s == name {
+			return i, true
+		}
+	}
+	f.errorf(n, "unknown value %q, expected one of: %s", s, strings.Join(names, ", "))
+	return 0, false
+}
+
+func (f *file) colorTable(n *node) {
+	o, ok := f.object(n)
+	if !ok {
+		return
+	}
+	// Entries may refer to the entries before them.
+	for _, k := range o.keys {
+		name := k.value.(string)
+		if _, builtin := namedColors[name]; builtin {
+			f.errorf(k, "color %q hides the built-in color of the same name", name)
+			continue
+		}
+		if c, ok := f.color(o.get(name)); ok {
+			f.colors[name] = c
+		}
+	}
+}
+
+func (f *file) color(n *node) (gxui.Color, bool) {
+	s, ok := f.str(n)
+	if !ok {
+		return gxui.Color{}, false
+	}
+	if c, ok := f.colors[s]; ok {
+		return c, true
+	}
+	if c, ok := namedColors[s]; ok {
+		return c, true
+	}
+	if !strings.HasPrefix(s, "#") {
+		f.errorf(n, "unknown color %q", s)
+		return gxui.Color{}, false
+	}
+	c, ok := parseHexColor(s[1:])
+	if !ok {
+		f.errorf(n, "invalid color %q, expected #RGB, #RRGGBB or #RRGGBBAA", s)
+	}
+	return c, ok
+}
+
+func parseHexColor(s string) (gxui.Color, bool) {
+	var v uint32
+	for _, r := range s {
+		d := strings.IndexRune("0123456789abcdef", unicode.ToLower(r))
+		if d < 0 {
+			return gxui.Color{}, false
+		}
+		v = v<<4 | uint32(d)
+	}
+	switch len(s) {
+	case 3: // #RGB
+		r, g, b := v>>8, v>>4&0xf, v&0xf
+		return gxui.ColorFromHex((r*0x11)<<24 | (g*0x11)<<16 | (b*0x11)<<8 | 0xff), true
+	case 6: // #RRGGBB
+		return gxui.ColorFromHex(v<<8 | 0xff), true
+	case 8: // #RRGGBBAA
+		return gxui.ColorFromHex(v), true
+	default:
+		return gxui.Color{}, false
+	}
+}
+
+func (f *file) fonts(n *node, t *theme) {
+	o, ok := f.object(n)
+	if !ok {
+		return
+	}
+	f.checkKeys(o, "default", "monospace")
+	for _, k := range o.keys {
+		name := k.value.(string)
+		fo, ok := f.object(o.get(name))
+		if !ok {
+			continue
+		}
+		f.checkKeys(fo, "file", "size")
+		src := t.fonts[name]
+		if n := fo.get("file"); n != nil {
+			path, ok := f.str(n)
+			if !ok {
+				continue
+			}
+			data, err := f.loader.readFile(f.path(path))
+			if err != nil {
+				f.errorf(n, "cannot read font: %v", err)
+				continue
+			}
+			src.data = data
+		}
+		if n := fo.get("size"); n != nil {
+			if src.size, ok = f.integer(n); !ok {
+				continue
+			}
+			if src.size <= 0 {
+				f.errorf(n, "font size must be greater than 0")
+				continue
+			}
+		}
+		font, err := f.loader.driver.CreateFont(src.data, src.size)
+		if err != nil {
+			f.errorf(k, "cannot load font: %v", err)
+			continue
+		}
+		font.LoadGlyphs(32, 126)
+		t.fonts[name] = src
+		if name == "default" {
+			t.DefaultFontInfo = font
+		} else {
+			t.DefaultMonospaceFontInfo = font
+		}
+	}
+}
+
+var (
+	spacingType = reflect.TypeOf(math.Spacing{})
+	styleType   = reflect.TypeOf(basic.Style{})
+)
+
+// lowerFirst returns s with its first letter in lower case.
+func lowerFirst(s string) string {
+	r, n := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[n:]
+}
+
+// fields returns the fields of the struct pointed to by v with the type t,
+// keyed by their name in theme files.
+func fields(v interface{}, t reflect.Type, suffix string) (map[string]reflect.Value, []string) {
+	s := reflect.ValueOf(v).Elem()
+	m := make(map[string]reflect.Value)
+	var names []string
+	for i := 0; i < s.NumField(); i++ {
+		field := s.Type().Field(i)
+		if field.Type == t {
+			name := lowerFirst(strings.TrimSuffix(field.Name, suffix))
+			m[name] = s.Field(i)
+			names = append(names, name)
+		}
+	}
+	return m, names
+}
+
+func (f *file) metrics(n *node, m *basic.Metrics) {
+	o, ok := f.object(n)
+	if !ok {
+		return
+	}
+	spacings, names := fields(m, spacingType, "")
+	f.checkKeys(o, append(names, "treeIndent")...)
+	for _, k := range o.keys {
+		name := k.value.(string)
+		if field, ok := spacings[name]; ok {
+			f.spacing(o.get(name), field.Addr().Interface().(*math.Spacing))
+		}
+	}
+	if n := o.get("treeIndent"); n != nil {
+		if i, ok := f.integer(n); ok {
+			m.TreeIndent = i
+		}
+	}
+}
+
+// spacing parses n into s, either as a number for all sides, or as an object
+// of the sides to change.
+func (f *file) spacing(n *node, s *math.Spacing) {
+	if _, ok := n.value.(*object); !ok {
+		if v, ok := f.integer(n); ok {
+			*s = math.CreateSpacing(v)
+		}
+		return
+	}
+	o, _ := f.object(n)
+	f.checkKeys(o, "l", "t", "r", "b")
+	sides := []*int{&s.L, &s.T, &s.R, &s.B}
+	for i, key := range []string{"l", "t", "r", "b"} {
+		if n := o.get(key); n != nil {
+			if v, ok := f.integer(n); ok {
+				*sides[i] = v
+			}
+		}
+	}
+}
+
+func (f *file) styles(n *node, t *basic.Theme) {
+	o, ok := f.object(n)
+	if !ok {
+		return
+	}
+	styles, names := fields(t, styleType, "Style")
+	f.checkKeys(o, names...)
+	for _, k := range o.keys {
+		name := k.value.(string)
+		if field, ok := styles[name]; ok {
+			f.style(o.get(name), field.Addr().Interface().(*basic.Style), skinnedStyles[name])
+		}
+	}
+}
+
+// skinnedStyles are the styles that are painted with a skin if they have one.
+var skinnedStyles = map[string]bool{
+	"buttonDefault":   true,
+	"buttonDisabled":  true,
+	"buttonOver":      true,
+	"buttonPressed":   true,
+	"panelBackground": true,
+	"tabDefault":      true,
+	"tabOver":         true,
+	"tabPressed":      true,
+}
+
+// skinnedTypes are the control types of style classes that are painted with a
+// skin if they have one.
+var skinnedTypes = map[string]bool{
+	"Button":   true,
+	"PanelTab": true,
+}
+
+// style parses n into s. Skins are reported as errors unless skinned is true,
+// as they would be silently ignored.
+func (f *file) style(n *node, s *basic.Style, skinned bool) {
+	o, ok := f.object(n)
+	if !ok {
+		return
+	}
+	f.checkKeys(o, "fontColor", "brush", "pen", "shadow", "skin")
+	if n := o.get("fontColor"); n != nil {
+		if c, ok := f.color(n); ok {
+			s.FontColor = c
+		}
+	}
+	if n := o.get("brush"); n != nil {
+		if b, ok := f.brush(n); ok {
+			s.Brush = b
+		}
+	}
+	if n := o.get("pen"); n != nil {
+		f.pen(n, &s.Pen)
+	}
+	if n := o.get("shadow"); n != nil {
+		f.shadow(n, &s.Shadow)
+	}
+	if n := o.get("skin"); n != nil {
+		if skinned {
+			f.skin(n, s)
+		} else {
+			f.errorf(n, "skins are only drawn by buttons, tabs and panel backgrounds")
+		}
+	}
+}
+
+var styleStates = []gxui.StyleState{
+	gxui.StyleDefault,
+	gxui.StyleFocused,
+	gxui.StyleOver,
+	gxui.StylePressed,
+	gxui.StyleDisabled,
+}
+
+func (f *file) classes(n *node, t *basic.Theme) {
+	o, ok := f.object(n)
+	if !ok {
+		return
+	}
+	for _, k := range o.keys {
+		selector := k.value.(string)
+		parts := strings.Split(selector, ".")
+		if len(parts) > 2 || parts[len(parts)-1] == "" || parts[0] == "" {
+			f.errorf(k, "invalid selector %q, expected \"class\" or \"Type.class\"", selector)
+			continue
+		}
+		rule, ok := f.object(o.get(selector))
+		if !ok {
+			continue
+		}
+		names := make([]string, len(styleStates))
+		for i, state := range styleStates {
+			names[i] = state.String()
+		}
+		f.checkKeys(rule, names...)
+		skinned := len(parts) == 1 || skinnedTypes[parts[0]]
+		for _, state := range styleStates {
+			if n := rule.get(state.String()); n != nil {
+				t.SetClassStyle(selector, state, f.styleOverride(n, skinned))
+			}
+		}
+	}
+}
+
+// styleOverride parses n as a style, returning the changes to the properties
+// that are listed.
+func (f *file) styleOverride(n *node, skinned bool) gxui.StyleOverride {
+	s := basic.Style{Pen: gxui.CreatePen(1, gxui.Transparent)}
+	f.style(n, &s, skinned)
+	o, ok := n.value.(*object)
+	if !ok {
+		return gxui.StyleOverride{}
+	}
+	var override gxui.StyleOverride
+	if o.get("fontColor") != nil {
+		override.FontColor = &s.FontColor
+	}
+	if o.get("brush") != nil {
+		override.Brush = &s.Brush
+	}
+	if o.get("pen") != nil {
+		override.Pen = &s.Pen
+	}
+	if o.get("skin") != nil {
+		override.Skin = s.Skin
+	}
+	if o.get("shadow") != nil {
+		override.Shadow = &s.Shadow
+	}
+	return override
+}
+
+func (f *file) brush(n *node) (gxui.Brush, bool) {
+	if _, ok := n.value.(string); ok {
+		c, ok := f.color(n)
+		return gxui.CreateBrush(c), ok
+	}
+	o, ok := f.object(n)
+	if !ok {
+		return gxui.Brush{}, false
+	}
+	f.checkKeys(o, "color", "gradient")
+	switch {
+	case o.get("color") != nil && o.get("gradient") == nil:
+		c, ok := f.color(o.get("color"))
+		return gxui.CreateBrush(c), ok
+	case o.get("gradient") != nil && o.get("color") == nil:
+		g, ok := f.gradient(o.get("gradient"))
+		return gxui.Brush{Gradient: g}, ok
+	default:
+		f.errorf(n, "expected either a color or a gradient")
+		return gxui.Brush{}, false
+	}
+}
+
+func (f *file) gradient(n *node) (*gxui.Gradient, bool) {
+	o, ok := f.object(n)
+	if !ok {
+		return nil, false
+	}
+	f.checkKeys(o, "kind", "spread", "start", "end", "center", "radius", "stops")
+	g := &gxui.Gradient{
+		Start:  math.Vec2{X: 0, Y: 0},
+		End:    math.Vec2{X: 0, Y: 1},
+		Center: math.Vec2{X: 0.5, Y: 0.5},
+		Radius: math.Vec2{X: 0.5, Y: 0.5},
+	}
+	ok = true
+	if n := o.get("kind"); n != nil {
+		kind, k := f.enum(n, "linear", "radial")
+		g.Kind, ok = gxui.GradientKind(kind), ok && k
+	}
+	if n := o.get("spread"); n != nil {
+		spread, k := f.enum(n, "pad", "repeat", "reflect")
+		g.Spread, ok = gxui.SpreadMode(spread), ok && k
+	}
+	positions := []*math.Vec2{&g.Start, &g.End, &g.Center, &g.Radius}
+	for i, key := range []string{"start", "end", "center", "radius"} {
+		if n := o.get(key); n != nil {
+			v, k := f.vec2(n)
+			*positions[i], ok = v, ok && k
+		}
+	}
+	stops := o.get("stops")
+	if stops == nil {
+		f.errorf(n, "gradient requires stops")
+		return nil, false
+	}
+	list, isList := stops.value.([]*node)
+	if !isList || len(list) == 0 || len(list) > gxui.MaxGradientStops {
+		f.errorf(stops, "expected a list of 1 to %d stops", gxui.MaxGradientStops)
+		return nil, false
+	}
+	for _, s := range list {
+		a, isPair := s.value.([]*node)
+		if !isPair || len(a) != 2 {
+			f.errorf(s, "expected an [offset, color] pair")
+			ok = false
+			continue
+		}
+		offset, k1 := f.number(a[0])
+		color, k2 := f.color(a[1])
+		if k1 && (offset < 0 || offset > 1) {
+			f.errorf(a[0], "gradient stop offset must be between 0 and 1")
+			k1 = false
+		} else if k1 && len(g.Stops) > 0 && offset < g.Stops[len(g.Stops)-1].Offset {
+			f.errorf(a[0], "gradient stops must be sorted by offset")
+			k1 = false
+		}
+		ok = ok && k1 && k2
+		g.Stops = append(g.Stops, gxui.GradientStop{Offset: offset, Color: color})
+	}
+	return g, ok
+}
+
+func (f *file) pen(n *node, p *gxui.Pen) {
+	if _, ok := n.value.(string); ok {
+		if c, ok := f.color(n); ok {
+			p.Color = c
+		}
+		return
+	}
+	o, ok := f.object(n)
+	if !ok {
+		return
+	}
+	f.checkKeys(o, "color", "width", "dash", "dashOffset")
+	if n := o.get("color"); n != nil {
+		if c, ok := f.color(n); ok {
+			p.Color = c
+		}
+	}
+	if n := o.get("width"); n != nil {
+		if w, ok := f.number(n); ok {
+			p.Width = w
+		}
+	}
+	if n := o.get("dash"); n != nil {
+		if lengths, ok := f.numbers(n, 0); ok {
+			if len(lengths) == 0 {
+				p.Dash = nil
+			} else {
+				p.Dash = &gxui.DashPattern{Lengths: lengths}
+			}
+		}
+	}
+	if n := o.get("dashOffset"); n != nil {
+		if offset, ok := f.number(n); ok {
+			if p.Dash == nil {
+				f.errorf(n, "dashOffset requires a dash pattern")
+			} else {
+				dash := *p.Dash
+				dash.Offset = offset
+				p.Dash = &dash
+			}
+		}
+	}
+}
+
+func (f *file) shadow(n *node, s *gxui.Shadow) {
+	if str, ok := n.value.(string); ok && str == "none" {
+		*s = gxui.NoShadow
+		return
+	}
+	o, ok := f.object(n)
+	if !ok {
+		return
+	}
+	f.checkKeys(o, "offset", "blur", "spread", "color")
+	if n := o.get("offset"); n != nil {
+		if p, ok := f.point(n); ok {
+			s.Offset = p
+		}
+	}
+	if n := o.get("blur"); n != nil {
+		if b, ok := f.number(n); ok {
+			s.Blur = b
+		}
+	}
+	if n := o.get("spread"); n != nil {
+		if i, ok := f.integer(n); ok {
+			s.Spread = i
+		}
+	}
+	if n := o.get("color"); n != nil {
+		if c, ok := f.color(n); ok {
+			s.Color = c
+		}
+	}
+}
+
+func (f *file) skin(n *node, s *basic.Style) {
+	if str, ok := n.value.(string); ok && str == "none" {
+		s.Skin = nil
+		return
+	}
+	o, ok := f.object(n)
+	if !ok {
+		return
+	}
+	f.checkKeys(o, "image", "insets", "edges", "center", "scale")
+	var skin gxui.NinePatch
+	if s.Skin != nil {
+		skin = *s.Skin
+	}
+	ok = true
+	if n := o.get("insets"); n != nil {
+		f.spacing(n, &skin.Insets)
+	}
+	if n := o.get("edges"); n != nil {
+		mode, k := f.enum(n, "stretch", "tile")
+		skin.Edges, ok = gxui.PatchMode(mode), ok && k
+	}
+	if n := o.get("center"); n != nil {
+		mode, k := f.enum(n, "stretch", "tile")
+		skin.Center, ok = gxui.PatchMode(mode), ok && k
+	}
+	scale := float32(1)
+	if n := o.get("scale"); n != nil {
+		v, k := f.number(n)
+		if k && v <= 0 {
+			f.errorf(n, "scale must be greater than 0")
+			k = false
+		}
+		scale, ok = v, ok && k
+	}
+	if !ok {
+		return
+	}
+	if n := o.get("image"); n != nil {
+		path, k := f.str(n)
+		if !k {
+			return
+		}
+		img, err := f.image(f.path(path))
+		if err != nil {
+			f.errorf(n, "cannot load image: %v", err)
+			return
+		}
+		skin.Texture = f.loader.driver.CreateTexture(img, scale)
+	} else if skin.Texture == nil {
+		f.errorf(n, "skin requires an image")
+		return
+	}
+	s.Skin = &skin
+}
+
+func (f *file) image(path string) (image.Image, error) {
+	f.loader.files = append(f.loader.files, path)
+	r, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	img, _, err := image.Decode(r)
+	return img, err
+}
+
+// namedColors are the gxui colors, by their lower case names.
+var namedColors = map[string]gxui.Color{
+	"transparent": gxui.Transparent,
+	"black":       gxui.Black,
+	"white":       gxui.White,
+	"yellow":      gxui.Yellow,
+	"red":         gxui.Red,
+	"red10":       gxui.Red10,
+	"red20":       gxui.Red20,
+	"red30":       gxui.Red30,
+	"red40":       gxui.Red40,
+	"red50":       gxui.Red50,
+	"red60":       gxui.Red60,
+	"red70":       gxui.Red70,
+	"red80":       gxui.Red80,
+	"red90":       gxui.Red90,
+	"green":       gxui.Green,
+	"green10":     gxui.Green10,
+	"green20":     gxui.Green20,
+	"green30":     gxui.Green30,
+	"green40":     gxui.Green40,
+	"green50":     gxui.Green50,
+	"green60":     gxui.Green60,
+	"green70":     gxui.Green70,
+	"green80":     gxui.Green80,
+	"green90":     gxui.Green90,
+	"blue":        gxui.Blue,
+	"blue10":      gxui.Blue10,
+	"blue20":      gxui.Blue20,
+	"blue30":      gxui.Blue30,
+	"blue40":      gxui.Blue40,
+	"blue50":      gxui.Blue50,
+	"blue60":      gxui.Blue60,
+	"blue70":      gxui.Blue70,
+	"blue80":      gxui.Blue80,
+	"blue90":      gxui.Blue90,
+	"gray10":      gxui.Gray10,
+	"gray15":      gxui.Gray15,
+	"gray20":      gxui.Gray20,
+	"gray30":      gxui.Gray30,
+	"gray40":      gxui.Gray40,
+	"gray50":      gxui.Gray50,
+	"gray60":      gxui.Gray60,
+	"gray70":      gxui.Gray70,
+	"gray80":      gxui.Gray80,
+	"gray90":      gxui.Gray90,
+}
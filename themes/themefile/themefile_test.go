@@ -0,0 +1,158 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package themefile
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/gxui"
+	"github.com/google/gxui/math"
+	test "github.com/google/gxui/testing"
+	"github.com/google/gxui/themes/basic"
+)
+
+// testDriver is a Driver for controls that are never attached.
+type testDriver struct {
+	gxui.Driver
+}
+
+func (testDriver) AssertUIGoroutine() {}
+
+func testLoader() *loader {
+	l := newLoader(nil)
+	l.bases = map[string]func(gxui.Driver) gxui.Theme{
+		"dark": func(gxui.Driver) gxui.Theme {
+			return &basic.Theme{
+				DriverInfo:         testDriver{},
+				Metrics:            basic.DefaultMetrics,
+				WindowBackground:   gxui.Black,
+				ButtonDefaultStyle: basic.CreateStyle(gxui.Gray80, gxui.Gray10, gxui.Gray20, 2.0),
+			}
+		},
+	}
+	return l
+}
+
+func TestParse(t *testing.T) {
+	tt, err := testLoader().parse("test.json", []byte(`{
+		"colors": {"accent": "#5C8CFF", "accentFaded": "#5C8CFF80"},
+		"windowBackground": "#fff",
+		"metrics": {"buttonPadding": 4, "panelTabPadding": {"l": 8}, "treeIndent": 10},
+		"styles": {
+			"buttonDefault": {"brush": "accent", "pen": "accentFaded"},
+			"bubbleOverlay": {"brush": {"gradient": {"stops": [[0, "black"], [1, "white"]]}}}
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.AssertEquals(t, gxui.White, tt.WindowBackground)
+	test.AssertEquals(t, math.CreateSpacing(4), tt.Metrics.ButtonPadding)
+	test.AssertEquals(t, math.Spacing{L: 8, T: 3, R: 5, B: 3}, tt.Metrics.PanelTabPadding)
+	test.AssertEquals(t, 10, tt.Metrics.TreeIndent)
+	test.AssertEquals(t, basic.DefaultMetrics.LabelMargin, tt.Metrics.LabelMargin)
+
+	accent := gxui.ColorFromHex(0x5C8CFFFF)
+	style := tt.ButtonDefaultStyle
+	test.AssertEquals(t, gxui.Gray80, style.FontColor)
+	test.AssertEquals(t, accent, style.Brush.Color)
+	test.AssertEquals(t, gxui.ColorFromHex(0x5C8CFF80), style.Pen.Color)
+	test.AssertEquals(t, float32(2), style.Pen.Width)
+	test.AssertEquals(t, 2, len(tt.BubbleOverlayStyle.Brush.Gradient.Stops))
+}
+
+func TestParseErrors(t *testing.T) {
+	_, err := testLoader().parse("test.json", []byte(`{
+  "colors": {"accent": "#5C8CFG"},
+  "styles": {
+    "buttonDefault": {"brush": "acent", "pen": {"width": "1"}},
+    "buttonDefualt": {}
+  }
+}`))
+	errs := err.(ErrorList)
+	test.AssertEquals(t, 4, len(errs))
+	test.AssertEquals(t, `test.json:2:24: invalid color "#5C8CFG", expected #RGB, #RRGGBB or #RRGGBBAA`, errs[0].Error())
+	test.AssertEquals(t, `test.json:4:32: unknown color "acent"`, errs[1].Error())
+	test.AssertEquals(t, `test.json:4:58: expected a number`, errs[2].Error())
+	test.AssertEquals(t, &Error{"test.json", 5, 5, errs[3].Message}, errs[3])
+
+	_, err = testLoader().parse("test.json", []byte("{\n  \"inherits\": \"dark\",\n}"))
+	test.AssertEquals(t, 3, err.(ErrorList)[0].Line)
+}
+
+func TestInheritsCycle(t *testing.T) {
+	dir, err := ioutil.TempDir("", "themefile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	a, b := filepath.Join(dir, "a.json"), filepath.Join(dir, "b.json")
+	ioutil.WriteFile(a, []byte(`{"inherits": "b.json"}`), 0644)
+	ioutil.WriteFile(b, []byte(`{"inherits": "a.json"}`), 0644)
+
+	_, files, err := testLoader().load(a)
+	test.AssertEquals(t, []string{a, b}, files)
+	test.AssertEquals(t, `theme "`+a+`" inherits from itself`, err.(ErrorList)[0].Message)
+	test.AssertEquals(t, b, err.(ErrorList)[0].File)
+}
+
+func TestClasses(t *testing.T) {
+	tt, err := testLoader().parse("test.json", []byte(`{
+		"classes": {
+			"primary": {"default": {"brush": "blue", "fontColor": "white"}, "over": {"brush": "blue80"}},
+			"Button.primary": {"default": {"pen": "blue90"}},
+			"Label.primary": {"default": {"pen": "red"}},
+			"danger": {"default": {"brush": "red"}}
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := basic.CreateButton(tt.Theme)
+	base := tt.ButtonDefaultStyle
+	test.AssertEquals(t, base, tt.ResolveStyle(b, "Button", gxui.StyleDefault, base))
+
+	b.AddStyleClass("primary")
+	s := tt.ResolveStyle(b, "Button", gxui.StyleDefault, base)
+	test.AssertEquals(t, gxui.Blue, s.Brush.Color)
+	test.AssertEquals(t, gxui.White, s.FontColor)
+	test.AssertEquals(t, gxui.Blue90, s.Pen.Color)
+	test.AssertEquals(t, float32(1), s.Pen.Width)
+
+	// State variants apply after the defaults of every class.
+	b.AddStyleClass("danger")
+	test.AssertEquals(t, gxui.Red, tt.ResolveStyle(b, "Button", gxui.StyleDefault, base).Brush.Color)
+	test.AssertEquals(t, gxui.Blue80, tt.ResolveStyle(b, "Button", gxui.StyleOver|gxui.StyleFocused, base).Brush.Color)
+
+	// Overrides take precedence over all classes.
+	green := gxui.CreateBrush(gxui.Green)
+	b.SetStyleOverride(gxui.StyleDefault, gxui.StyleOverride{Brush: &green})
+	test.AssertEquals(t, gxui.Green, tt.ResolveStyle(b, "Button", gxui.StyleOver, base).Brush.Color)
+
+	b.RemoveStyleClass("primary")
+	test.AssertEquals(t, []string{"danger"}, b.StyleClasses())
+	test.AssertEquals(t, gxui.Gray20, tt.ResolveStyle(b, "Button", gxui.StyleDefault, base).Pen.Color)
+}
+
+func TestSkinsOnlyOnSkinnedStyles(t *testing.T) {
+	_, err := testLoader().parse("test.json", []byte(`{
+  "styles": {
+    "buttonDefault": {"skin": "none"},
+    "textBoxDefault": {"skin": "none"}
+  },
+  "classes": {
+    "flat": {"default": {"skin": "none"}},
+    "PanelTab.flat": {"default": {"skin": "none"}},
+    "Label.flat": {"default": {"skin": "none"}}
+  }
+}`))
+	errs := err.(ErrorList)
+	test.AssertEquals(t, 2, len(errs))
+	test.AssertEquals(t, `test.json:4:32: skins are only drawn by buttons, tabs and panel backgrounds`, errs[0].Error())
+	test.AssertEquals(t, 9, errs[1].Line)
+}
@@ -0,0 +1,157 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package themefile
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/gxui"
+	"github.com/google/gxui/themes/basic"
+)
+
+// Watcher reloads a theme file whenever it, or any of the files it refers to,
+// changes. Watchers are intended to be used while developing a theme.
+//
+// Reloaded themes are set on the windows added with AddWindow, which passes
+// them on to their controls, so the styles, metrics and fonts of the new
+// theme reach running windows.
+type Watcher struct {
+	driver    gxui.Driver
+	newLoader func(gxui.Driver) *loader
+	path      string
+	theme     *basic.Theme
+	windows   []gxui.Window
+	onReload  gxui.Event
+	onError   gxui.Event
+
+	mutex sync.Mutex
+	files map[string]time.Time // Guarded by mutex.
+	stop  chan struct{}
+	once  sync.Once // Closes stop.
+}
+
+// Watch loads the theme file at path, and then checks the file for changes
+// every interval until Stop is called. Watch must be called on the UI
+// go-routine.
+func Watch(driver gxui.Driver, path string, interval time.Duration) (*Watcher, error) {
+	return watch(driver, path, interval, newLoader)
+}
+
+// watch is Watch, loading the theme with loaders created by newLoader.
+func watch(driver gxui.Driver, path string, interval time.Duration, newLoader func(gxui.Driver) *loader) (*Watcher, error) {
+	t, files, err := newLoader(driver).load(path)
+	if err != nil {
+		return nil, err
+	}
+	w := &Watcher{
+		driver:    driver,
+		newLoader: newLoader,
+		path:      path,
+		theme:     t.Theme,
+		onReload:  gxui.CreateEvent(func() {}),
+		onError:   gxui.CreateEvent(func(error) {}),
+		stop:      make(chan struct{}),
+	}
+	w.setFiles(files)
+	go w.poll(interval)
+	return w, nil
+}
+
+// Theme returns the theme that was loaded last.
+func (w *Watcher) Theme() *basic.Theme {
+	return w.theme
+}
+
+// AddWindow adds a window to set the theme of when the theme is reloaded.
+// The window is removed when it is closed.
+func (w *Watcher) AddWindow(window gxui.Window) {
+	w.windows = append(w.windows, window)
+	window.OnClose(func() {
+		for i, o := range w.windows {
+			if o == window {
+				w.windows = append(w.windows[:i], w.windows[i+1:]...)
+				return
+			}
+		}
+	})
+}
+
+// OnReload subscribes f to be called on the UI go-routine each time the theme
+// is reloaded.
+func (w *Watcher) OnReload(f func()) gxui.EventSubscription {
+	return w.onReload.Listen(f)
+}
+
+// OnError subscribes f to be called on the UI go-routine when a changed theme
+// file fails to load. The previous theme remains in use.
+func (w *Watcher) OnError(f func(error)) gxui.EventSubscription {
+	return w.onError.Listen(f)
+}
+
+// Stop stops checking the theme file for changes. Calling Stop more than once
+// has no effect.
+func (w *Watcher) Stop() {
+	w.once.Do(func() { close(w.stop) })
+}
+
+func (w *Watcher) setFiles(files []string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.files = make(map[string]time.Time)
+	for _, f := range files {
+		w.files[f] = modTime(f)
+	}
+}
+
+func (w *Watcher) changed() bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	for f, t := range w.files {
+		if !modTime(f).Equal(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *Watcher) poll(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			if w.changed() && !w.driver.CallSync(w.reload) {
+				return
+			}
+		}
+	}
+}
+
+func (w *Watcher) reload() {
+	t, files, err := w.newLoader(w.driver).load(w.path)
+	w.setFiles(files)
+	if err != nil {
+		w.onError.Fire(err)
+		return
+	}
+	w.theme = t.Theme
+	for _, window := range w.windows {
+		window.SetTheme(w.theme)
+	}
+	w.onReload.Fire()
+}
+
+// modTime returns the modification time of the file, or the zero time if the
+// file cannot be found.
+func modTime(path string) time.Time {
+	if info, err := os.Stat(path); err == nil {
+		return info.ModTime()
+	}
+	return time.Time{}
+}
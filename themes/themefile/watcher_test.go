@@ -0,0 +1,108 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package themefile
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/gxui"
+	test "github.com/google/gxui/testing"
+)
+
+// testCallDriver is a Driver that runs the functions passed to CallSync when
+// the test calls step, as a UI go-routine would.
+type testCallDriver struct {
+	testDriver
+	calls chan func()
+}
+
+func (d testCallDriver) CallSync(f func()) bool {
+	done := make(chan struct{})
+	d.calls <- func() {
+		f()
+		close(done)
+	}
+	<-done
+	return true
+}
+
+// testWindow is a Window that records the theme set on it.
+type testWindow struct {
+	gxui.Window
+	theme gxui.Theme
+}
+
+func (w *testWindow) SetTheme(theme gxui.Theme)             { w.theme = theme }
+func (w *testWindow) OnClose(func()) gxui.EventSubscription { return nil }
+
+// step runs the next function passed to CallSync.
+func (d testCallDriver) step(t *testing.T) {
+	select {
+	case f := <-d.calls:
+		f()
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the theme to be reloaded")
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	dir, err := ioutil.TempDir("", "themefile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "theme.json")
+
+	// rewrite replaces the theme file, moving its modification time forwards
+	// so the change is seen regardless of the file system's time resolution.
+	modified := time.Now()
+	rewrite := func(data string) {
+		if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+		modified = modified.Add(time.Second)
+		os.Chtimes(path, modified, modified)
+	}
+	rewrite(`{"windowBackground": "black"}`)
+
+	d := testCallDriver{calls: make(chan func())}
+	w, err := watch(d, path, time.Millisecond, func(gxui.Driver) *loader { return testLoader() })
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+	old := w.Theme()
+	test.AssertEquals(t, gxui.Black, old.WindowBackground)
+	window := &testWindow{}
+	w.AddWindow(window)
+
+	reloads, errs := 0, []error{}
+	w.OnReload(func() { reloads++ })
+	w.OnError(func(err error) { errs = append(errs, err) })
+
+	rewrite(`{"windowBackground": "white"}`)
+	d.step(t)
+	test.AssertEquals(t, 1, reloads)
+	test.AssertEquals(t, 0, len(errs))
+	theme := w.Theme()
+	test.AssertEquals(t, gxui.White, theme.WindowBackground)
+	test.AssertEquals(t, true, window.theme == gxui.Theme(theme))
+	test.AssertEquals(t, gxui.Black, old.WindowBackground)
+
+	// Themes that fail to load leave the previous theme in use.
+	rewrite(`{"windowBackground": "whit"}`)
+	d.step(t)
+	test.AssertEquals(t, 1, reloads)
+	test.AssertEquals(t, 1, len(errs))
+	test.AssertEquals(t, true, w.Theme() == theme)
+	test.AssertEquals(t, true, window.theme == gxui.Theme(theme))
+
+	w.Stop()
+	w.Stop() // Stopping again has no effect.
+}
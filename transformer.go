@@ -0,0 +1,56 @@
+// Copyright 2015 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gxui
+
+import (
+	"github.com/google/gxui/math"
+)
+
+// Transformer is the optional interface implemented by Controls that are
+// painted with an affine transform. The transform is applied to the control's
+// local DIP coordinates before the child offset, so for example a rotation
+// turns the control about its top-left corner. Transformers do not affect
+// layout, only painting and hit-testing.
+type Transformer interface {
+	// Transform returns the affine transform of the control.
+	Transform() math.Mat3
+}
+
+// Transform returns the transform of the child's control, or math.Mat3Ident
+// if the control does not implement Transformer.
+func (c *Child) Transform() math.Mat3 {
+	if t, ok := c.Control.(Transformer); ok {
+		return t.Transform()
+	}
+	return math.Mat3Ident
+}
+
+// ToParent maps the point p from the child's coordinate space to the
+// parent's.
+func (c *Child) ToParent(p math.Point) math.Point {
+	if m := c.Transform(); m != math.Mat3Ident {
+		p = p.Transform(m)
+	}
+	return p.Add(c.Offset)
+}
+
+// ToChild maps the point p from the parent's coordinate space to the
+// child's.
+func (c *Child) ToChild(p math.Point) math.Point {
+	p = p.Sub(c.Offset)
+	if m := c.Transform(); m != math.Mat3Ident {
+		p = p.Transform(m.Invert())
+	}
+	return p
+}
+
+// ToParentRect returns the smallest rectangle in the parent's coordinate
+// space that contains the rectangle r in the child's coordinate space.
+func (c *Child) ToParentRect(r math.Rect) math.Rect {
+	if m := c.Transform(); m != math.Mat3Ident {
+		r = r.Transform(m)
+	}
+	return r.Offset(c.Offset)
+}
@@ -38,6 +38,21 @@ func (l ControlPointList) Find(c Control) (math.Point, bool) {
 	return math.Point{}, false
 }
 
+// Cursor returns the cursor of the last control in the list that does not use
+// the DefaultCursor, or def if there is none. If def is also the
+// DefaultCursor, then the ArrowCursor is returned.
+func (l ControlPointList) Cursor(def Cursor) Cursor {
+	for i := len(l) - 1; i >= 0; i-- {
+		if c := l[i].C.Cursor(); !c.IsDefault() {
+			return c
+		}
+	}
+	if def.IsDefault() {
+		return CreateCursor(ArrowCursor)
+	}
+	return def
+}
+
 func ValidateHierarchy(p Parent) {
 	for _, c := range p.Children() {
 		if p != c.Control.Parent() {
@@ -79,7 +94,7 @@ func TopControlsUnder(p math.Point, c Parent) ControlPointList {
 	children := c.Children()
 	for i := len(children) - 1; i >= 0; i-- {
 		child := children[i]
-		cp := p.Sub(child.Offset)
+		cp := child.ToChild(p)
 		if child.Control.ContainsPoint(cp) {
 			l := ControlPointList{ControlPoint{child.Control, cp}}
 			if cc, ok := child.Control.(Parent); ok {
@@ -99,7 +114,7 @@ func ControlsUnder(p math.Point, c Parent) ControlPointList {
 		p = toVisit[0].P
 		toVisit = toVisit[1:]
 		for _, child := range c.Children() {
-			cp := p.Sub(child.Offset)
+			cp := child.ToChild(p)
 			if child.Control.ContainsPoint(cp) {
 				l = append(l, ControlPoint{child.Control, cp})
 				if cc, ok := child.Control.(Parent); ok {
@@ -112,6 +127,8 @@ func ControlsUnder(p math.Point, c Parent) ControlPointList {
 }
 
 func WindowToChild(coord math.Point, to Control) math.Point {
+	// Transforms are applied top-down, so gather the path from the window.
+	path := []*Child{}
 	c := to
 	for {
 		p := c.Parent()
@@ -123,12 +140,16 @@ func WindowToChild(coord math.Point, to Control) math.Point {
 			Dump(p)
 			panic(fmt.Errorf("Control's parent (%p %T) did not contain control (%p %T).", &p, p, &c, c))
 		}
-		coord = coord.Sub(child.Offset)
+		path = append(path, child)
 		if _, ok := p.(Window); ok {
-			return coord
+			break
 		}
 		c = p.(Control)
 	}
+	for i := len(path) - 1; i >= 0; i-- {
+		coord = path[i].ToChild(coord)
+	}
+	return coord
 }
 
 func ChildToParent(coord math.Point, from Control, to Parent) math.Point {
@@ -143,7 +164,7 @@ func ChildToParent(coord math.Point, from Control, to Parent) math.Point {
 			Dump(p)
 			panic(fmt.Errorf("Control's parent (%p %T) did not contain control (%p %T).", &p, p, &c, c))
 		}
-		coord = coord.Add(child.Offset)
+		coord = child.ToParent(coord)
 		if p == to {
 			return coord
 		}
@@ -159,7 +180,35 @@ func ChildToParent(coord math.Point, from Control, to Parent) math.Point {
 }
 
 func ParentToChild(coord math.Point, from Parent, to Control) math.Point {
-	return coord.Sub(ChildToParent(math.ZeroPoint, to, from))
+	// Transforms are applied top-down, so gather the path from the parent.
+	path := []*Child{}
+	c := to
+	for {
+		p := c.Parent()
+		if p == nil {
+			panic(fmt.Errorf("Control detached: %s", Path(c)))
+		}
+		child := p.Children().Find(c)
+		if child == nil {
+			Dump(p)
+			panic(fmt.Errorf("Control's parent (%p %T) did not contain control (%p %T).", &p, p, &c, c))
+		}
+		path = append(path, child)
+		if p == from {
+			break
+		}
+		if control, ok := p.(Control); ok {
+			c = control
+		} else {
+			Dump(p)
+			panic(fmt.Errorf("ParentToChild (%p %T) -> (%p %T) reached non-control parent (%p %T).",
+				&from, from, &to, to, &p, p))
+		}
+	}
+	for i := len(path) - 1; i >= 0; i-- {
+		coord = path[i].ToChild(coord)
+	}
+	return coord
 }
 
 func TransformCoordinate(coord math.Point, from, to Control) math.Point {
@@ -5,6 +5,8 @@
 package gxui
 
 import (
+	"time"
+
 	"github.com/google/gxui/math"
 )
 
@@ -22,14 +24,33 @@ type Viewport interface {
 	// SizePixels returns the size of the viewport in pixels.
 	SizePixels() math.Size
 
-	// Scale returns the display scaling for this viewport.
+	// Scale returns the display scaling for this viewport, which is the
+	// content scale of the monitor showing the viewport multiplied by the
+	// scale set with SetScale.
 	// A scale of 1 is unscaled, 2 is twice the regular scaling.
 	Scale() float32
 
-	// SetScale alters the display scaling for this viewport.
-	// A scale of 1 is unscaled, 2 is twice the regular scaling.
+	// SetScale alters the display scaling for this viewport, relative to the
+	// content scale of the monitor showing the viewport.
+	// A scale of 1 is the monitor's scaling, 2 is twice the monitor's scaling.
 	SetScale(float32)
 
+	// OnScaleChanged subscribes f to be called whenever Scale changes, either
+	// by a call to SetScale or by the viewport moving to a monitor with a
+	// different content scale. Textures rasterized for the previous scale
+	// should be recreated.
+	OnScaleChanged(f func()) EventSubscription
+
+	// RenderOptions returns the anti-aliasing options used to draw the
+	// viewport. Samples is the number of multisamples the platform provided,
+	// which may differ from the number requested.
+	RenderOptions() RenderOptions
+
+	// SetRenderOptions changes the anti-aliasing options used to draw the
+	// viewport, and redraws it. Samples cannot be changed once the viewport is
+	// created, and is ignored.
+	SetRenderOptions(RenderOptions)
+
 	// Fullscreen returns true if the viewport was created full-screen.
 	Fullscreen() bool
 
@@ -46,6 +67,11 @@ type Viewport interface {
 	// SetPosition changes position of the window.
 	SetPosition(math.Point)
 
+	// SetCursor changes the mouse cursor shown while the pointer is over the
+	// viewport. Standard shapes without an equivalent on the platform are
+	// shown as the arrow.
+	SetCursor(Cursor)
+
 	// Show makes the window visible.
 	Show()
 
@@ -61,6 +87,19 @@ type Viewport interface {
 	// viewport will require a call to SetCanvas.
 	SetCanvas(Canvas)
 
+	// SetCanvasRegion changes the displayed content of the viewport to the
+	// specified Canvas, like SetCanvas, but only repaints the dirty rectangle
+	// (in device-independent pixels). The rest of the viewport is presumed to
+	// be unchanged since the last call to SetCanvas or SetCanvasRegion.
+	SetCanvasRegion(c Canvas, dirty math.Rect)
+
+	// RequestFrame calls f once, on the UI go-routine, before the next frame
+	// is drawn. Requests made before the next frame begins are batched, and a
+	// frame is drawn for them even if the canvas has not changed. Frames are
+	// produced at the refresh rate of the display. f is called with the time
+	// of the frame.
+	RequestFrame(f func(frameTime time.Time))
+
 	// OnClose subscribes f to be called when the viewport closes.
 	OnClose(f func()) EventSubscription
 
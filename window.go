@@ -5,11 +5,14 @@
 package gxui
 
 import (
+	"time"
+
 	"github.com/google/gxui/math"
 )
 
 type Window interface {
 	Container
+	RoutedEventTarget
 
 	// Title returns the title of the window.
 	// This is usually the text displayed at the top of the window.
@@ -18,14 +21,26 @@ type Window interface {
 	// SetTitle changes the title of the window.
 	SetTitle(string)
 
-	// Scale returns the display scaling for this window.
+	// Scale returns the display scaling for this window, which is the
+	// content scale of the monitor showing the window multiplied by the scale
+	// set with SetScale.
 	// A scale of 1 is unscaled, 2 is twice the regular scaling.
 	Scale() float32
 
-	// SetScale alters the display scaling for this window.
-	// A scale of 1 is unscaled, 2 is twice the regular scaling.
+	// SetScale alters the display scaling for this window, relative to the
+	// content scale of the monitor showing the window.
+	// A scale of 1 is the monitor's scaling, 2 is twice the monitor's scaling.
 	SetScale(float32)
 
+	// LayoutDirection returns the layout direction set with
+	// SetLayoutDirection.
+	LayoutDirection() LayoutDirection
+
+	// SetLayoutDirection sets the horizontal direction in which the window's
+	// controls lay out their content, unless overridden by a control.
+	// SetLayoutDirection issues a relayout of the entire window.
+	SetLayoutDirection(LayoutDirection)
+
 	// Position returns position of the window.
 	Position() math.Point
 
@@ -48,6 +63,59 @@ type Window interface {
 	// Once the window is closed, no further calls should be made to it.
 	Close()
 
+	// RequestFrame calls f once, on the UI go-routine, before the window's
+	// next frame is drawn. f is called with the time of the frame.
+	RequestFrame(f func(frameTime time.Time))
+
+	// Animator returns the Animator that steps animations once per frame of
+	// the window.
+	Animator() *Animator
+
+	// Theme returns the theme of the window.
+	Theme() Theme
+
+	// SetTheme changes the theme of the window and of every control in the
+	// window, which re-resolve their styles, fonts and metrics from the theme.
+	// The state of the controls, such as the text of a TextBox, is preserved.
+	SetTheme(Theme)
+
+	// OnThemeChanged subscribes f to be called whenever the theme of the
+	// window is changed with SetTheme.
+	OnThemeChanged(f func()) EventSubscription
+
+	// Viewport returns the viewport displaying the window.
+	Viewport() Viewport
+
+	// Cursor returns the mouse cursor set with SetCursor.
+	Cursor() Cursor
+
+	// SetCursor sets the mouse cursor shown while the pointer is over the
+	// window and not over a control that sets its own cursor. The
+	// DefaultCursor shows the arrow.
+	SetCursor(Cursor)
+
+	// UpdateCursor shows the cursor of the top-most control under the mouse
+	// pointer that sets a cursor, or the window's cursor if there is none.
+	// Controls call UpdateCursor when their cursor changes.
+	UpdateCursor()
+
+	// Keymap returns the keymap of the window, which binds key chords to
+	// commands.
+	Keymap() *Keymap
+
+	// CaptureMouse routes all the mouse events of the window to the control
+	// and its ancestors until the capture is released, wherever the mouse
+	// pointer is. Controls capture the mouse to track drags that leave their
+	// bounds. If another control has captured the mouse, it loses the capture.
+	CaptureMouse(Control)
+
+	// ReleaseMouse releases the mouse capture, calling MouseCaptureLost on
+	// the control that had captured the mouse.
+	ReleaseMouse()
+
+	// MouseCapture returns the control that has captured the mouse, or nil.
+	MouseCapture() Control
+
 	// Focus returns the control currently with focus.
 	Focus() Focusable
 
@@ -75,6 +143,7 @@ type Window interface {
 	// Events
 	OnClose(func()) EventSubscription
 	OnResize(func()) EventSubscription
+	OnScaleChanged(func()) EventSubscription
 	OnClick(func(MouseEvent)) EventSubscription
 	OnDoubleClick(func(MouseEvent)) EventSubscription
 	OnMouseMove(func(MouseEvent)) EventSubscription